@@ -0,0 +1,111 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// permissionsObjectPath returns the path of the object in permissions API. Notebooks
+// and directories could be referenced either by their numeric ID or by workspace path.
+func permissionsObjectPath(ctx context.Context, client *common.DatabricksClient,
+	objectType, objectID string) (string, error) {
+	byPath := strings.HasPrefix(objectID, "/")
+	for _, mapping := range permissionsResourceIDFields(ctx) {
+		if mapping.objectType != objectType {
+			continue
+		}
+		if strings.HasSuffix(mapping.field, "_path") != byPath {
+			continue
+		}
+		id, err := mapping.idRetriever(client, objectID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("/%s/%s", mapping.resourceType, id), nil
+	}
+	return "", fmt.Errorf("cannot read permissions of %s %s", objectType, objectID)
+}
+
+// withoutInherited returns access control entries with only direct permissions
+func withoutInherited(acl []AccessControl) (direct []AccessControl) {
+	direct = []AccessControl{}
+	for _, ac := range acl {
+		if ac.PermissionLevel != "" {
+			// SQLA entities have no inherited permissions
+			direct = append(direct, ac)
+			continue
+		}
+		permissions := []Permission{}
+		for _, permission := range ac.AllPermissions {
+			if permission.Inherited {
+				continue
+			}
+			permissions = append(permissions, permission)
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+		ac.AllPermissions = permissions
+		direct = append(direct, ac)
+	}
+	return
+}
+
+// DataSourcePermissions reads permission assignments of an object without managing them
+func DataSourcePermissions() *schema.Resource {
+	type entity struct {
+		ObjectType        string          `json:"object_type"`
+		ObjectID          string          `json:"object_id"`
+		DirectOnly        bool            `json:"direct_only,omitempty"`
+		AccessControlList []AccessControl `json:"access_control_list,omitempty" tf:"computed"`
+	}
+	objectTypes := []string{}
+	for _, mapping := range permissionsResourceIDFields(context.Background()) {
+		if !stringInSlice(mapping.objectType, objectTypes) {
+			objectTypes = append(objectTypes, mapping.objectType)
+		}
+	}
+	sort.Strings(objectTypes)
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["object_type"].ValidateFunc = validation.StringInSlice(objectTypes, false)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			objectPath, err := permissionsObjectPath(ctx, m.(*common.DatabricksClient),
+				this.ObjectType, this.ObjectID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			objectACL, err := NewPermissionsAPI(ctx, m).Read(objectPath)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.AccessControlList = objectACL.AccessControlList
+			if this.DirectOnly {
+				this.AccessControlList = withoutInherited(this.AccessControlList)
+			}
+			d.SetId(objectPath)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,154 @@
+package access
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/databrickslabs/terraform-provider-databricks/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var notebookACLFixture = qa.HTTPFixture{
+	Method:   http.MethodGet,
+	Resource: "/api/2.0/permissions/notebooks/988765",
+	Response: ObjectACL{
+		ObjectID:   "/notebooks/988765",
+		ObjectType: "notebook",
+		AccessControlList: []AccessControl{
+			{
+				UserName: TestingUser,
+				AllPermissions: []Permission{
+					{
+						PermissionLevel: "CAN_RUN",
+					},
+					{
+						PermissionLevel:     "CAN_READ",
+						Inherited:           true,
+						InheritedFromObject: []string{"/directories/123"},
+					},
+				},
+			},
+			{
+				GroupName: "admins",
+				AllPermissions: []Permission{
+					{
+						PermissionLevel:     "CAN_MANAGE",
+						Inherited:           true,
+						InheritedFromObject: []string{"/directories/"},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestDataSourcePermissions(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{notebookACLFixture},
+		Resource:    DataSourcePermissions(),
+		Read:        true,
+		NonWritable: true,
+		HCL: `
+		object_type = "notebook"
+		object_id = "988765"`,
+		ID: "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/notebooks/988765", d.Id())
+	assert.Equal(t, 2, d.Get("access_control_list.#"))
+	assert.Equal(t, 2, d.Get("access_control_list.0.all_permissions.#"))
+	assert.Equal(t, true, d.Get("access_control_list.0.all_permissions.1.inherited"))
+	assert.Equal(t, "admins", d.Get("access_control_list.1.group_name"))
+}
+
+func TestDataSourcePermissions_DirectOnlyByPath(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FDevelopment%2FInit",
+				Response: workspace.ObjectStatus{
+					ObjectID:   988765,
+					ObjectType: "NOTEBOOK",
+				},
+			},
+			notebookACLFixture,
+		},
+		Resource:    DataSourcePermissions(),
+		Read:        true,
+		NonWritable: true,
+		HCL: `
+		object_type = "notebook"
+		object_id = "/Development/Init"
+		direct_only = true`,
+		ID: "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/notebooks/988765", d.Id())
+	assert.Equal(t, 1, d.Get("access_control_list.#"))
+	assert.Equal(t, TestingUser, d.Get("access_control_list.0.user_name"))
+	assert.Equal(t, 1, d.Get("access_control_list.0.all_permissions.#"))
+	assert.Equal(t, "CAN_RUN", d.Get("access_control_list.0.all_permissions.0.permission_level"))
+}
+
+func TestDataSourcePermissions_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/permissions/clusters/abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Cluster does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Resource:    DataSourcePermissions(),
+		Read:        true,
+		NonWritable: true,
+		HCL: `
+		object_type = "cluster"
+		object_id = "abc"`,
+		ID: "_",
+	}.ExpectError(t, "Cluster does not exist")
+}
+
+func TestDataSourcePermissions_PathNotSupported(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourcePermissions(),
+		Read:        true,
+		NonWritable: true,
+		HCL: `
+		object_type = "cluster"
+		object_id = "/abc"`,
+		ID: "_",
+	}.ExpectError(t, "cannot read permissions of cluster /abc")
+}
+
+func TestWithoutInherited_SQLA(t *testing.T) {
+	direct := withoutInherited([]AccessControl{
+		{
+			GroupName:       "users",
+			PermissionLevel: "CAN_RUN",
+		},
+		{
+			GroupName: "admins",
+			AllPermissions: []Permission{
+				{
+					PermissionLevel: "CAN_MANAGE",
+					Inherited:       true,
+				},
+			},
+		},
+	})
+	assert.Equal(t, []AccessControl{
+		{
+			GroupName:       "users",
+			PermissionLevel: "CAN_RUN",
+		},
+	}, direct)
+}
@@ -161,7 +161,7 @@ var createHighConcurrencyCluster = []qa.HTTPFixture{
 	{
 		Method:       "GET",
 		ReuseRequest: true,
-		Resource:     "/api/2.0/clusters/list",
+		Resource:     "/api/2.1/clusters/list?page_size=100",
 		Response:     map[string]interface{}{},
 	},
 	{
@@ -0,0 +1,34 @@
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/internal/acceptance"
+)
+
+func TestUcAccArtifactAllowlistResourceFullLifecycle(t *testing.T) {
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `resource "databricks_artifact_allowlist" "init" {
+				artifact_type = "INIT_SCRIPT"
+				artifact_matchers {
+					artifact = "/Volumes/inits/tf-{var.RANDOM}"
+					match_type = "PREFIX_MATCH"
+				}
+			}`,
+		},
+		{
+			Template: `resource "databricks_artifact_allowlist" "init" {
+				artifact_type = "INIT_SCRIPT"
+				artifact_matchers {
+					artifact = "/Volumes/inits/tf-{var.RANDOM}"
+					match_type = "PREFIX_MATCH"
+				}
+				artifact_matchers {
+					artifact = "/Volumes/shared/tf-{var.RANDOM}"
+					match_type = "PREFIX_MATCH"
+				}
+			}`,
+		},
+	})
+}
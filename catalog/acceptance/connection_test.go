@@ -0,0 +1,38 @@
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/internal/acceptance"
+)
+
+func TestUcAccConnectionsResourceFullLifecycle(t *testing.T) {
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `resource "databricks_connection" "this" {
+				name = "tf-{var.RANDOM}"
+				connection_type = "MYSQL"
+				comment = "created by terraform"
+				options = {
+					host     = "test.mysql.database.azure.com"
+					port     = "3306"
+					user     = "user"
+					password = "password"
+				}
+			}`,
+		},
+		{
+			Template: `resource "databricks_connection" "this" {
+				name = "tf-{var.RANDOM}"
+				connection_type = "MYSQL"
+				comment = "created by terraform"
+				options = {
+					host     = "test.mysql.database.azure.com"
+					port     = "3307"
+					user     = "user"
+					password = "password"
+				}
+			}`,
+		},
+	})
+}
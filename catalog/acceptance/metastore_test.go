@@ -0,0 +1,43 @@
+package acceptance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/internal/acceptance"
+)
+
+func TestUcAccMetastoreAssignmentFullLifecycle(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Cannot run test on non-MWS environment")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `resource "databricks_metastore" "this" {
+				name          = "tf-{var.RANDOM}"
+				storage_root  = "s3://{env.TEST_BUCKET}/metastore"
+				region        = "{env.AWS_REGION}"
+				force_destroy = true
+			}
+			resource "databricks_metastore_assignment" "this" {
+				workspace_id         = {env.TEST_WORKSPACE_ID}
+				metastore_id         = databricks_metastore.this.id
+				default_catalog_name = "hive_metastore"
+			}`,
+		},
+		{
+			Template: `resource "databricks_metastore" "this" {
+				name          = "tf-{var.RANDOM}-renamed"
+				storage_root  = "s3://{env.TEST_BUCKET}/metastore"
+				region        = "{env.AWS_REGION}"
+				force_destroy = true
+			}
+			resource "databricks_metastore_assignment" "this" {
+				workspace_id         = {env.TEST_WORKSPACE_ID}
+				metastore_id         = databricks_metastore.this.id
+				default_catalog_name = "main"
+			}`,
+		},
+	})
+}
@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"context"
+	"log"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ArtifactMatcher is a pattern of artifacts, that are allowed to be used on shared clusters
+type ArtifactMatcher struct {
+	Artifact  string `json:"artifact"`
+	MatchType string `json:"match_type"`
+}
+
+// ArtifactAllowlist lists artifacts of the given type, that could be installed on clusters
+// with Unity Catalog. There is exactly one allowlist per artifact type in the metastore.
+type ArtifactAllowlist struct {
+	ArtifactType     string            `json:"artifact_type" tf:"force_new"`
+	ArtifactMatchers []ArtifactMatcher `json:"artifact_matchers" tf:"slice_set"`
+	MetastoreID      string            `json:"metastore_id,omitempty" tf:"computed"`
+	CreatedAt        int64             `json:"created_at,omitempty" tf:"computed"`
+	CreatedBy        string            `json:"created_by,omitempty" tf:"computed"`
+}
+
+type artifactMatchersUpdate struct {
+	ArtifactMatchers []ArtifactMatcher `json:"artifact_matchers"`
+}
+
+var artifactTypes = []string{"INIT_SCRIPT", "LIBRARY_JAR", "LIBRARY_MAVEN", "LIBRARY_PYTHON_WHEEL"}
+
+var artifactMatchTypes = []string{"PREFIX", "PREFIX_MATCH"}
+
+// NewArtifactAllowlistsAPI creates ArtifactAllowlistsAPI instance from provider meta
+func NewArtifactAllowlistsAPI(ctx context.Context, m interface{}) ArtifactAllowlistsAPI {
+	return ArtifactAllowlistsAPI{
+		client:  m.(*common.DatabricksClient),
+		context: context.WithValue(ctx, common.Api, common.API_2_1),
+	}
+}
+
+// ArtifactAllowlistsAPI exposes the Unity Catalog artifact allowlists API
+type ArtifactAllowlistsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Get returns the allowlist of the artifact type
+func (a ArtifactAllowlistsAPI) Get(artifactType string) (aa ArtifactAllowlist, err error) {
+	err = a.client.Get(a.context, "/unity-catalog/artifact-allowlists/"+artifactType, nil, &aa)
+	aa.ArtifactType = artifactType
+	return
+}
+
+// Update replaces all matchers of the artifact type allowlist
+func (a ArtifactAllowlistsAPI) Update(artifactType string, matchers []ArtifactMatcher) error {
+	if matchers == nil {
+		matchers = []ArtifactMatcher{}
+	}
+	return a.client.Patch(a.context, "/unity-catalog/artifact-allowlists/"+artifactType,
+		artifactMatchersUpdate{
+			ArtifactMatchers: matchers,
+		})
+}
+
+// ResourceArtifactAllowlist manages the allowlist of artifacts for Unity Catalog clusters
+func ResourceArtifactAllowlist() *schema.Resource {
+	s := common.StructToSchema(ArtifactAllowlist{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["artifact_type"].ValidateFunc = validation.StringInSlice(artifactTypes, false)
+			common.MustSchemaPath(m, "artifact_matchers", "match_type").ValidateFunc =
+				validation.StringInSlice(artifactMatchTypes, false)
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var aa ArtifactAllowlist
+			if err := common.DataToStructPointer(d, s, &aa); err != nil {
+				return err
+			}
+			allowlistsAPI := NewArtifactAllowlistsAPI(ctx, c)
+			// allowlist always exists, so the current one is replaced
+			current, err := allowlistsAPI.Get(aa.ArtifactType)
+			if err != nil {
+				return err
+			}
+			if len(current.ArtifactMatchers) > 0 {
+				log.Printf("[INFO] Replacing %d existing matchers of %s allowlist",
+					len(current.ArtifactMatchers), aa.ArtifactType)
+			}
+			err = allowlistsAPI.Update(aa.ArtifactType, aa.ArtifactMatchers)
+			if err != nil {
+				return err
+			}
+			d.SetId(aa.ArtifactType)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			aa, err := NewArtifactAllowlistsAPI(ctx, c).Get(d.Id())
+			if err != nil {
+				return err
+			}
+			return common.StructToData(aa, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var aa ArtifactAllowlist
+			if err := common.DataToStructPointer(d, s, &aa); err != nil {
+				return err
+			}
+			return NewArtifactAllowlistsAPI(ctx, c).Update(d.Id(), aa.ArtifactMatchers)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// allowlist cannot be removed, so it's emptied instead
+			return NewArtifactAllowlistsAPI(ctx, c).Update(d.Id(), nil)
+		},
+	}.ToResource()
+}
@@ -0,0 +1,187 @@
+package catalog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactAllowlistCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceArtifactAllowlist())
+}
+
+var initScriptsAllowlist = ArtifactAllowlist{
+	ArtifactType: "INIT_SCRIPT",
+	ArtifactMatchers: []ArtifactMatcher{
+		{
+			Artifact:  "/Volumes/inits",
+			MatchType: "PREFIX_MATCH",
+		},
+	},
+	MetastoreID: "abc",
+	CreatedBy:   "admin@example.com",
+}
+
+func TestArtifactAllowlistCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/INIT_SCRIPT",
+				Response: ArtifactAllowlist{
+					ArtifactMatchers: []ArtifactMatcher{
+						{
+							Artifact:  "/Volumes/old",
+							MatchType: "PREFIX_MATCH",
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/INIT_SCRIPT",
+				ExpectedRequest: artifactMatchersUpdate{
+					ArtifactMatchers: initScriptsAllowlist.ArtifactMatchers,
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				Resource:     "/api/2.1/unity-catalog/artifact-allowlists/INIT_SCRIPT",
+				Response:     initScriptsAllowlist,
+				ReuseRequest: true,
+			},
+		},
+		Resource: ResourceArtifactAllowlist(),
+		Create:   true,
+		HCL: `
+		artifact_type = "INIT_SCRIPT"
+		artifact_matchers {
+			artifact = "/Volumes/inits"
+			match_type = "PREFIX_MATCH"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "INIT_SCRIPT", d.Id())
+	assert.Equal(t, "abc", d.Get("metastore_id"))
+	assert.Equal(t, "admin@example.com", d.Get("created_by"))
+	assert.Equal(t, 1, d.Get("artifact_matchers.#"))
+}
+
+func TestArtifactAllowlistCreate_InvalidMatchType(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceArtifactAllowlist(),
+		Create:   true,
+		HCL: `
+		artifact_type = "LIBRARY_JAR"
+		artifact_matchers {
+			artifact = "/Volumes/jars"
+			match_type = "SUFFIX"
+		}`,
+	}.ExpectError(t, "invalid config supplied. [artifact_matchers] "+
+		"expected artifact_matchers.0.match_type to be one of [PREFIX PREFIX_MATCH], got SUFFIX")
+}
+
+func TestArtifactAllowlistRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/INIT_SCRIPT",
+				Response: initScriptsAllowlist,
+			},
+		},
+		Resource: ResourceArtifactAllowlist(),
+		Read:     true,
+		New:      true,
+		ID:       "INIT_SCRIPT",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "INIT_SCRIPT", d.Get("artifact_type"))
+	assert.Equal(t, 1, d.Get("artifact_matchers.#"))
+}
+
+func TestArtifactAllowlistUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/LIBRARY_MAVEN",
+				ExpectedRequest: artifactMatchersUpdate{
+					ArtifactMatchers: []ArtifactMatcher{
+						{
+							Artifact:  "com.example:lib",
+							MatchType: "PREFIX",
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/LIBRARY_MAVEN",
+				Response: ArtifactAllowlist{
+					ArtifactMatchers: []ArtifactMatcher{
+						{
+							Artifact:  "com.example:lib",
+							MatchType: "PREFIX",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceArtifactAllowlist(),
+		Update:   true,
+		ID:       "LIBRARY_MAVEN",
+		InstanceState: map[string]string{
+			"artifact_type": "LIBRARY_MAVEN",
+		},
+		HCL: `
+		artifact_type = "LIBRARY_MAVEN"
+		artifact_matchers {
+			artifact = "com.example:lib"
+			match_type = "PREFIX"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "LIBRARY_MAVEN", d.Id())
+}
+
+func TestArtifactAllowlistDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/INIT_SCRIPT",
+				ExpectedRequest: map[string]interface{}{
+					"artifact_matchers": []interface{}{},
+				},
+			},
+		},
+		Resource: ResourceArtifactAllowlist(),
+		Delete:   true,
+		ID:       "INIT_SCRIPT",
+	}.Apply(t)
+	require.NoError(t, err, err)
+}
+
+func TestArtifactAllowlistRead_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/artifact-allowlists/INIT_SCRIPT",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "Only metastore admins can read allowlists",
+				},
+				Status: 403,
+			},
+		},
+		Resource: ResourceArtifactAllowlist(),
+		Read:     true,
+		ID:       "INIT_SCRIPT",
+	}.ExpectError(t, "Only metastore admins can read allowlists")
+}
@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ConnectionInfo is the Unity Catalog connection to an external database, used by lakehouse federation
+type ConnectionInfo struct {
+	Name           string            `json:"name"`
+	ConnectionType string            `json:"connection_type"`
+	Options        map[string]string `json:"options"`
+	Comment        string            `json:"comment,omitempty"`
+	Properties     map[string]string `json:"properties,omitempty"`
+	Owner          string            `json:"owner,omitempty" tf:"computed"`
+	FullName       string            `json:"full_name,omitempty" tf:"computed"`
+}
+
+type updateConnection struct {
+	Options map[string]string `json:"options"`
+}
+
+// requiredConnectionOptions lists option keys, without which connection of given type cannot be created
+var requiredConnectionOptions = map[string][]string{
+	"MYSQL":      {"host", "port", "user", "password"},
+	"POSTGRESQL": {"host", "port", "user", "password"},
+	"REDSHIFT":   {"host", "port", "user", "password"},
+	"SNOWFLAKE":  {"host", "port", "sfWarehouse", "user", "password"},
+	"SQLDW":      {"host", "port", "user", "password"},
+	"SQLSERVER":  {"host", "port", "user", "password"},
+	"DATABRICKS": {"host", "httpPath", "personalAccessToken"},
+}
+
+// NewConnectionsAPI creates ConnectionsAPI instance from provider meta
+func NewConnectionsAPI(ctx context.Context, m interface{}) ConnectionsAPI {
+	return ConnectionsAPI{
+		client:  m.(*common.DatabricksClient),
+		context: context.WithValue(ctx, common.Api, common.API_2_1),
+	}
+}
+
+// ConnectionsAPI exposes the Unity Catalog connections API
+type ConnectionsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Create creates a connection
+func (a ConnectionsAPI) Create(ci ConnectionInfo) (info ConnectionInfo, err error) {
+	err = a.client.Post(a.context, "/unity-catalog/connections", ci, &info)
+	return
+}
+
+// Get returns connection by name
+func (a ConnectionsAPI) Get(name string) (info ConnectionInfo, err error) {
+	err = a.client.Get(a.context, "/unity-catalog/connections/"+name, nil, &info)
+	return
+}
+
+// Update changes options of the connection
+func (a ConnectionsAPI) Update(name string, options map[string]string) error {
+	return a.client.Patch(a.context, "/unity-catalog/connections/"+name, updateConnection{
+		Options: options,
+	})
+}
+
+// Delete removes the connection
+func (a ConnectionsAPI) Delete(name string) error {
+	return a.client.Delete(a.context, "/unity-catalog/connections/"+name, nil)
+}
+
+func validateConnectionOptions(connectionType string, options map[string]string) error {
+	var missing []string
+	for _, key := range requiredConnectionOptions[connectionType] {
+		if options[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s connection requires options: %s",
+		connectionType, strings.Join(missing, ", "))
+}
+
+// ResourceConnection manages Unity Catalog connections
+func ResourceConnection() *schema.Resource {
+	connectionTypes := []string{}
+	for k := range requiredConnectionOptions {
+		connectionTypes = append(connectionTypes, k)
+	}
+	sort.Strings(connectionTypes)
+	s := common.StructToSchema(ConnectionInfo{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["name"].ForceNew = true
+			m["connection_type"].ForceNew = true
+			m["connection_type"].ValidateFunc = validation.StringInSlice(connectionTypes, false)
+			m["options"].Sensitive = true
+			m["comment"].ForceNew = true
+			m["properties"].ForceNew = true
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if !d.NewValueKnown("options") {
+				return nil
+			}
+			options := map[string]string{}
+			for k, v := range d.Get("options").(map[string]interface{}) {
+				options[k] = v.(string)
+			}
+			return validateConnectionOptions(d.Get("connection_type").(string), options)
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var ci ConnectionInfo
+			if err := common.DataToStructPointer(d, s, &ci); err != nil {
+				return err
+			}
+			info, err := NewConnectionsAPI(ctx, c).Create(ci)
+			if err != nil {
+				return err
+			}
+			d.SetId(info.Name)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			info, err := NewConnectionsAPI(ctx, c).Get(d.Id())
+			if err != nil {
+				return err
+			}
+			// secret options, like password, are never returned by the API
+			if info.Options == nil {
+				info.Options = map[string]string{}
+			}
+			for k, v := range d.Get("options").(map[string]interface{}) {
+				if _, ok := info.Options[k]; !ok {
+					info.Options[k] = v.(string)
+				}
+			}
+			return common.StructToData(info, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var ci ConnectionInfo
+			if err := common.DataToStructPointer(d, s, &ci); err != nil {
+				return err
+			}
+			return NewConnectionsAPI(ctx, c).Update(d.Id(), ci.Options)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewConnectionsAPI(ctx, c).Delete(d.Id())
+		},
+	}.ToResource()
+}
@@ -0,0 +1,167 @@
+package catalog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceConnection())
+}
+
+func TestConnectionCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.1/unity-catalog/connections",
+				ExpectedRequest: ConnectionInfo{
+					Name:           "mysql",
+					ConnectionType: "MYSQL",
+					Comment:        "federated",
+					Options: map[string]string{
+						"host":     "mysql.example.com",
+						"port":     "3306",
+						"user":     "admin",
+						"password": "secret",
+					},
+				},
+				Response: ConnectionInfo{
+					Name: "mysql",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/connections/mysql",
+				Response: ConnectionInfo{
+					Name:           "mysql",
+					ConnectionType: "MYSQL",
+					Comment:        "federated",
+					Options: map[string]string{
+						"host": "mysql.example.com",
+						"port": "3306",
+						"user": "admin",
+					},
+					Owner:    "admin@example.com",
+					FullName: "mysql",
+				},
+			},
+		},
+		Resource: ResourceConnection(),
+		Create:   true,
+		HCL: `
+		name = "mysql"
+		connection_type = "MYSQL"
+		comment = "federated"
+		options = {
+			host     = "mysql.example.com"
+			port     = "3306"
+			user     = "admin"
+			password = "secret"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "mysql", d.Id())
+	assert.Equal(t, "admin@example.com", d.Get("owner"))
+	assert.Equal(t, "mysql", d.Get("full_name"))
+	assert.Equal(t, "secret", d.Get("options.password"))
+}
+
+func TestConnectionCreate_MissingOptions(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceConnection(),
+		Create:   true,
+		HCL: `
+		name = "pg"
+		connection_type = "POSTGRESQL"
+		options = {
+			host = "pg.example.com"
+			user = "admin"
+		}`,
+	}.ExpectError(t, "POSTGRESQL connection requires options: port, password")
+}
+
+func TestConnectionCreate_InvalidType(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceConnection(),
+		Create:   true,
+		HCL: `
+		name = "abc"
+		connection_type = "ORACLE"
+		options = {
+			host = "abc"
+		}`,
+	}.ExpectError(t, "invalid config supplied. [connection_type] expected connection_type to be one of [DATABRICKS MYSQL POSTGRESQL REDSHIFT SNOWFLAKE SQLDW SQLSERVER], got ORACLE")
+}
+
+func TestConnectionUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.1/unity-catalog/connections/pg",
+				ExpectedRequest: updateConnection{
+					Options: map[string]string{
+						"host":     "new.example.com",
+						"port":     "5432",
+						"user":     "admin",
+						"password": "secret",
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.1/unity-catalog/connections/pg",
+				Response: ConnectionInfo{
+					Name:           "pg",
+					ConnectionType: "POSTGRESQL",
+					Options: map[string]string{
+						"host": "new.example.com",
+						"port": "5432",
+						"user": "admin",
+					},
+					FullName: "pg",
+				},
+			},
+		},
+		Resource: ResourceConnection(),
+		Update:   true,
+		ID:       "pg",
+		InstanceState: map[string]string{
+			"name":             "pg",
+			"connection_type":  "POSTGRESQL",
+			"options.%":        "4",
+			"options.host":     "old.example.com",
+			"options.port":     "5432",
+			"options.user":     "admin",
+			"options.password": "secret",
+		},
+		HCL: `
+		name = "pg"
+		connection_type = "POSTGRESQL"
+		options = {
+			host     = "new.example.com"
+			port     = "5432"
+			user     = "admin"
+			password = "secret"
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestConnectionDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodDelete,
+				Resource: "/api/2.1/unity-catalog/connections/pg",
+			},
+		},
+		Resource: ResourceConnection(),
+		Delete:   true,
+		ID:       "pg",
+	}.ApplyNoError(t)
+}
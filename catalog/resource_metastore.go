@@ -0,0 +1,177 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MetastoreInfo is the top-level container of Unity Catalog objects, that is shared by workspaces
+type MetastoreInfo struct {
+	Name              string `json:"name"`
+	StorageRoot       string `json:"storage_root" tf:"force_new"`
+	Region            string `json:"region,omitempty" tf:"computed,force_new"`
+	Owner             string `json:"owner,omitempty" tf:"computed"`
+	MetastoreID       string `json:"metastore_id,omitempty" tf:"computed"`
+	Cloud             string `json:"cloud,omitempty" tf:"computed"`
+	GlobalMetastoreID string `json:"global_metastore_id,omitempty" tf:"computed"`
+}
+
+type metastoreInfoWrapper struct {
+	MetastoreInfo MetastoreInfo `json:"metastore_info"`
+}
+
+type metastoreUpdate struct {
+	Name  string `json:"name,omitempty"`
+	Owner string `json:"owner,omitempty"`
+}
+
+type metastoreUpdateWrapper struct {
+	MetastoreInfo metastoreUpdate `json:"metastore_info"`
+}
+
+// accountID returns account of the provider, as Unity Catalog metastores are managed on account level
+func accountID(c *common.DatabricksClient) (string, error) {
+	if c.AccountID == "" {
+		return "", fmt.Errorf("account_id is required in provider configuration")
+	}
+	return c.AccountID, nil
+}
+
+// NewMetastoresAPI creates MetastoresAPI instance from provider meta
+func NewMetastoresAPI(ctx context.Context, m interface{}) MetastoresAPI {
+	return MetastoresAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// MetastoresAPI exposes the account-level Unity Catalog metastores API
+type MetastoresAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+func (a MetastoresAPI) path(suffix string) (string, error) {
+	accountID, err := accountID(a.client)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/accounts/%s/metastores%s", accountID, suffix), nil
+}
+
+// Create creates a metastore
+func (a MetastoresAPI) Create(mi MetastoreInfo) (info MetastoreInfo, err error) {
+	path, err := a.path("")
+	if err != nil {
+		return
+	}
+	var resp metastoreInfoWrapper
+	err = a.client.Post(a.context, path, metastoreInfoWrapper{
+		MetastoreInfo: MetastoreInfo{
+			Name:        mi.Name,
+			StorageRoot: mi.StorageRoot,
+			Region:      mi.Region,
+		},
+	}, &resp)
+	info = resp.MetastoreInfo
+	return
+}
+
+// Get returns metastore by ID
+func (a MetastoresAPI) Get(metastoreID string) (info MetastoreInfo, err error) {
+	path, err := a.path("/" + metastoreID)
+	if err != nil {
+		return
+	}
+	var resp metastoreInfoWrapper
+	err = a.client.Get(a.context, path, nil, &resp)
+	info = resp.MetastoreInfo
+	return
+}
+
+// Update renames metastore or changes its owner
+func (a MetastoresAPI) Update(metastoreID, name, owner string) error {
+	path, err := a.path("/" + metastoreID)
+	if err != nil {
+		return err
+	}
+	return a.client.Put(a.context, path, metastoreUpdateWrapper{
+		MetastoreInfo: metastoreUpdate{
+			Name:  name,
+			Owner: owner,
+		},
+	})
+}
+
+// Delete removes metastore. Non-empty metastores are removed only if force is set.
+func (a MetastoresAPI) Delete(metastoreID string, force bool) error {
+	path, err := a.path("/" + metastoreID)
+	if err != nil {
+		return err
+	}
+	return a.client.Delete(a.context, path, map[string]interface{}{
+		"force": force,
+	})
+}
+
+// ResourceMetastore manages Unity Catalog metastores
+func ResourceMetastore() *schema.Resource {
+	s := common.StructToSchema(MetastoreInfo{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["force_destroy"] = &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			}
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var mi MetastoreInfo
+			if err := common.DataToStructPointer(d, s, &mi); err != nil {
+				return err
+			}
+			metastoresAPI := NewMetastoresAPI(ctx, c)
+			info, err := metastoresAPI.Create(mi)
+			if err != nil {
+				return err
+			}
+			d.SetId(info.MetastoreID)
+			if mi.Owner == "" || mi.Owner == info.Owner {
+				return nil
+			}
+			// owner could be changed only after metastore is created
+			return metastoresAPI.Update(info.MetastoreID, "", mi.Owner)
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			info, err := NewMetastoresAPI(ctx, c).Get(d.Id())
+			if err != nil {
+				return err
+			}
+			return common.StructToData(info, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var mi MetastoreInfo
+			if err := common.DataToStructPointer(d, s, &mi); err != nil {
+				return err
+			}
+			var name, owner string
+			if d.HasChange("name") {
+				name = mi.Name
+			}
+			if d.HasChange("owner") {
+				owner = mi.Owner
+			}
+			if name == "" && owner == "" {
+				// only force_destroy has changed
+				return nil
+			}
+			return NewMetastoresAPI(ctx, c).Update(d.Id(), name, owner)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewMetastoresAPI(ctx, c).Delete(d.Id(), d.Get("force_destroy").(bool))
+		},
+	}.ToResource()
+}
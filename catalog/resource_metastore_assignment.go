@@ -0,0 +1,186 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MetastoreAssignment links workspace to the Unity Catalog metastore
+type MetastoreAssignment struct {
+	WorkspaceID        int64  `json:"workspace_id" tf:"force_new"`
+	MetastoreID        string `json:"metastore_id" tf:"force_new"`
+	DefaultCatalogName string `json:"default_catalog_name,omitempty"`
+}
+
+type metastoreAssignmentWrapper struct {
+	MetastoreAssignment MetastoreAssignment `json:"metastore_assignment"`
+}
+
+// NewMetastoreAssignmentAPI creates MetastoreAssignmentAPI instance from provider meta
+func NewMetastoreAssignmentAPI(ctx context.Context, m interface{}) MetastoreAssignmentAPI {
+	return MetastoreAssignmentAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// MetastoreAssignmentAPI exposes the account-level API for assigning metastores to workspaces
+type MetastoreAssignmentAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+func (a MetastoreAssignmentAPI) path(workspaceID int64, suffix string) (string, error) {
+	accountID, err := accountID(a.client)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/accounts/%s/workspaces/%d%s", accountID, workspaceID, suffix), nil
+}
+
+// Get returns metastore assignment of the workspace
+func (a MetastoreAssignmentAPI) Get(workspaceID int64) (ma MetastoreAssignment, err error) {
+	path, err := a.path(workspaceID, "/metastore")
+	if err != nil {
+		return
+	}
+	var resp metastoreAssignmentWrapper
+	err = a.client.Get(a.context, path, nil, &resp)
+	ma = resp.MetastoreAssignment
+	return
+}
+
+// Create assigns metastore to the workspace
+func (a MetastoreAssignmentAPI) Create(ma MetastoreAssignment) error {
+	path, err := a.path(ma.WorkspaceID, "/metastores/"+ma.MetastoreID)
+	if err != nil {
+		return err
+	}
+	return a.client.Post(a.context, path, metastoreAssignmentWrapper{
+		MetastoreAssignment: ma,
+	}, nil)
+}
+
+// Update changes the default catalog of the assigned metastore
+func (a MetastoreAssignmentAPI) Update(ma MetastoreAssignment) error {
+	path, err := a.path(ma.WorkspaceID, "/metastores/"+ma.MetastoreID)
+	if err != nil {
+		return err
+	}
+	return a.client.Put(a.context, path, metastoreAssignmentWrapper{
+		MetastoreAssignment: ma,
+	})
+}
+
+// Delete unassigns metastore from the workspace
+func (a MetastoreAssignmentAPI) Delete(workspaceID int64, metastoreID string) error {
+	path, err := a.path(workspaceID, "/metastores/"+metastoreID)
+	if err != nil {
+		return err
+	}
+	return a.client.Delete(a.context, path, nil)
+}
+
+// Assign makes metastore the current one for the workspace. Workspace, that is already
+// assigned to a different metastore, is detached from it first, if forceDetach is set.
+func (a MetastoreAssignmentAPI) Assign(ma MetastoreAssignment, forceDetach bool) error {
+	current, err := a.Get(ma.WorkspaceID)
+	if common.IsMissing(err) {
+		return a.Create(ma)
+	}
+	if err != nil {
+		return err
+	}
+	if current.MetastoreID == "" {
+		return a.Create(ma)
+	}
+	if current.MetastoreID == ma.MetastoreID {
+		return a.Update(ma)
+	}
+	if !forceDetach {
+		return fmt.Errorf("workspace %d is already assigned to metastore %s, "+
+			"set force_detach to re-assign it", ma.WorkspaceID, current.MetastoreID)
+	}
+	if err = a.Delete(ma.WorkspaceID, current.MetastoreID); err != nil {
+		return err
+	}
+	return a.Create(ma)
+}
+
+// ResourceMetastoreAssignment manages assignment of Unity Catalog metastores to workspaces
+func ResourceMetastoreAssignment() *schema.Resource {
+	s := common.StructToSchema(MetastoreAssignment{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["force_detach"] = &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			}
+			return m
+		})
+	p := common.NewPairSeparatedID("workspace_id", "metastore_id", "|").Schema(
+		func(_ map[string]*schema.Schema) map[string]*schema.Schema {
+			return s
+		})
+	unpack := func(d *schema.ResourceData) (int64, string, error) {
+		workspaceID, metastoreID, err := p.Unpack(d)
+		if err != nil {
+			return 0, "", err
+		}
+		id, err := strconv.ParseInt(workspaceID, 10, 64)
+		if err != nil {
+			return 0, "", fmt.Errorf("invalid workspace_id: %s", workspaceID)
+		}
+		return id, metastoreID, nil
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var ma MetastoreAssignment
+			if err := common.DataToStructPointer(d, s, &ma); err != nil {
+				return err
+			}
+			err := NewMetastoreAssignmentAPI(ctx, c).Assign(ma, d.Get("force_detach").(bool))
+			if err != nil {
+				return err
+			}
+			p.Pack(d)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			workspaceID, metastoreID, err := unpack(d)
+			if err != nil {
+				return err
+			}
+			ma, err := NewMetastoreAssignmentAPI(ctx, c).Get(workspaceID)
+			if err != nil {
+				return err
+			}
+			if ma.MetastoreID != metastoreID {
+				return common.NotFound(fmt.Sprintf("metastore %s is not assigned to workspace %d",
+					metastoreID, workspaceID))
+			}
+			ma.WorkspaceID = workspaceID
+			return common.StructToData(ma, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var ma MetastoreAssignment
+			if err := common.DataToStructPointer(d, s, &ma); err != nil {
+				return err
+			}
+			if !d.HasChange("default_catalog_name") {
+				return nil
+			}
+			return NewMetastoreAssignmentAPI(ctx, c).Update(ma)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			workspaceID, metastoreID, err := unpack(d)
+			if err != nil {
+				return err
+			}
+			return NewMetastoreAssignmentAPI(ctx, c).Delete(workspaceID, metastoreID)
+		},
+	}.ToResource()
+}
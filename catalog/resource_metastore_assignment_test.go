@@ -0,0 +1,229 @@
+package catalog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetastoreAssignmentCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceMetastoreAssignment(),
+		qa.CornerCaseID("123|m1"), qa.CornerCaseAccountID("abc"))
+}
+
+var assignedMetastoreFixture = qa.HTTPFixture{
+	Method:   http.MethodGet,
+	Resource: "/api/2.0/accounts/abc/workspaces/123/metastore",
+	Response: metastoreAssignmentWrapper{
+		MetastoreAssignment: MetastoreAssignment{
+			WorkspaceID:        123,
+			MetastoreID:        "m1",
+			DefaultCatalogName: "main",
+		},
+	},
+}
+
+func TestMetastoreAssignmentCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastore",
+				Status:   404,
+				Response: common.NotFound("no metastore assigned"),
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastores/m1",
+				ExpectedRequest: metastoreAssignmentWrapper{
+					MetastoreAssignment: MetastoreAssignment{
+						WorkspaceID:        123,
+						MetastoreID:        "m1",
+						DefaultCatalogName: "main",
+					},
+				},
+			},
+			assignedMetastoreFixture,
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Create:    true,
+		HCL: `
+		workspace_id = 123
+		metastore_id = "m1"
+		default_catalog_name = "main"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "123|m1", d.Id())
+	assert.Equal(t, "main", d.Get("default_catalog_name"))
+}
+
+func TestMetastoreAssignmentCreate_AlreadyAssigned(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			assignedMetastoreFixture,
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastores/m1",
+				ExpectedRequest: metastoreAssignmentWrapper{
+					MetastoreAssignment: MetastoreAssignment{
+						WorkspaceID:        123,
+						MetastoreID:        "m1",
+						DefaultCatalogName: "main",
+					},
+				},
+			},
+			assignedMetastoreFixture,
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Create:    true,
+		HCL: `
+		workspace_id = 123
+		metastore_id = "m1"
+		default_catalog_name = "main"`,
+	}.ApplyNoError(t)
+}
+
+func TestMetastoreAssignmentCreate_Reassign(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastore",
+				Response: metastoreAssignmentWrapper{
+					MetastoreAssignment: MetastoreAssignment{
+						WorkspaceID: 123,
+						MetastoreID: "old",
+					},
+				},
+			},
+			{
+				Method:   http.MethodDelete,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastores/old",
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastores/m1",
+				ExpectedRequest: metastoreAssignmentWrapper{
+					MetastoreAssignment: MetastoreAssignment{
+						WorkspaceID:        123,
+						MetastoreID:        "m1",
+						DefaultCatalogName: "main",
+					},
+				},
+			},
+			assignedMetastoreFixture,
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Create:    true,
+		HCL: `
+		workspace_id = 123
+		metastore_id = "m1"
+		default_catalog_name = "main"`,
+	}.ApplyNoError(t)
+}
+
+func TestMetastoreAssignmentCreate_NoForceDetach(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastore",
+				Response: metastoreAssignmentWrapper{
+					MetastoreAssignment: MetastoreAssignment{
+						WorkspaceID: 123,
+						MetastoreID: "old",
+					},
+				},
+			},
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Create:    true,
+		HCL: `
+		workspace_id = 123
+		metastore_id = "m1"
+		force_detach = false`,
+	}.ExpectError(t, "workspace 123 is already assigned to metastore old, set force_detach to re-assign it")
+}
+
+func TestMetastoreAssignmentRead_AssignedToOther(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			assignedMetastoreFixture,
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "123|other",
+	}.ApplyNoError(t)
+}
+
+func TestMetastoreAssignmentRead_InvalidID(t *testing.T) {
+	for id, message := range map[string]string{
+		"123":    "invalid ID: 123",
+		"|m1":    "workspace_id cannot be empty",
+		"abc|m1": "invalid workspace_id: abc",
+	} {
+		qa.ResourceFixture{
+			Resource:  ResourceMetastoreAssignment(),
+			AccountID: "abc",
+			Read:      true,
+			ID:        id,
+		}.ExpectError(t, message)
+	}
+}
+
+func TestMetastoreAssignmentUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastores/m1",
+				ExpectedRequest: metastoreAssignmentWrapper{
+					MetastoreAssignment: MetastoreAssignment{
+						WorkspaceID:        123,
+						MetastoreID:        "m1",
+						DefaultCatalogName: "main",
+					},
+				},
+			},
+			assignedMetastoreFixture,
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Update:    true,
+		ID:        "123|m1",
+		InstanceState: map[string]string{
+			"workspace_id":         "123",
+			"metastore_id":         "m1",
+			"default_catalog_name": "hive_metastore",
+		},
+		HCL: `
+		workspace_id = 123
+		metastore_id = "m1"
+		default_catalog_name = "main"`,
+	}.ApplyNoError(t)
+}
+
+func TestMetastoreAssignmentDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodDelete,
+				Resource: "/api/2.0/accounts/abc/workspaces/123/metastores/m1",
+			},
+		},
+		Resource:  ResourceMetastoreAssignment(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "123|m1",
+	}.ApplyNoError(t)
+}
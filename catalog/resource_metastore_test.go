@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetastoreCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceMetastore(), qa.CornerCaseAccountID("abc"))
+}
+
+func TestMetastoreCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/accounts/abc/metastores",
+				ExpectedRequest: metastoreInfoWrapper{
+					MetastoreInfo: MetastoreInfo{
+						Name:        "primary",
+						StorageRoot: "s3://bucket/metastore",
+						Region:      "us-east-1",
+					},
+				},
+				Response: metastoreInfoWrapper{
+					MetastoreInfo: MetastoreInfo{
+						MetastoreID: "m1",
+						Owner:       "admin@example.com",
+					},
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/accounts/abc/metastores/m1",
+				ExpectedRequest: metastoreUpdateWrapper{
+					MetastoreInfo: metastoreUpdate{
+						Owner: "metastore-admins",
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/accounts/abc/metastores/m1",
+				Response: metastoreInfoWrapper{
+					MetastoreInfo: MetastoreInfo{
+						Name:              "primary",
+						StorageRoot:       "s3://bucket/metastore",
+						Region:            "us-east-1",
+						Owner:             "metastore-admins",
+						MetastoreID:       "m1",
+						Cloud:             "aws",
+						GlobalMetastoreID: "aws:us-east-1:m1",
+					},
+				},
+			},
+		},
+		Resource:  ResourceMetastore(),
+		AccountID: "abc",
+		Create:    true,
+		HCL: `
+		name = "primary"
+		storage_root = "s3://bucket/metastore"
+		region = "us-east-1"
+		owner = "metastore-admins"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "m1", d.Id())
+	assert.Equal(t, "aws", d.Get("cloud"))
+	assert.Equal(t, "metastore-admins", d.Get("owner"))
+}
+
+func TestMetastoreCreate_NoAccountID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceMetastore(),
+		Create:   true,
+		HCL: `
+		name = "primary"
+		storage_root = "s3://bucket/metastore"`,
+	}.ExpectError(t, "account_id is required in provider configuration")
+}
+
+func TestMetastoreUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/accounts/abc/metastores/m1",
+				ExpectedRequest: metastoreUpdateWrapper{
+					MetastoreInfo: metastoreUpdate{
+						Name: "renamed",
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/accounts/abc/metastores/m1",
+				Response: metastoreInfoWrapper{
+					MetastoreInfo: MetastoreInfo{
+						Name:        "renamed",
+						StorageRoot: "s3://bucket/metastore",
+						Region:      "us-east-1",
+						Owner:       "admin@example.com",
+						MetastoreID: "m1",
+					},
+				},
+			},
+		},
+		Resource:  ResourceMetastore(),
+		AccountID: "abc",
+		Update:    true,
+		ID:        "m1",
+		InstanceState: map[string]string{
+			"name":         "primary",
+			"storage_root": "s3://bucket/metastore",
+			"region":       "us-east-1",
+			"owner":        "admin@example.com",
+			"metastore_id": "m1",
+		},
+		HCL: `
+		name = "renamed"
+		storage_root = "s3://bucket/metastore"`,
+	}.ApplyNoError(t)
+}
+
+func TestMetastoreDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodDelete,
+				Resource: "/api/2.0/accounts/abc/metastores/m1",
+				ExpectedRequest: map[string]interface{}{
+					"force": true,
+				},
+			},
+		},
+		Resource:  ResourceMetastore(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "m1",
+		HCL: `
+		name = "primary"
+		storage_root = "s3://bucket/metastore"
+		force_destroy = true`,
+	}.ApplyNoError(t)
+}
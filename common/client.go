@@ -94,6 +94,16 @@ type DatabricksClient struct {
 	// Maximum number of requests per second made to Databricks REST API.
 	RateLimitPerSecond int `name:"rate_limit" env:"DATABRICKS_RATE_LIMIT"`
 
+	// Check that notebooks of jobs exist during plan. Default is false.
+	ValidateNotebookPaths bool `name:"validate_notebook_paths" env:"DATABRICKS_VALIDATE_NOTEBOOK_PATHS"`
+
+	// Log progress of long-running operations, like cluster creation. Default is false.
+	DebugProgress bool `name:"debug_progress" env:"DATABRICKS_DEBUG_PROGRESS"`
+
+	// Git credentials used by all Databricks Repos, that are created by the provider.
+	GitUsername string `name:"git_username" env:"DATABRICKS_GIT_USERNAME"`
+	GitToken    string `name:"git_token" env:"DATABRICKS_GIT_TOKEN"`
+
 	// OAuth token refreshers for Azure to be used within `authVisitor`
 	azureAuthorizer autorest.Authorizer
 
@@ -112,6 +122,9 @@ type DatabricksClient struct {
 	// HTTP request interceptor, that assigns Authorization header
 	authVisitor func(r *http.Request) error
 
+	// short name of authentication method picked by Authenticate
+	authType string
+
 	// Databricks REST API rate limiter
 	rateLimiter *rate.Limiter
 
@@ -209,15 +222,16 @@ func (c *DatabricksClient) Authenticate(ctx context.Context) error {
 	type auth struct {
 		configure func(context.Context) (func(*http.Request) error, error)
 		name      string
+		authType  string
 	}
 	providers := []auth{
-		{c.configureWithDirectParams, "direct"},
-		{c.configureWithAzureClientSecret, "Azure Service Principal"},
-		{c.configureWithAzureManagedIdentity, "Azure MSI"},
-		{c.configureWithAzureCLI, "Azure CLI"},
-		{c.configureWithGoogleForAccountsAPI, "Databricks Account on GCP"},
-		{c.configureWithGoogleForWorkspace, "Databricks on GCP"},
-		{c.configureWithDatabricksCfg, "Databricks CLI"},
+		{c.configureWithDirectParams, "direct", "pat"},
+		{c.configureWithAzureClientSecret, "Azure Service Principal", "azure-client-secret"},
+		{c.configureWithAzureManagedIdentity, "Azure MSI", "azure-msi"},
+		{c.configureWithAzureCLI, "Azure CLI", "azure-cli"},
+		{c.configureWithGoogleForAccountsAPI, "Databricks Account on GCP", "google-accounts"},
+		{c.configureWithGoogleForWorkspace, "Databricks on GCP", "google-id"},
+		{c.configureWithDatabricksCfg, "Databricks CLI", "databricks-cli"},
 	}
 	// try configuring authentication with different methods
 	for _, auth := range providers {
@@ -229,6 +243,10 @@ func (c *DatabricksClient) Authenticate(ctx context.Context) error {
 			continue
 		}
 		c.authVisitor = authorizer
+		c.authType = auth.authType
+		if auth.authType == "pat" && c.Username != "" && c.Password != "" {
+			c.authType = "basic"
+		}
 		c.fixHost()
 		return nil
 	}
@@ -411,6 +429,12 @@ func (c *DatabricksClient) configureHTTPCLient() {
 	}
 }
 
+// AuthType returns short name of authentication method, like pat or azure-cli,
+// or empty string, if client is not yet authenticated
+func (c *DatabricksClient) AuthType() string {
+	return c.authType
+}
+
 // IsAzure returns true if client is configured for Azure Databricks - either by using AAD auth or with host+token combination
 func (c *DatabricksClient) IsAzure() bool {
 	return c.resourceID() != "" || strings.Contains(c.Host, ".azuredatabricks.net") || c.AzureUseMSI
@@ -454,6 +478,8 @@ func (c *DatabricksClient) ClientForHost(url string) *DatabricksClient {
 		DebugTruncateBytes:   c.DebugTruncateBytes,
 		DebugHeaders:         c.DebugHeaders,
 		RateLimitPerSecond:   c.RateLimitPerSecond,
+		GitUsername:          c.GitUsername,
+		GitToken:             c.GitToken,
 		Provider:             c.Provider,
 		rateLimiter:          c.rateLimiter,
 		httpClient:           c.httpClient,
@@ -3,12 +3,36 @@ package common
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"strings"
 
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/idtoken"
 	"google.golang.org/api/impersonate"
 )
 
+// impersonationFallbackTokenSource uses default credentials, when the caller is not allowed
+// to impersonate google_service_account, e.g. when provider already runs as that account
+type impersonationFallbackTokenSource struct {
+	impersonated oauth2.TokenSource
+	fallback     func() (oauth2.TokenSource, error)
+}
+
+func (ts impersonationFallbackTokenSource) Token() (*oauth2.Token, error) {
+	token, err := ts.impersonated.Token()
+	if err == nil || !strings.Contains(err.Error(), "status code 403") {
+		return token, err
+	}
+	log.Printf("[WARN] Cannot impersonate service account, using default credentials: %s", err)
+	fallback, ferr := ts.fallback()
+	if ferr != nil {
+		return nil, fmt.Errorf("%w. Default credentials cannot be used either: %s", err, ferr)
+	}
+	return fallback.Token()
+}
+
 func (c *DatabricksClient) getGoogleOIDCSource(ctx context.Context) (oauth2.TokenSource, error) {
 	// source for generateIdToken
 	ts, err := impersonate.IDTokenSource(ctx, impersonate.IDTokenConfig{
@@ -20,6 +44,9 @@ func (c *DatabricksClient) getGoogleOIDCSource(ctx context.Context) (oauth2.Toke
 		err = fmt.Errorf("could not obtain OIDC token. %w Running 'gcloud auth application-default login' may help", err)
 		return nil, err
 	}
+	ts = impersonationFallbackTokenSource{ts, func() (oauth2.TokenSource, error) {
+		return idtoken.NewTokenSource(ctx, c.Host, c.googleAuthOptions...)
+	}}
 	// TODO: verify that refreshers work...
 	ts = oauth2.ReuseTokenSource(nil, ts)
 	return ts, nil
@@ -34,16 +61,21 @@ func (c *DatabricksClient) configureWithGoogleForAccountsAPI(ctx context.Context
 		return nil, err
 	}
 	// source for generateAccessToken
+	scopes := []string{
+		"https://www.googleapis.com/auth/cloud-platform",
+		"https://www.googleapis.com/auth/compute",
+	}
 	platformSource, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
 		TargetPrincipal: c.GoogleServiceAccount,
-		Scopes: []string{
-			"https://www.googleapis.com/auth/cloud-platform",
-			"https://www.googleapis.com/auth/compute",
-		},
+		Scopes:          scopes,
 	}, c.googleAuthOptions...)
 	if err != nil {
 		return nil, err
 	}
+	platformSource = oauth2.ReuseTokenSource(nil, impersonationFallbackTokenSource{
+		platformSource, func() (oauth2.TokenSource, error) {
+			return google.DefaultTokenSource(ctx, scopes...)
+		}})
 	return newOidcAuthorizerForAccountsAPI(oidcSource, platformSource), nil
 }
 
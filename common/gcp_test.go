@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"fmt"
 	"net/http/httptest"
 	"testing"
 
@@ -90,3 +91,43 @@ func TestNewOidcAuthorizerForWorkspace(t *testing.T) {
 	assert.Equal(t, "Bearer abc", request.Header.Get("Authorization"))
 	assert.Equal(t, "", request.Header.Get("X-Databricks-GCP-SA-Access-Token"))
 }
+
+type failingTokenSource struct {
+	err error
+}
+
+func (ts failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, ts.err
+}
+
+func TestImpersonationFallbackTokenSource(t *testing.T) {
+	impersonated := oauth2.Token{AccessToken: "impersonated"}
+	fallback := oauth2.Token{AccessToken: "default"}
+	fallbackSource := func() (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&fallback), nil
+	}
+
+	token, err := impersonationFallbackTokenSource{
+		oauth2.StaticTokenSource(&impersonated), fallbackSource}.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "impersonated", token.AccessToken)
+
+	token, err = impersonationFallbackTokenSource{
+		failingTokenSource{fmt.Errorf("impersonate: status code 403: denied")},
+		fallbackSource}.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "default", token.AccessToken)
+
+	_, err = impersonationFallbackTokenSource{
+		failingTokenSource{fmt.Errorf("impersonate: status code 500: oops")},
+		fallbackSource}.Token()
+	assert.EqualError(t, err, "impersonate: status code 500: oops")
+
+	_, err = impersonationFallbackTokenSource{
+		failingTokenSource{fmt.Errorf("impersonate: status code 403: denied")},
+		func() (oauth2.TokenSource, error) {
+			return nil, fmt.Errorf("no default credentials")
+		}}.Token()
+	assert.EqualError(t, err, "impersonate: status code 403: denied. "+
+		"Default credentials cannot be used either: no default credentials")
+}
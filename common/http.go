@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-querystring/query"
@@ -350,6 +351,26 @@ func (c *DatabricksClient) Scim(ctx context.Context, method, path string, reques
 	return c.unmarshall(path, body, &response)
 }
 
+// CurrentWorkspaceID returns ID of the workspace, that the client is configured for.
+// Workspace APIs return it in the X-Databricks-Org-Id header of every response.
+func (c *DatabricksClient) CurrentWorkspaceID(ctx context.Context) (int64, error) {
+	err := c.Authenticate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	_, header, err := c.genericQueryWithHeaders(ctx, http.MethodGet, "/preview/scim/v2/Me",
+		nil, c.authVisitor, c.completeUrl)
+	if err != nil {
+		return 0, err
+	}
+	orgID := header.Get("X-Databricks-Org-Id")
+	workspaceID, err := strconv.ParseInt(orgID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse workspace id from X-Databricks-Org-Id header: %#v", orgID)
+	}
+	return workspaceID, nil
+}
+
 func (c *DatabricksClient) authenticatedQuery(ctx context.Context, method, requestURL string,
 	data interface{}, visitors ...func(*http.Request) error) (body []byte, err error) {
 	err = c.Authenticate(ctx)
@@ -374,6 +395,10 @@ func (c *DatabricksClient) recursiveMask(requestMap map[string]interface{}) inte
 			requestMap[k] = "**REDACTED**"
 			continue
 		}
+		if k == "personal_access_token" {
+			requestMap[k] = "**REDACTED**"
+			continue
+		}
 		if m, ok := v.(map[string]interface{}); ok {
 			requestMap[k] = c.recursiveMask(m)
 			continue
@@ -425,25 +450,32 @@ func (c *DatabricksClient) userAgent(ctx context.Context) string {
 // todo: do is better name
 func (c *DatabricksClient) genericQuery(ctx context.Context, method, requestURL string, data interface{},
 	visitors ...func(*http.Request) error) (body []byte, err error) {
+	body, _, err = c.genericQueryWithHeaders(ctx, method, requestURL, data, visitors...)
+	return body, err
+}
+
+// genericQueryWithHeaders is genericQuery, that also returns headers of the response
+func (c *DatabricksClient) genericQueryWithHeaders(ctx context.Context, method, requestURL string, data interface{},
+	visitors ...func(*http.Request) error) (body []byte, header http.Header, err error) {
 	if c.httpClient == nil {
-		return nil, fmt.Errorf("DatabricksClient is not configured")
+		return nil, nil, fmt.Errorf("DatabricksClient is not configured")
 	}
 	if err = c.rateLimiter.Wait(ctx); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	requestBody, err := makeRequestBody(method, &requestURL, data, true)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	request.Header.Set("User-Agent", c.userAgent(ctx))
 	for _, requestVisitor := range visitors {
 		err = requestVisitor(request)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	headers := ""
@@ -459,16 +491,16 @@ func (c *DatabricksClient) genericQuery(ctx context.Context, method, requestURL
 
 	r, err := retryablehttp.FromRequest(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	resp, err := c.httpClient.Do(r)
 	// retryablehttp library now returns only wrapped errors
 	var ae APIError
 	if errors.As(err, &ae) {
-		return nil, ae
+		return nil, nil, ae
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		if ferr := resp.Body.Close(); ferr != nil {
@@ -477,10 +509,10 @@ func (c *DatabricksClient) genericQuery(ctx context.Context, method, requestURL
 	}()
 	body, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	log.Printf("[DEBUG] %s %v <- %s %s", resp.Status, c.redactedDump(body), method, request.URL.Path)
-	return body, nil
+	return body, resp.Header, nil
 }
 
 func makeRequestBody(method string, requestURL *string, data interface{}, marshalJSON bool) ([]byte, error) {
@@ -314,6 +314,34 @@ func TestScim(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCurrentWorkspaceID(t *testing.T) {
+	orgID := "1234567890"
+	server := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/api/2.0/preview/scim/v2/Me", req.RequestURI)
+			if orgID != "" {
+				rw.Header().Set("X-Databricks-Org-Id", orgID)
+			}
+			_, err := rw.Write([]byte(`{"userName": "me@example.com"}`))
+			assert.NoError(t, err)
+		}))
+	defer server.Close()
+	client := &DatabricksClient{
+		Host:  server.URL + "/",
+		Token: "..",
+	}
+	err := client.Configure()
+	require.NoError(t, err)
+
+	workspaceID, err := client.CurrentWorkspaceID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234567890), workspaceID)
+
+	orgID = ""
+	_, err = client.CurrentWorkspaceID(context.Background())
+	assert.EqualError(t, err, `cannot parse workspace id from X-Databricks-Org-Id header: ""`)
+}
+
 func TestMakeRequestBody(t *testing.T) {
 	type x struct {
 		Scope string `json:"scope" url:"scope"`
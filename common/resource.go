@@ -108,7 +108,7 @@ func makeEmptyBlockSuppressFunc(name string) func(k, old, new string, d *schema.
 	re := MustCompileKeyRE(name)
 	return func(k, old, new string, d *schema.ResourceData) bool {
 		log.Printf("[DEBUG] name=%s k='%v', old='%v', new='%v'", name, k, old, new)
-		if re.Match([]byte(name)) && old == "1" && new == "0" {
+		if re.Match([]byte(k)) && old == "1" && new == "0" {
 			log.Printf("[DEBUG] Suppressing diff for name=%s k=%#v old=%#v new=%#v", name, k, old, new)
 			return true
 		}
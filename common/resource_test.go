@@ -69,3 +69,11 @@ func TestUpdate(t *testing.T) {
 	assert.True(t, diags.HasError())
 	assert.Equal(t, "nope", diags[0].Summary)
 }
+
+func TestMakeEmptyBlockSuppressFunc(t *testing.T) {
+	suppress := makeEmptyBlockSuppressFunc("aws_attributes.#")
+	assert.True(t, suppress("aws_attributes.#", "1", "0", nil))
+	assert.False(t, suppress("aws_attributes.#", "0", "1", nil))
+	assert.False(t, suppress("aws_attributes.0.first_on_demand", "1", "0", nil))
+	assert.False(t, suppress("aws_attributes.0.availability", "SPOT", "ON_DEMAND", nil))
+}
@@ -78,3 +78,24 @@ func TestAccClusterResource_CreateSingleNodeCluster(t *testing.T) {
 		},
 	})
 }
+
+func TestAccClusterResource_CreateClusterWithEnhancedSecurityMonitoring(t *testing.T) {
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `
+			data "databricks_spark_version" "latest" {
+			}
+			resource "databricks_cluster" "this" {
+				cluster_name = "esm-{var.RANDOM}"
+				spark_version = data.databricks_spark_version.latest.id
+				instance_pool_id = "{var.COMMON_INSTANCE_POOL_ID}"
+				autotermination_minutes = 10
+				num_workers = 1
+				enhanced_security_monitoring {
+					is_enabled = true
+				}
+				{var.AWS_ATTRIBUTES}
+			}`,
+		},
+	})
+}
@@ -16,7 +16,12 @@ import (
 	"golang.org/x/mod/semver"
 )
 
+// defaultTimeout bounds waiting for cluster state by the deadline of the context, so that
+// every wait within the same create or update shares the timeout of that operation
 func (a ClustersAPI) defaultTimeout() time.Duration {
+	if deadline, ok := a.context.Deadline(); ok {
+		return time.Until(deadline)
+	}
 	return 30 * time.Minute
 }
 
@@ -34,6 +39,18 @@ type ClustersAPI struct {
 	context context.Context
 }
 
+// progressPrefix is the common prefix of progress log lines, so that they could be grepped
+const progressPrefix = "[progress]"
+
+// logProgress reports major steps of cluster lifecycle, when debug_progress is enabled
+// in provider configuration, so that slow applies are traced without reading all HTTP calls
+func logProgress(client *common.DatabricksClient, clusterID, format string, args ...interface{}) {
+	if client == nil || !client.DebugProgress {
+		return
+	}
+	log.Printf("[INFO] %s cluster %s: %s", progressPrefix, clusterID, fmt.Sprintf(format, args...))
+}
+
 // Create creates a new Spark cluster and waits till it's running
 func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
 	var ci ClusterID
@@ -41,6 +58,8 @@ func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
 	if err != nil {
 		return
 	}
+	defer a.invalidateCache(ci.ClusterID)
+	logProgress(a.client, ci.ClusterID, "create accepted")
 	info, err = a.waitForClusterStatus(ci.ClusterID, ClusterStateRunning)
 	if err != nil {
 		// https://github.com/databrickslabs/terraform-provider-databricks/issues/383
@@ -54,34 +73,26 @@ func (a ClustersAPI) Create(cluster Cluster) (info ClusterInfo, err error) {
 	return
 }
 
+// maxEditAttempts is how many times the edit is retried, if the cluster changes
+// its state between the check and the edit
+const maxEditAttempts = 3
+
 // Edit edits the configuration of a cluster to match the provided attributes and size
 func (a ClustersAPI) Edit(cluster Cluster) (info ClusterInfo, err error) {
-	info, err = a.Get(cluster.ClusterID)
-	if err != nil {
-		return info, err
-	}
-	switch info.State {
-	case ClusterStateRunning, ClusterStateTerminated:
-		// it's already running or terminated, so we're safe to edit
-		break
-	case ClusterStatePending, ClusterStateResizing, ClusterStateRestarting:
-		// let's wait tiny bit, so we return RUNNING cluster info
-		info, err = a.waitForClusterStatus(info.ClusterID, ClusterStateRunning)
+	defer a.invalidateCache(cluster.ClusterID)
+	for attempt := 1; ; attempt++ {
+		info, err = a.waitForEditableState(cluster.ClusterID)
 		if err != nil {
 			return info, err
 		}
-	case ClusterStateTerminating:
-		// let it finish terminating, so it's safe to edit.
-		// TERMINATED cluster info will be returned this way
-		info, err = a.waitForClusterStatus(info.ClusterID, ClusterStateTerminated)
-		if err != nil {
-			return info, err
+		err = a.client.Post(a.context, "/clusters/edit", cluster, nil)
+		apiErr, ok := err.(common.APIError)
+		if !ok || apiErr.ErrorCode != "INVALID_STATE" || attempt == maxEditAttempts {
+			break
 		}
-	case ClusterStateError, ClusterStateUnknown:
-		// we don't know what to do, so return error
-		return info, fmt.Errorf("unexpected state: %#v", info.StateMessage)
+		// cluster may have been restarted between the state check and the edit
+		log.Printf("[INFO] Cluster %s has changed its state before edit: %s", cluster.ClusterID, err)
 	}
-	err = a.client.Post(a.context, "/clusters/edit", cluster, nil)
 	if err != nil {
 		return info, err
 	}
@@ -93,6 +104,73 @@ func (a ClustersAPI) Edit(cluster Cluster) (info ClusterInfo, err error) {
 	return info, err
 }
 
+// Resize changes the size of the running cluster and waits until it's RUNNING again.
+// Unlike edits of other properties, resize doesn't restart the cluster.
+func (a ClustersAPI) Resize(r ResizeRequest) (info ClusterInfo, err error) {
+	defer a.invalidateCache(r.ClusterID)
+	info, err = a.waitForEditableState(r.ClusterID)
+	if err != nil {
+		return info, err
+	}
+	if info.State != ClusterStateRunning {
+		return info, fmt.Errorf("cluster %s is %s and cannot be resized", r.ClusterID, info.State)
+	}
+	err = a.client.Post(a.context, "/clusters/resize", r, nil)
+	if err != nil {
+		return info, err
+	}
+	return a.waitForResize(r.ClusterID)
+}
+
+// waitForResize waits for the cluster to leave RESIZING state. Cluster that is
+// being resized is not restarted, so there's no need to start it again.
+func (a ClustersAPI) waitForResize(clusterID string) (info ClusterInfo, err error) {
+	err = resource.RetryContext(a.context, a.defaultTimeout(), func() *resource.RetryError {
+		info, err = a.Get(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch {
+		case info.State == ClusterStateRunning:
+			return nil
+		case info.State.CanReach(ClusterStateRunning):
+			return resource.RetryableError(fmt.Errorf(
+				"cluster %s is %s; wait for resize to complete", clusterID, info.State))
+		default:
+			return resource.NonRetryableError(fmt.Errorf(
+				"cluster %s is %s and cannot complete resize: %s%s", clusterID,
+				info.State, info.StateMessage, a.terminationDetails(info)))
+		}
+	})
+	return
+}
+
+// waitForEditableState waits until the cluster becomes RUNNING or TERMINATED, as only
+// those states are safe to edit. Clusters, that cannot reach RUNNING state, are terminating
+// or are broken, so helpful error is returned for them.
+func (a ClustersAPI) waitForEditableState(clusterID string) (info ClusterInfo, err error) {
+	err = resource.RetryContext(a.context, a.defaultTimeout(), func() *resource.RetryError {
+		info, err = a.Get(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch {
+		case info.State == ClusterStateRunning, info.State == ClusterStateTerminated:
+			return nil
+		case info.State == ClusterStateTerminating:
+			return resource.RetryableError(fmt.Errorf(
+				"cluster %s is terminating; wait for TERMINATED then modify", clusterID))
+		case info.State.CanReach(ClusterStateRunning):
+			return resource.RetryableError(fmt.Errorf(
+				"cluster %s is %s; wait for RUNNING then modify", clusterID, info.State))
+		default:
+			return resource.NonRetryableError(fmt.Errorf(
+				"cluster %s is %s and cannot be modified: %s", clusterID, info.State, info.StateMessage))
+		}
+	})
+	return
+}
+
 // ListZones returns the zones info sent by the cloud service provider
 func (a ClustersAPI) ListZones() (ZonesInfo, error) {
 	var zonesInfo ZonesInfo
@@ -108,6 +186,7 @@ func (a ClustersAPI) Start(clusterID string) error {
 
 // StartAndGetInfo starts cluster and returns info
 func (a ClustersAPI) StartAndGetInfo(clusterID string) (ClusterInfo, error) {
+	defer a.invalidateCache(clusterID)
 	info, err := a.Get(clusterID)
 	if err != nil {
 		return info, err
@@ -142,6 +221,7 @@ func (a ClustersAPI) StartAndGetInfo(clusterID string) (ClusterInfo, error) {
 
 // Restart restart a Spark cluster given its ID. If the cluster is not in a RUNNING state, nothing will happen.
 func (a ClustersAPI) Restart(clusterID string) error {
+	defer a.invalidateCache(clusterID)
 	return a.client.Post(a.context, "/clusters/restart", ClusterID{ClusterID: clusterID}, nil)
 }
 
@@ -157,7 +237,7 @@ func wrapMissingClusterError(err error, id string) error {
 		return err
 	}
 	// fix non-compliant error code
-	if strings.Contains(apiErr.Message,
+	if apiErr.ErrorCode == "RESOURCE_DOES_NOT_EXIST" || strings.Contains(apiErr.Message,
 		fmt.Sprintf("Cluster %s does not exist", id)) {
 		apiErr.StatusCode = 404
 		return apiErr
@@ -168,6 +248,8 @@ func wrapMissingClusterError(err error, id string) error {
 func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState) (result ClusterInfo, err error) {
 	// this tangles client with terraform more, which is inevitable
 	// nolint should be a bigger context-aware refactor
+	started := time.Now()
+	var lastState ClusterState
 	return result, resource.RetryContext(a.context, a.defaultTimeout(), func() *resource.RetryError {
 		clusterInfo, err := a.Get(clusterID)
 		if common.IsMissing(err) {
@@ -179,20 +261,24 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 		}
 		result = clusterInfo
 		log.Printf("[DEBUG] Cluster %s is %s: %s", clusterID, clusterInfo.State, clusterInfo.StateMessage)
+		if clusterInfo.State != lastState {
+			transition := string(clusterInfo.State)
+			if lastState != "" {
+				transition = fmt.Sprintf("%s -> %s", lastState, clusterInfo.State)
+			}
+			logProgress(a.client, clusterID, "state %s at %s, waited %s for %s", transition,
+				time.Now().UTC().Format(time.RFC3339), time.Since(started).Round(time.Second), desired)
+			lastState = clusterInfo.State
+		}
 		if clusterInfo.State == desired {
 			return nil
 		}
 		if !clusterInfo.State.CanReach(desired) {
 			docLink := "https://docs.databricks.com/dev-tools/api/latest/clusters.html#clusterclusterstate"
-			details := ""
-			if clusterInfo.TerminationReason != nil {
-				details = fmt.Sprintf(", Termination info: code: %s, type: %s, parameters: %v",
-					clusterInfo.TerminationReason.Code, clusterInfo.TerminationReason.Type,
-					clusterInfo.TerminationReason.Parameters)
-			}
 			return resource.NonRetryableError(fmt.Errorf(
 				"%s is not able to transition from %s to %s: %s%s. Please see %s for more details",
-				clusterID, clusterInfo.State, desired, clusterInfo.StateMessage, details, docLink))
+				clusterID, clusterInfo.State, desired, clusterInfo.StateMessage,
+				a.terminationDetails(clusterInfo), docLink))
 		}
 		return resource.RetryableError(
 			fmt.Errorf("%s is %s, but has to be %s",
@@ -200,8 +286,84 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 	})
 }
 
+// terminationInitScriptFailure is the termination code of clusters with failed init scripts
+const terminationInitScriptFailure = "INIT_SCRIPT_FAILURE"
+
+// terminationDetails enriches errors of clusters, that cannot reach desired state,
+// with information about the reason of termination
+func (a ClustersAPI) terminationDetails(ci ClusterInfo) string {
+	if ci.TerminationReason == nil {
+		return ""
+	}
+	details := fmt.Sprintf(", Termination info: code: %s, type: %s, parameters: %v",
+		ci.TerminationReason.Code, ci.TerminationReason.Type,
+		ci.TerminationReason.Parameters)
+	if ci.TerminationReason.Code == terminationInitScriptFailure {
+		details += a.initScriptFailureDetails(ci)
+	}
+	return details
+}
+
+// initScriptFailureDetails looks up the latest init scripts event to tell, which of the
+// scripts has failed and where its logs are delivered
+func (a ClustersAPI) initScriptFailureDetails(ci ClusterInfo) string {
+	events, err := a.Events(EventsRequest{
+		ClusterID:  ci.ClusterID,
+		Order:      SortDescending,
+		EventTypes: []ClusterEventType{EvTypeInitScriptsFinished},
+		Limit:      1,
+		MaxItems:   1,
+	})
+	if err != nil {
+		log.Printf("[WARN] Cannot get init script events for %s: %s", ci.ClusterID, err)
+		return ""
+	}
+	if len(events) == 0 || events[0].Details.InitScripts == nil {
+		return ""
+	}
+	scripts := events[0].Details.InitScripts
+	var failures []string
+	describe := func(kind string, results []InitScriptExecutionDetails) {
+		for i, result := range results {
+			if result.Status == "" || result.Status == "SUCCEEDED" || result.Status == "SKIPPED" {
+				continue
+			}
+			failure := fmt.Sprintf("%s #%d (%s) %s", kind, i, result.destination(), result.Status)
+			if result.ErrorMessage != "" {
+				failure += ": " + result.ErrorMessage
+			}
+			failures = append(failures, failure)
+		}
+	}
+	describe("global init script", scripts.Global)
+	describe("init_scripts", scripts.Cluster)
+	details := ""
+	if len(failures) > 0 {
+		details = fmt.Sprintf(". Failed %s", strings.Join(failures, "; "))
+	}
+	if location := initScriptLogLocation(ci, scripts.ReportedForNode); location != "" {
+		details += fmt.Sprintf(". Init script logs are delivered to %s", location)
+	}
+	return details
+}
+
+// initScriptLogLocation returns the folder with init script logs of the node, which is
+// <destination>/<cluster-id>/init_scripts/<cluster-id>_<ip with underscores>
+func initScriptLogLocation(ci ClusterInfo, node string) string {
+	if ci.ClusterLogConf == nil || node == "" {
+		return ""
+	}
+	destination := strings.TrimSuffix(ci.ClusterLogConf.destination(), "/")
+	if destination == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/init_scripts/%s_%s/", destination, ci.ClusterID,
+		ci.ClusterID, strings.ReplaceAll(node, ".", "_"))
+}
+
 // Terminate terminates a Spark cluster given its ID
 func (a ClustersAPI) Terminate(clusterID string) error {
+	defer a.invalidateCache(clusterID)
 	err := a.client.Post(a.context, "/clusters/delete", ClusterID{ClusterID: clusterID}, nil)
 	if err != nil {
 		return err
@@ -212,6 +374,7 @@ func (a ClustersAPI) Terminate(clusterID string) error {
 
 // PermanentDelete permanently delete a cluster
 func (a ClustersAPI) PermanentDelete(clusterID string) error {
+	defer a.invalidateCache(clusterID)
 	err := a.Terminate(clusterID)
 	if err != nil {
 		return err
@@ -233,24 +396,76 @@ func (a ClustersAPI) PermanentDelete(clusterID string) error {
 	return a.client.Post(a.context, "/clusters/permanent-delete", r, nil)
 }
 
-// Get retrieves the information for a cluster given its identifier
+// Get retrieves the information for a cluster given its identifier. Missing clusters
+// are always reported as errors, for which common.IsMissing returns true.
 func (a ClustersAPI) Get(clusterID string) (ci ClusterInfo, err error) {
 	err = wrapMissingClusterError(a.client.Get(a.context, "/clusters/get",
 		ClusterID{ClusterID: clusterID}, &ci), clusterID)
 	return
 }
 
+type clusterCacheKey struct {
+	client    *common.DatabricksClient
+	clusterID string
+}
+
+// clusterCache keeps cluster information for the lifetime of the provider process,
+// which is a single terraform operation, like plan or refresh
+type clusterCache struct {
+	mu       sync.Mutex
+	clusters map[clusterCacheKey]ClusterInfo
+}
+
+var readClusterCache = &clusterCache{
+	clusters: map[clusterCacheKey]ClusterInfo{},
+}
+
+// GetCached is the same as Get, but shares results between read paths of the same
+// operation, so that many resources referencing the same existing cluster don't read
+// it over and over. Missing clusters and errors are not cached.
+func (a ClustersAPI) GetCached(clusterID string) (ClusterInfo, error) {
+	key := clusterCacheKey{a.client, clusterID}
+	readClusterCache.mu.Lock()
+	ci, ok := readClusterCache.clusters[key]
+	readClusterCache.mu.Unlock()
+	if ok {
+		return ci, nil
+	}
+	ci, err := a.Get(clusterID)
+	if err != nil {
+		return ci, err
+	}
+	readClusterCache.mu.Lock()
+	readClusterCache.clusters[key] = ci
+	readClusterCache.mu.Unlock()
+	return ci, nil
+}
+
+// invalidateCache is called by every method, that changes the cluster. Listing of all
+// clusters of the workspace is dropped as well, as it includes the changed cluster.
+func (a ClustersAPI) invalidateCache(clusterID string) {
+	readClusterCache.mu.Lock()
+	delete(readClusterCache.clusters, clusterCacheKey{a.client, clusterID})
+	readClusterCache.mu.Unlock()
+	listAllClustersCache.mu.Lock()
+	delete(listAllClustersCache.clusters, a.client)
+	listAllClustersCache.mu.Unlock()
+}
+
 // Pin ensure that an interactive cluster configuration is retained even after a cluster has been terminated for more than 30 days
 func (a ClustersAPI) Pin(clusterID string) error {
+	defer a.invalidateCache(clusterID)
 	return a.client.Post(a.context, "/clusters/pin", ClusterID{ClusterID: clusterID}, nil)
 }
 
 // Unpin allows the cluster to eventually be removed from the list returned by the List API
 func (a ClustersAPI) Unpin(clusterID string) error {
+	defer a.invalidateCache(clusterID)
 	return a.client.Post(a.context, "/clusters/unpin", ClusterID{ClusterID: clusterID}, nil)
 }
 
-// Events - only using Cluster ID string to get all events
+// Events - only using Cluster ID string to get all events. When the context of the API is
+// cancelled while following next pages, events collected so far are returned with context error.
 // https://docs.databricks.com/dev-tools/api/latest/clusters.html#events
 func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error) {
 	var eventsResponse EventsResponse
@@ -269,9 +484,20 @@ func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error)
 	}
 	startPos := 0
 	curPos := len(eventsResponse.Events)
+	if curPos > totalCount {
+		// first page may be larger than requested number of items
+		curPos = totalCount
+	}
 	copy(events[startPos:curPos], eventsResponse.Events)
 	for curPos < totalCount && eventsResponse.NextPage != nil {
+		// stop following pages once Terraform timeout is reached, but keep what was fetched
+		if err := a.context.Err(); err != nil {
+			return events[0:curPos], err
+		}
 		err := a.client.Post(a.context, "/clusters/events", eventsResponse.NextPage, &eventsResponse)
+		if ctxErr := a.context.Err(); err != nil && ctxErr != nil {
+			return events[0:curPos], ctxErr
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -297,12 +523,180 @@ func (a ClustersAPI) List() ([]ClusterInfo, error) {
 	return clusterList.Clusters, err
 }
 
+// ListAll returns all clusters in the workspace, following every page of the list
+func (a ClustersAPI) ListAll() (result []ClusterInfo, err error) {
+	ctx := context.WithValue(a.context, common.Api, common.API_2_1)
+	req := ClusterListRequest{PageSize: 100}
+	for {
+		var page ClusterList
+		err = a.client.Get(ctx, "/clusters/list", req, &page)
+		if err != nil {
+			return
+		}
+		result = append(result, page.Clusters...)
+		if page.NextPageToken == "" {
+			return
+		}
+		req.PageToken = page.NextPageToken
+	}
+}
+
+// clusterListCache keeps all clusters of every workspace for the lifetime of the provider
+// process, so that multiple data sources don't list them over and over
+type clusterListCache struct {
+	mu       sync.Mutex
+	clusters map[*common.DatabricksClient][]ClusterInfo
+}
+
+var listAllClustersCache = &clusterListCache{
+	clusters: map[*common.DatabricksClient][]ClusterInfo{},
+}
+
+// ListAllCached is the same as ListAll, but lists clusters only once per provider process,
+// unless any cluster is changed through ClustersAPI. Errors are not cached.
+func (a ClustersAPI) ListAllCached() ([]ClusterInfo, error) {
+	listAllClustersCache.mu.Lock()
+	clusters, ok := listAllClustersCache.clusters[a.client]
+	listAllClustersCache.mu.Unlock()
+	if ok {
+		return clusters, nil
+	}
+	clusters, err := a.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	listAllClustersCache.mu.Lock()
+	listAllClustersCache.clusters[a.client] = clusters
+	listAllClustersCache.mu.Unlock()
+	return clusters, nil
+}
+
+// ListByName returns all clusters with exactly the given name, including terminated ones.
+// Names are case-sensitive and are not unique, so multiple clusters could be returned.
+func (a ClustersAPI) ListByName(name string) (result []ClusterInfo, err error) {
+	clusters, err := a.ListAll()
+	if err != nil {
+		return
+	}
+	for _, cl := range clusters {
+		if cl.ClusterName == name {
+			result = append(result, cl)
+		}
+	}
+	return
+}
+
+// ListBySource returns all clusters created by the given source, like UI, JOB or API
+func (a ClustersAPI) ListBySource(source Availability) (result []ClusterInfo, err error) {
+	clusters, err := a.ListAll()
+	if err != nil {
+		return
+	}
+	for _, cl := range clusters {
+		if cl.ClusterSource == source {
+			result = append(result, cl)
+		}
+	}
+	return
+}
+
 // ListNodeTypes returns a sorted list of supported Spark node types
 func (a ClustersAPI) ListNodeTypes() (l NodeTypeList, err error) {
 	err = a.client.Get(a.context, "/clusters/list-node-types", nil, &l)
 	return
 }
 
+// nodeTypesCache keeps node types of every workspace for the lifetime of the provider process,
+// as they don't change within a single terraform operation
+type nodeTypesCache struct {
+	mu        sync.Mutex
+	nodeTypes map[*common.DatabricksClient]NodeTypeList
+}
+
+var listNodeTypesCache = &nodeTypesCache{
+	nodeTypes: map[*common.DatabricksClient]NodeTypeList{},
+}
+
+// ListNodeTypesCached is the same as ListNodeTypes, but fetches node types only once
+// per provider process. Errors are not cached.
+func (a ClustersAPI) ListNodeTypesCached() (NodeTypeList, error) {
+	listNodeTypesCache.mu.Lock()
+	l, ok := listNodeTypesCache.nodeTypes[a.client]
+	listNodeTypesCache.mu.Unlock()
+	if ok {
+		return l, nil
+	}
+	l, err := a.ListNodeTypes()
+	if err != nil {
+		return l, err
+	}
+	listNodeTypesCache.mu.Lock()
+	listNodeTypesCache.nodeTypes[a.client] = l
+	listNodeTypesCache.mu.Unlock()
+	return l, nil
+}
+
+// validateNodeType checks that node type is offered in the workspace. Unknown node types
+// are rejected with the suggestion of the closest one and deprecated ones are only logged.
+// Node types are not verified, if they cannot be listed, as workspace may not exist yet.
+func (a ClustersAPI) validateNodeType(attr, nodeTypeID string) error {
+	l, err := a.ListNodeTypesCached()
+	if err != nil {
+		log.Printf("[WARN] cannot verify %s %s: %s", attr, nodeTypeID, err)
+		return nil
+	}
+	closest, minDistance := "", -1
+	for _, nt := range l.NodeTypes {
+		if nt.NodeTypeID == nodeTypeID {
+			if nt.IsDeprecated {
+				log.Printf("[WARN] %s %s is deprecated. Consider using databricks_node_type "+
+					"data source to find the current one", attr, nodeTypeID)
+			}
+			return nil
+		}
+		distance := editDistance(strings.ToLower(nt.NodeTypeID), strings.ToLower(nodeTypeID))
+		if minDistance == -1 || distance < minDistance {
+			closest, minDistance = nt.NodeTypeID, distance
+		}
+	}
+	if closest == "" {
+		return fmt.Errorf("%s %s is not available in this workspace", attr, nodeTypeID)
+	}
+	return fmt.Errorf("%s %s is not available in this workspace. Did you mean %s?",
+		attr, nodeTypeID, closest)
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
 // getOrCreateClusterMutex guards "mounting" cluster creation to prevent multiple
 // redundant instances created at the same name. Compute package private property.
 // https://github.com/databrickslabs/terraform-provider-databricks/issues/445
@@ -318,26 +712,24 @@ func (a ClustersAPI) GetOrCreateRunningCluster(name string, custom ...Cluster) (
 		return
 	}
 
-	clusters, err := a.List()
+	clusters, err := a.ListByName(name)
 	if err != nil {
 		return
 	}
 	for _, cl := range clusters {
-		if cl.ClusterName == name {
-			log.Printf("[INFO] Found reusable cluster '%s'", name)
-
-			clusterAvailable := true
-			if !cl.IsRunningOrResizing() {
-				err = a.Start(cl.ClusterID)
-				if err != nil {
-					clusterAvailable = false
-					log.Printf("[INFO] Cluster %s cannot be started, creating an autoterminating cluster", name)
-				}
-			}
-			if clusterAvailable {
-				return cl, nil
+		log.Printf("[INFO] Found reusable cluster '%s'", name)
+
+		clusterAvailable := true
+		if !cl.IsRunningOrResizing() {
+			err = a.Start(cl.ClusterID)
+			if err != nil {
+				clusterAvailable = false
+				log.Printf("[INFO] Cluster %s cannot be started, creating an autoterminating cluster", name)
 			}
 		}
+		if clusterAvailable {
+			return cl, nil
+		}
 	}
 	smallestNodeType := a.GetSmallestNodeType(NodeTypeRequest{
 		LocalDisk: true,
@@ -376,6 +768,32 @@ type NodeTypeRequest struct {
 	PhotonDriverCapable   bool   `json:"photon_driver_capable,omitempty"`
 	IsIOCacheEnabled      bool   `json:"is_io_cache_enabled,omitempty"`
 	SupportPortForwarding bool   `json:"support_port_forwarding,omitempty"`
+	Role                  string `json:"role,omitempty"`
+}
+
+const (
+	// NodeTypeRoleWorker selects node type for cluster workers, which is the default
+	NodeTypeRoleWorker = "worker"
+	// NodeTypeRoleDriver selects node type for cluster driver with larger default minimums
+	NodeTypeRoleDriver = "driver"
+
+	driverMinCores    = 4
+	driverMinMemoryGB = 16
+)
+
+// withRoleDefaults raises minimum cores and memory for driver node types,
+// unless higher values were explicitly requested
+func (r NodeTypeRequest) withRoleDefaults() NodeTypeRequest {
+	if r.Role != NodeTypeRoleDriver {
+		return r
+	}
+	if r.MinCores < driverMinCores {
+		r.MinCores = driverMinCores
+	}
+	if r.MinMemoryGB < driverMinMemoryGB {
+		r.MinMemoryGB = driverMinMemoryGB
+	}
+	return r
 }
 
 func defaultSmallestNodeType(a ClustersAPI) string {
@@ -396,12 +814,13 @@ func (a ClustersAPI) GetSmallestNodeType(r NodeTypeRequest) string {
 		return defaultSmallestNodeType(a)
 	}
 	list.Sort()
+	r = r.withRoleDefaults()
 	for _, nt := range list.NodeTypes {
 		gbs := (nt.MemoryMB / 1024)
-		if r.MinMemoryGB > 0 && gbs < r.MinMemoryGB {
+		if r.MinMemoryGB > 0 && gbs < int64(r.MinMemoryGB) {
 			continue
 		}
-		if r.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < r.GBPerCore {
+		if r.GBPerCore > 0 && (gbs/int64(nt.NumCores)) < int64(r.GBPerCore) {
 			continue
 		}
 		if r.MinCores > 0 && int32(nt.NumCores) < r.MinCores {
@@ -463,6 +882,64 @@ func extractDbrVersions(s string) string {
 	return s
 }
 
+var runtimeKeyRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(?:x|\d+)-(.*)-scala(\d+\.\d+)$`)
+
+// runtimeKey is a parsed representation of Databricks Runtime key, like 7.3.x-cpu-ml-scala2.12
+type runtimeKey struct {
+	majorMinor string
+	scala      string
+	// variant keeps all tokens between version and scala, like cpu-ml or aarch64-photon,
+	// except for the cosmetic ones returned by the workspace
+	variant string
+}
+
+// cosmeticRuntimeTokens are tokens, that workspace may add to the requested key
+// without changing the runtime
+var cosmeticRuntimeTokens = map[string]bool{
+	"snapshot": true,
+}
+
+func parseRuntimeKey(key string) (rk runtimeKey, ok bool) {
+	m := runtimeKeyRegex.FindStringSubmatch(key)
+	if m == nil {
+		// keys without variant, like 7.3.x-scala2.12
+		m = runtimeKeyRegex.FindStringSubmatch(strings.Replace(key, "-scala", "--scala", 1))
+	}
+	if m == nil {
+		return
+	}
+	tokens := []string{}
+	for _, token := range strings.Split(m[3], "-") {
+		if token == "" || cosmeticRuntimeTokens[token] {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return runtimeKey{
+		majorMinor: m[1] + "." + m[2],
+		scala:      m[4],
+		variant:    strings.Join(tokens, "-"),
+	}, true
+}
+
+// sameRuntimeKeys returns true if both keys point to the same runtime, even if one of them
+// is an alias returned by the workspace, like snapshot or auto-updated patch release.
+// All other tokens, like aarch64 or photon, must match exactly.
+func sameRuntimeKeys(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, ok := parseRuntimeKey(a)
+	if !ok {
+		return false
+	}
+	rb, ok := parseRuntimeKey(b)
+	if !ok {
+		return false
+	}
+	return ra == rb
+}
+
 func (s sparkVersionsType) Less(i, j int) bool {
 	return semver.Compare("v"+extractDbrVersions(s[i]), "v"+extractDbrVersions(s[j])) > 0
 }
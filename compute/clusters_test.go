@@ -1,11 +1,17 @@
 package compute
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
@@ -17,7 +23,7 @@ func TestGetOrCreateRunningCluster_AzureAuth(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list?page_size=100",
 			Response: map[string]interface{}{},
 		},
 		{
@@ -116,7 +122,7 @@ func TestGetOrCreateRunningCluster_Existing_AzureAuth(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list?page_size=100",
 			Response: ClusterList{
 				Clusters: []ClusterInfo{
 					{
@@ -240,6 +246,119 @@ func TestWaitForClusterStatus_NotReachable(t *testing.T) {
 	assert.Contains(t, err.Error(), "code: unknown, type: broken")
 }
 
+func TestWaitForClusterStatus_InitScriptFailure(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:    "abc",
+				State:        ClusterStateTerminated,
+				StateMessage: "Init script failure",
+				ClusterLogConf: &StorageInfo{
+					Dbfs: &DbfsStorageInfo{Destination: "dbfs:/cluster-logs/"},
+				},
+				TerminationReason: &TerminationReason{
+					Code: "INIT_SCRIPT_FAILURE",
+					Type: "CLIENT_ERROR",
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			ExpectedRequest: EventsRequest{
+				ClusterID:  "abc",
+				Order:      SortDescending,
+				EventTypes: []ClusterEventType{EvTypeInitScriptsFinished},
+				Limit:      1,
+			},
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						ClusterID: "abc",
+						Type:      EvTypeInitScriptsFinished,
+						Details: EventDetails{
+							InitScripts: &InitScriptEventDetails{
+								Cluster: []InitScriptExecutionDetails{
+									{
+										InitScriptStorageInfo: InitScriptStorageInfo{
+											Dbfs: &DbfsStorageInfo{Destination: "dbfs:/init/a.sh"},
+										},
+										Status: "SUCCEEDED",
+									},
+									{
+										InitScriptStorageInfo: InitScriptStorageInfo{
+											Dbfs: &DbfsStorageInfo{Destination: "dbfs:/init/b.sh"},
+										},
+										Status:       "FAILED_EXECUTION",
+										ErrorMessage: "Script exit status is non-zero",
+									},
+								},
+								ReportedForNode: "10.0.1.2",
+							},
+						},
+					},
+				},
+				TotalCount: 1,
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).waitForClusterStatus("abc", ClusterStateRunning)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "code: INIT_SCRIPT_FAILURE, type: CLIENT_ERROR")
+	assert.Contains(t, err.Error(), "Failed init_scripts #1 (dbfs:/init/b.sh) "+
+		"FAILED_EXECUTION: Script exit status is non-zero")
+	assert.Contains(t, err.Error(), "Init script logs are delivered to "+
+		"dbfs:/cluster-logs/abc/init_scripts/abc_10_0_1_2/")
+	assert.NotContains(t, err.Error(), "a.sh")
+}
+
+func TestWaitForClusterStatus_InitScriptFailureWithoutEvents(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateTerminated,
+				TerminationReason: &TerminationReason{
+					Code: "INIT_SCRIPT_FAILURE",
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			Status:   500,
+			Response: common.APIErrorBody{
+				Message: "Events are not available",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).waitForClusterStatus("abc", ClusterStateRunning)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "abc is not able to transition from TERMINATED to RUNNING")
+	assert.NotContains(t, err.Error(), "Events are not available")
+}
+
+func TestInitScriptLogLocation(t *testing.T) {
+	ci := ClusterInfo{ClusterID: "abc"}
+	assert.Equal(t, "", initScriptLogLocation(ci, "10.0.1.2"))
+	ci.ClusterLogConf = &StorageInfo{S3: &S3StorageInfo{Destination: "s3://logs/cluster"}}
+	assert.Equal(t, "", initScriptLogLocation(ci, ""))
+	assert.Equal(t, "s3://logs/cluster/abc/init_scripts/abc_10_0_1_2/",
+		initScriptLogLocation(ci, "10.0.1.2"))
+}
+
 func TestWaitForClusterStatus_NormalRetry(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -266,6 +385,58 @@ func TestWaitForClusterStatus_NormalRetry(t *testing.T) {
 	assert.Equal(t, ClusterStateRunning, string(clusterInfo.State))
 }
 
+func TestClustersAPICreate_DebugProgress(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			Response: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State: ClusterStatePending,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State: ClusterStateRunning,
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client.DebugProgress = true
+	_, err = NewClustersAPI(context.Background(), client).Create(Cluster{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "[INFO] [progress] cluster abc: create accepted")
+	assert.Contains(t, buf.String(), "[INFO] [progress] cluster abc: state PENDING at ")
+	assert.Contains(t, buf.String(), "[INFO] [progress] cluster abc: state PENDING -> RUNNING at ")
+}
+
+func TestLogProgress_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	logProgress(&common.DatabricksClient{}, "abc", "create accepted")
+	logProgress(nil, "abc", "create accepted")
+	assert.Equal(t, "", buf.String())
+
+	logProgress(&common.DatabricksClient{DebugProgress: true}, "abc", "%d of %d libraries INSTALLED", 1, 2)
+	assert.Contains(t, buf.String(), "[INFO] [progress] cluster abc: 1 of 2 libraries INSTALLED")
+}
+
 func TestEditCluster_Pending(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -312,6 +483,206 @@ func TestEditCluster_Pending(t *testing.T) {
 	assert.Equal(t, ClusterStateRunning, string(clusterInfo.State))
 }
 
+func TestResizeCluster_WaitsForResizing(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/resize",
+			ExpectedRequest: ResizeRequest{
+				ClusterID: "abc",
+				Autoscale: &AutoScale{
+					MinWorkers: 2,
+					MaxWorkers: 10,
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateResizing,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	clusterInfo, err := NewClustersAPI(ctx, client).Resize(ResizeRequest{
+		ClusterID: "abc",
+		Autoscale: &AutoScale{
+			MinWorkers: 2,
+			MaxWorkers: 10,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ClusterStateRunning, string(clusterInfo.State))
+}
+
+func TestResizeCluster_ToZeroWorkers(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/resize",
+			ExpectedRequest: map[string]interface{}{
+				"cluster_id":  "abc",
+				"num_workers": 0,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	clusterInfo, err := NewClustersAPI(ctx, client).Resize(ResizeRequest{
+		ClusterID:  "abc",
+		NumWorkers: 0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ClusterStateRunning, string(clusterInfo.State))
+}
+
+func TestResizeCluster_TerminatedWhileResizing(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/resize",
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:        ClusterStateTerminating,
+				StateMessage: "Terminated by user",
+				ClusterID:    "abc",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).Resize(ResizeRequest{
+		ClusterID:  "abc",
+		NumWorkers: 5,
+	})
+	qa.AssertErrorStartsWith(t, err, "cluster abc is TERMINATING and cannot complete resize: Terminated by user")
+}
+
+func TestEditCluster_InvalidStateRetry(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateTerminated,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/edit",
+			Status:   400,
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_STATE",
+				Message:   "Cluster abc is in unexpected state Pending.",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStatePending,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/edit",
+			ExpectedRequest: Cluster{
+				ClusterID:   "abc",
+				ClusterName: "Morty",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateRunning,
+				ClusterID: "abc",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	clusterInfo, err := NewClustersAPI(ctx, client).Edit(Cluster{
+		ClusterID:   "abc",
+		ClusterName: "Morty",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ClusterStateRunning, string(clusterInfo.State))
+}
+
+func TestClustersAPIDefaultTimeout(t *testing.T) {
+	assert.Equal(t, 30*time.Minute, NewClustersAPI(context.Background(), &common.DatabricksClient{}).defaultTimeout())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	timeout := NewClustersAPI(ctx, &common.DatabricksClient{}).defaultTimeout()
+	assert.True(t, timeout <= 5*time.Minute && timeout > 4*time.Minute, timeout)
+}
+
 func TestEditCluster_Terminating(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -358,6 +729,54 @@ func TestEditCluster_Terminating(t *testing.T) {
 	assert.Equal(t, ClusterStateTerminated, string(clusterInfo.State))
 }
 
+func TestEditCluster_TerminatingTimeout(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:     ClusterStateTerminating,
+				ClusterID: "abc",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	_, err = NewClustersAPI(ctx, client).Edit(Cluster{
+		ClusterID:   "abc",
+		ClusterName: "Morty",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cluster abc is terminating; wait for TERMINATED then modify")
+}
+
+func TestEditCluster_Unknown(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				State:        ClusterStateUnknown,
+				ClusterID:    "abc",
+				StateMessage: "Something strange is going on",
+			},
+		},
+	})
+	defer server.Close()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = NewClustersAPI(ctx, client).Edit(Cluster{
+		ClusterID:   "abc",
+		ClusterName: "Morty",
+	})
+	assert.EqualError(t, err, "cluster abc is UNKNOWN and cannot be modified: Something strange is going on")
+}
+
 func TestEditCluster_Error(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -759,6 +1178,47 @@ func TestEventsTwoPages(t *testing.T) {
 	assert.Equal(t, clusterEvents[1].Details.TargetNumWorkers, int32(2))
 }
 
+func TestEventsTwoPagesContextTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request EventsRequest
+		err := json.NewDecoder(req.Body).Decode(&request)
+		assert.NoError(t, err, err)
+		if request.Offset > 0 {
+			// next page is never served within the deadline
+			<-req.Context().Done()
+			return
+		}
+		err = json.NewEncoder(rw).Encode(EventsResponse{
+			Events: []ClusterEvent{
+				{
+					ClusterID: "abc",
+					Timestamp: int64(123),
+					Type:      EvTypeRunning,
+				},
+			},
+			TotalCount: 2,
+			NextPage: &EventsRequest{
+				ClusterID: "abc",
+				Offset:    1,
+			},
+		})
+		assert.NoError(t, err, err)
+	}))
+	defer server.Close()
+	client := &common.DatabricksClient{
+		Host:  server.URL,
+		Token: "...",
+	}
+	require.NoError(t, client.Configure())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	clusterEvents, err := NewClustersAPI(ctx, client).Events(EventsRequest{ClusterID: "abc"})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	require.Len(t, clusterEvents, 1)
+	assert.Equal(t, int64(123), clusterEvents[0].Timestamp)
+}
+
 func TestEventsTwoPagesMaxItems(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
@@ -1125,3 +1585,304 @@ func TestListNodeTypes(t *testing.T) {
 	nodeType = api.GetSmallestNodeType(NodeTypeRequest{Category: "Storage Optimized"})
 	assert.Equal(t, nodeType, defaultSmallestNodeType(api))
 }
+
+func TestSameRuntimeKeys(t *testing.T) {
+	for _, tc := range []struct {
+		requested string
+		echoed    string
+		same      bool
+	}{
+		{"7.3.x-scala2.12", "7.3.x-scala2.12", true},
+		{"7.3.x-scala2.12", "7.3.x-snapshot-scala2.12", true},
+		{"7.3.x-scala2.12", "7.3.15-scala2.12", true},
+		{"9.1.x-cpu-ml-scala2.12", "9.1.x-snapshot-cpu-ml-scala2.12", true},
+		{"9.1.x-gpu-ml-scala2.12", "9.1.7-gpu-ml-scala2.12", true},
+		{"9.1.x-photon-scala2.12", "9.1.x-snapshot-photon-scala2.12", true},
+		{"7.3.x-hls-scala2.12", "7.3.x-snapshot-hls-scala2.12", true},
+		{"7.3.x-scala2.12", "7.4.x-scala2.12", false},
+		{"7.3.x-scala2.12", "7.3.x-cpu-ml-scala2.12", false},
+		{"9.1.x-cpu-ml-scala2.12", "9.1.x-gpu-ml-scala2.12", false},
+		{"9.1.x-scala2.12", "9.1.x-photon-scala2.12", false},
+		{"6.4.x-scala2.11", "6.4.x-scala2.12", false},
+		{"custom:7.3.x", "7.3.x-scala2.12", false},
+		{"11.3.x-aarch64-scala2.12", "11.3.x-snapshot-aarch64-scala2.12", true},
+		{"11.3.x-aarch64-scala2.12", "11.3.x-scala2.12", false},
+		{"11.3.x-aarch64-photon-scala2.12", "11.3.x-photon-scala2.12", false},
+		{"11.3.x-aarch64-photon-scala2.12", "11.3.x-aarch64-scala2.12", false},
+		{"11.3.x-cpu-ml-scala2.12", "11.3.x-aarch64-cpu-ml-scala2.12", false},
+	} {
+		assert.Equal(t, tc.same, sameRuntimeKeys(tc.requested, tc.echoed),
+			"%s vs %s", tc.requested, tc.echoed)
+	}
+}
+
+func clusterListFixture() qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.1/clusters/list?page_size=100",
+		Response: ClusterList{
+			Clusters: []ClusterInfo{
+				{
+					ClusterID:     "a",
+					ClusterName:   "Shared Autoscaling",
+					ClusterSource: ClusterSourceUI,
+					State:         ClusterStateRunning,
+				},
+				{
+					ClusterID:     "b",
+					ClusterName:   "Shared Autoscaling",
+					ClusterSource: ClusterSourceAPI,
+					State:         ClusterStateTerminated,
+				},
+				{
+					ClusterID:     "c",
+					ClusterName:   "shared autoscaling",
+					ClusterSource: ClusterSourceUI,
+					State:         ClusterStateRunning,
+				},
+				{
+					ClusterID:     "d",
+					ClusterName:   "job-123-run-1",
+					ClusterSource: ClusterSourceJob,
+					State:         ClusterStateTerminated,
+				},
+			},
+		},
+	}
+}
+
+func clusterIDs(clusters []ClusterInfo) (ids []string) {
+	for _, cl := range clusters {
+		ids = append(ids, cl.ClusterID)
+	}
+	return
+}
+
+func TestListByName(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{clusterListFixture()},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			clustersAPI := NewClustersAPI(ctx, client)
+
+			clusters, err := clustersAPI.ListByName("Shared Autoscaling")
+			require.NoError(t, err)
+			// duplicates and terminated clusters are returned, but not the ones with different case
+			assert.Equal(t, []string{"a", "b"}, clusterIDs(clusters))
+
+			clusters, err = clustersAPI.ListByName("Nonexistent")
+			require.NoError(t, err)
+			assert.Len(t, clusters, 0)
+		})
+}
+
+func TestListBySource(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{clusterListFixture()},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			clustersAPI := NewClustersAPI(ctx, client)
+
+			clusters, err := clustersAPI.ListBySource(ClusterSourceUI)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a", "c"}, clusterIDs(clusters))
+
+			clusters, err = clustersAPI.ListBySource(ClusterSourceJob)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"d"}, clusterIDs(clusters))
+		})
+}
+
+func TestListByName_MultiplePages(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/clusters/list?page_size=100",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID:     "a",
+						ClusterName:   "Other",
+						ClusterSource: ClusterSourceUI,
+					},
+				},
+				NextPageToken: "next",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/clusters/list?page_size=100&page_token=next",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{
+						ClusterID:     "b",
+						ClusterName:   "Shared Autoscaling",
+						ClusterSource: ClusterSourceJob,
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+
+		clusters, err := clustersAPI.ListByName("Shared Autoscaling")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b"}, clusterIDs(clusters))
+
+		clusters, err = clustersAPI.ListBySource(ClusterSourceJob)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b"}, clusterIDs(clusters))
+	})
+}
+
+func TestListByName_Error(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/clusters/list?page_size=100",
+			Status:       404,
+			Response: common.APIErrorBody{
+				ErrorCode: "NOT_FOUND",
+				Message:   "nope",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		_, err := NewClustersAPI(ctx, client).ListByName("Shared Autoscaling")
+		qa.AssertErrorStartsWith(t, err, "nope")
+		_, err = NewClustersAPI(ctx, client).ListBySource(ClusterSourceUI)
+		qa.AssertErrorStartsWith(t, err, "nope")
+	})
+}
+
+func TestClustersAPIGetCached(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateTerminated,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/pin",
+			ExpectedRequest: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		for i := 0; i < 3; i++ {
+			ci, err := clustersAPI.GetCached("abc")
+			require.NoError(t, err)
+			assert.Equal(t, ClusterStateTerminated, string(ci.State))
+		}
+		require.NoError(t, clustersAPI.Pin("abc"))
+		ci, err := clustersAPI.GetCached("abc")
+		require.NoError(t, err)
+		assert.Equal(t, ClusterStateRunning, string(ci.State))
+	})
+}
+
+func TestClustersAPIGetCached_Missing(t *testing.T) {
+	missing := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=abc",
+		Response: common.APIErrorBody{
+			ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+			Message:   "No such cluster",
+		},
+		Status: 400,
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{missing, missing}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		// missing clusters are not cached, so both calls reach the API
+		for i := 0; i < 2; i++ {
+			_, err := clustersAPI.GetCached("abc")
+			assert.True(t, common.IsMissing(err), "%v", err)
+		}
+	})
+}
+
+func TestClustersAPIGetCached_Concurrent(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			go func() {
+				_, err := clustersAPI.GetCached("abc")
+				if err == nil {
+					clustersAPI.invalidateCache("abc")
+				}
+				errs <- err
+			}()
+		}
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, <-errs)
+		}
+	})
+}
+
+func TestClustersAPIValidateNodeType(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list-node-types",
+			Response: NodeTypeList{
+				NodeTypes: []NodeType{
+					{NodeTypeID: "Standard_DS3_v2"},
+					{NodeTypeID: "Standard_F4s", IsDeprecated: true},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		assert.NoError(t, clustersAPI.validateNodeType("node_type_id", "Standard_DS3_v2"))
+		// deprecated node types are only logged, and the list is fetched only once
+		assert.NoError(t, clustersAPI.validateNodeType("node_type_id", "Standard_F4s"))
+		assert.EqualError(t, clustersAPI.validateNodeType("node_type_id", "standard_ds3_v3"),
+			"node_type_id standard_ds3_v3 is not available in this workspace. Did you mean Standard_DS3_v2?")
+	})
+}
+
+func TestClustersAPIValidateNodeType_ListError(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list-node-types",
+			Status:   400,
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_STATE",
+				Message:   "Workspace is not ready",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		assert.NoError(t, NewClustersAPI(ctx, client).validateNodeType("node_type_id", "i3.xlarge"))
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("i3.xlarge", "i3.xlarge"))
+	assert.Equal(t, 1, editDistance("i3.xlarge", "i3.xlarg"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 5, editDistance("", "m5.xl"))
+}
@@ -99,6 +99,43 @@ func (a CommandsAPI) Execute(clusterID, language, commandStr string) common.Comm
 	return *command.Results
 }
 
+// ExecuteWhenReady waits for the cluster to be running with an initialized spark context
+// and then executes the command, so that ad-hoc commands could run right after cluster start
+func (a CommandsAPI) ExecuteWhenReady(clusterID, language, commandStr string) common.CommandResults {
+	err := a.waitForSparkContext(clusterID)
+	if err != nil {
+		return common.CommandResults{
+			ResultType: "error",
+			Summary:    err.Error(),
+		}
+	}
+	return a.Execute(clusterID, language, commandStr)
+}
+
+// waitForSparkContext waits until cluster is RUNNING and has non-zero spark_context_id,
+// as execution contexts cannot be created on the driver before that
+func (a CommandsAPI) waitForSparkContext(clusterID string) error {
+	ctx := context.WithValue(a.context, common.Api, common.API_2_0)
+	clusters := NewClustersAPI(ctx, a.client)
+	return resource.RetryContext(ctx, clusters.defaultTimeout(), func() *resource.RetryError {
+		cluster, err := clusters.Get(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if !cluster.State.CanReach(ClusterStateRunning) {
+			return resource.NonRetryableError(fmt.Errorf(
+				"cluster %s is %s and cannot become RUNNING", clusterID, cluster.State))
+		}
+		if cluster.State == ClusterStateRunning && cluster.SparkContextID != 0 {
+			return nil
+		}
+		log.Printf("[DEBUG] Cluster %s is %s with spark context %d", clusterID,
+			cluster.State, cluster.SparkContextID)
+		return resource.RetryableError(fmt.Errorf(
+			"cluster %s is %s without spark context", clusterID, cluster.State))
+	})
+}
+
 type genericCommandRequest struct {
 	CommandID string `json:"commandId,omitempty" url:"commandId,omitempty"`
 	Language  string `json:"language,omitempty" url:"language,omitempty"`
@@ -547,3 +547,66 @@ func TestAccContext(t *testing.T) {
  		dbutils.notebook.exit("success")`)
 	assert.Equal(t, "success", result.Text())
 }
+
+func TestCommandsAPIExecuteWhenReady(t *testing.T) {
+	finished := commonFixtureWithStatusResponse(Command{
+		Status: "Finished",
+		Results: &common.CommandResults{
+			ResultType: "text",
+			Data:       "done",
+		},
+	})
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStatePending,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:      "abc",
+				State:          ClusterStateRunning,
+				SparkContextID: 8973498,
+			},
+		},
+	}
+	for _, fixture := range finished {
+		if fixture.Resource == "/api/1.2/commands/status?clusterId=abc&commandId=234&contextId=123" {
+			fixtures = append(fixtures, qa.HTTPFixture{
+				Method:   "GET",
+				Resource: fixture.Resource,
+				Response: Command{
+					Status: "Running",
+				},
+			})
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	qa.HTTPFixturesApply(t, fixtures, func(ctx context.Context, client *common.DatabricksClient) {
+		result := NewCommandsAPI(ctx, client).ExecuteWhenReady("abc", "python", `print("done")`)
+		assert.Equal(t, false, result.Failed())
+		assert.Equal(t, "done", result.Text())
+	})
+}
+
+func TestCommandsAPIExecuteWhenReady_Terminated(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateTerminated,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		result := NewCommandsAPI(ctx, client).ExecuteWhenReady("abc", "python", `print("done")`)
+		assert.Equal(t, true, result.Failed())
+		assert.Equal(t, "cluster abc is TERMINATED and cannot become RUNNING", result.Error())
+	})
+}
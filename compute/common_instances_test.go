@@ -66,7 +66,7 @@ func TestNewTinyClusterInCommonPoolPossiblyReused(t *testing.T) {
 		},
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/clusters/list",
+			Resource: "/api/2.1/clusters/list?page_size=100",
 			Response: map[string]interface{}{},
 		},
 		{
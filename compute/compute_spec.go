@@ -0,0 +1,96 @@
+package compute
+
+import (
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// computeSpecOptions tells, which parts of Cluster specification are available to
+// a consumer of it, like databricks_cluster resource or new_cluster block of a job.
+type computeSpecOptions struct {
+	// allowLibraries adds `library` blocks, that are installed on interactive clusters
+	allowLibraries bool
+	// allowAutoterminate keeps `autotermination_minutes`, that is meaningless for job clusters
+	allowAutoterminate bool
+	// allowIdempotencyToken keeps `idempotency_token`, that is used only by clusters/create
+	allowIdempotencyToken bool
+	// allowAutoscaleMode keeps `autoscale.mode`, that is supported only by job and pipeline clusters
+	allowAutoscaleMode bool
+}
+
+// computeSpecSchema applies customizations, that are shared by every schema generated from
+// Cluster structure, so that validations behave the same way regardless of where the
+// cluster is defined. Settings depending on the top-level resource, like `ConflictsWith`
+// or diff suppression reading other attributes, are left to consumers.
+func computeSpecSchema(s map[string]*schema.Schema, opts computeSpecOptions) map[string]*schema.Schema {
+	markClusterSensitiveFields(s)
+	s["spark_env_vars"].ValidateDiagFunc = validatePysparkPython
+	if p, err := common.SchemaPath(s, "docker_image", "url"); err == nil {
+		p.ValidateDiagFunc = validateDockerImageURL
+	}
+	if p, err := common.SchemaPath(s, "gcp_attributes", "zone_id"); err == nil {
+		p.ValidateFunc = validateGcpZoneID
+	}
+	if p, ok := s["data_security_mode"]; ok {
+		p.ValidateFunc = validation.StringInSlice([]string{
+			DataSecurityModeNone,
+			DataSecurityModeSingleUser,
+			DataSecurityModeUserIsolation,
+		}, false)
+	}
+	s["num_workers"] = &schema.Schema{
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Default:          0,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+	}
+	if opts.allowLibraries {
+		s["library"] = common.StructToSchema(ClusterLibraryList{},
+			func(ss map[string]*schema.Schema) map[string]*schema.Schema {
+				return ss
+			})["library"]
+		customizeLibrarySchema(s)
+	}
+	if opts.allowAutoterminate {
+		s["autotermination_minutes"].Default = 60
+		s["autotermination_minutes"].ValidateFunc = validateAutoterminationMinutes
+	} else {
+		ignoreComputeSpecField(s, "autotermination_minutes", "job clusters terminate with the run")
+	}
+	if !opts.allowIdempotencyToken {
+		ignoreComputeSpecField(s, "idempotency_token", "it is used only by interactive clusters")
+	}
+	customizeAutoscaleModeSchema(s, opts.allowAutoscaleMode)
+	return s
+}
+
+// ignoreComputeSpecField deprecates the field, that doesn't apply to the consumer, but is
+// kept in schema, so that existing configurations setting it are still valid. Its value is
+// never part of the diff, so it's neither sent to the API nor stored in the state.
+func ignoreComputeSpecField(s map[string]*schema.Schema, field, reason string) {
+	s[field].Deprecated = fmt.Sprintf("%s is ignored, as %s", field, reason)
+	s[field].ForceNew = false
+	s[field].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+		return true
+	}
+}
+
+// customizeAutoscaleModeSchema validates `autoscale.mode` or removes it from schema of
+// interactive clusters, where the API doesn't support it
+func customizeAutoscaleModeSchema(s map[string]*schema.Schema, allow bool) {
+	autoscale, ok := s["autoscale"].Elem.(*schema.Resource)
+	if !ok {
+		return
+	}
+	if !allow {
+		delete(autoscale.Schema, "mode")
+		return
+	}
+	autoscale.Schema["mode"].ValidateFunc = validation.StringInSlice([]string{
+		AutoScaleModeEnhanced,
+		AutoScaleModeLegacy,
+	}, false)
+}
@@ -0,0 +1,191 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeSpecSchemaConsumers(t *testing.T) {
+	jobCluster := func(path ...string) map[string]*schema.Schema {
+		p, err := common.SchemaPath(jobSchema, path...)
+		require.NoError(t, err)
+		return p.Elem.(*schema.Resource).Schema
+	}
+	consumers := map[string]map[string]*schema.Schema{
+		"databricks_cluster":      clusterSchema,
+		"job.new_cluster":         jobCluster("new_cluster"),
+		"job.task.new_cluster":    jobCluster("task", "new_cluster"),
+		"job.job_cluster.cluster": jobCluster("job_cluster", "new_cluster"),
+	}
+	for name, s := range consumers {
+		t.Run(name, func(t *testing.T) {
+			isCluster := name == "databricks_cluster"
+			for field, expected := range map[string]bool{
+				"library":                 isCluster,
+				"autotermination_minutes": true,
+				"idempotency_token":       true,
+				"spark_version":           true,
+				"num_workers":             true,
+			} {
+				_, ok := s[field]
+				assert.Equal(t, expected, ok, field)
+			}
+			// existing job configurations setting them are still valid
+			for _, field := range []string{"autotermination_minutes", "idempotency_token"} {
+				assert.Equal(t, !isCluster, s[field].Deprecated != "", field)
+			}
+			assert.Equal(t, isCluster, s["autotermination_minutes"].ValidateFunc != nil)
+			assert.Equal(t, 0, s["num_workers"].Default)
+			assert.NotNil(t, s["num_workers"].ValidateDiagFunc)
+			assert.NotNil(t, s["spark_env_vars"].ValidateDiagFunc)
+
+			url, err := common.SchemaPath(s, "docker_image", "url")
+			require.NoError(t, err)
+			assert.NotNil(t, url.ValidateDiagFunc)
+
+			password, err := common.SchemaPath(s, "docker_image", "basic_auth", "password")
+			require.NoError(t, err)
+			assert.True(t, password.Sensitive)
+
+			// interactive clusters don't support autoscale modes
+			mode, err := common.SchemaPath(s, "autoscale", "mode")
+			if isCluster {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, mode.ValidateFunc)
+			}
+		})
+	}
+	assert.Equal(t, 60, clusterSchema["autotermination_minutes"].Default)
+}
+
+func TestResourceJobCreate_IgnoredClusterFields(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					NewCluster: &Cluster{
+						SparkVersion: "7.3.x-scala2.12",
+						NodeTypeID:   "i3.xlarge",
+						NumWorkers:   1,
+					},
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Shared/etl",
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						NewCluster: &Cluster{
+							SparkVersion: "7.3.x-scala2.12",
+							NodeTypeID:   "i3.xlarge",
+							NumWorkers:   1,
+						},
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Shared/etl",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+		new_cluster {
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "i3.xlarge"
+			num_workers = 1
+			autotermination_minutes = 30
+			idempotency_token = "abc"
+		}
+		notebook_task {
+			notebook_path = "/Shared/etl"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+}
+
+func TestAutoscaleModeRoundTrip(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				autoscale {
+					mode = "AGGRESSIVE"
+				}
+			}
+			notebook_task {
+				notebook_path = "/Shared/etl"
+			}
+		}`,
+	}.Apply(t)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected task.0.new_cluster.0.autoscale.0.mode "+
+		"to be one of [ENHANCED LEGACY], got AGGRESSIVE")
+
+	// mode returned by the API for interactive clusters is ignored
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:    "abc",
+					SparkVersion: "7.3.x-scala2.12",
+					NodeTypeID:   "i3.xlarge",
+					AutoScale: &AutoScale{
+						MinWorkers: 1,
+						MaxWorkers: 4,
+						Mode:       AutoScaleModeLegacy,
+					},
+					State: ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 4, d.Get("autoscale.0.max_workers"))
+}
@@ -0,0 +1,69 @@
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// idleMinutes returns minutes since the last activity on running cluster or zero otherwise
+func (ci ClusterInfo) idleMinutes(now time.Time) int64 {
+	if ci.State != ClusterStateRunning || ci.LastActivityTime == 0 {
+		return 0
+	}
+	idle := now.UnixNano()/int64(time.Millisecond) - ci.LastActivityTime
+	if idle < 0 {
+		return 0
+	}
+	return idle / int64(time.Minute/time.Millisecond)
+}
+
+// DataSourceCluster returns state and activity times of a cluster, that are not part
+// of databricks_cluster resource, so that they never cause diffs
+func DataSourceCluster() *schema.Resource {
+	type entity struct {
+		ClusterID        string `json:"cluster_id"`
+		ClusterName      string `json:"cluster_name,omitempty" tf:"computed"`
+		State            string `json:"state,omitempty" tf:"computed"`
+		StartTime        int64  `json:"start_time,omitempty" tf:"computed"`
+		LastActivityTime int64  `json:"last_activity_time,omitempty" tf:"computed"`
+		IdleMinutes      int64  `json:"idle_minutes,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			clusterInfo, err := NewClustersAPI(ctx, m).GetCached(this.ClusterID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.ClusterName = clusterInfo.ClusterName
+			this.State = string(clusterInfo.State)
+			// times of the previous run are not relevant for terminated clusters
+			if clusterInfo.State != ClusterStateTerminated &&
+				clusterInfo.State != ClusterStateTerminating {
+				this.StartTime = clusterInfo.StartTime
+				this.LastActivityTime = clusterInfo.LastActivityTime
+			}
+			this.IdleMinutes = clusterInfo.idleMinutes(time.Now())
+			d.SetId(clusterInfo.ClusterID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
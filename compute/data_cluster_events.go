@@ -0,0 +1,135 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type clusterEvent struct {
+	Timestamp         int64    `json:"timestamp"`
+	Type              string   `json:"type"`
+	CurrentNumWorkers int32    `json:"current_num_workers,omitempty"`
+	TargetNumWorkers  int32    `json:"target_num_workers,omitempty"`
+	ReasonCode        string   `json:"reason_code,omitempty"`
+	InstanceIDs       []string `json:"instance_ids,omitempty"`
+}
+
+type autoscaleHistoryEntry struct {
+	Timestamp         int64  `json:"timestamp"`
+	Type              string `json:"type"`
+	CurrentNumWorkers int32  `json:"current_num_workers,omitempty"`
+	TargetNumWorkers  int32  `json:"target_num_workers,omitempty"`
+	NodesLost         int32  `json:"nodes_lost,omitempty"`
+}
+
+// isAutoscaleEvent returns true for events, that change the number of workers
+func (ce ClusterEvent) isAutoscaleEvent() bool {
+	switch ce.Type {
+	case EvTypeResizing, EvTypeUpsizeCompleted, EvTypeNodesLost:
+		return true
+	}
+	return false
+}
+
+// validateClusterEventType checks that event type is one of the types known to Clusters API
+func validateClusterEventType(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	valid := []string{}
+	for _, eventType := range clusterEventTypes {
+		if string(eventType) == v {
+			return
+		}
+		valid = append(valid, string(eventType))
+	}
+	errors = append(errors, fmt.Errorf("%s must be one of %s, got: %s",
+		k, strings.Join(valid, ", "), v))
+	return
+}
+
+// DataSourceClusterEvents returns the most recent events of a cluster and the history
+// of its size changes, including nodes lost by the cloud provider
+func DataSourceClusterEvents() *schema.Resource {
+	type entity struct {
+		ClusterID        string                  `json:"cluster_id"`
+		EventTypes       []string                `json:"event_types,omitempty"`
+		MaxItems         int32                   `json:"max_items,omitempty"`
+		Events           []clusterEvent          `json:"events,omitempty" tf:"computed"`
+		AutoscaleHistory []autoscaleHistoryEntry `json:"autoscale_history,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["event_types"].Elem.(*schema.Schema).ValidateFunc = validateClusterEventType
+		s["max_items"].Default = 100
+		s["max_items"].ValidateFunc = validation.IntBetween(1, 500)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			eventTypes := []ClusterEventType{}
+			for _, et := range this.EventTypes {
+				eventTypes = append(eventTypes, ClusterEventType(et))
+			}
+			events, err := NewClustersAPI(ctx, m).Events(EventsRequest{
+				ClusterID:  this.ClusterID,
+				Order:      SortDescending,
+				EventTypes: eventTypes,
+				MaxItems:   uint(this.MaxItems),
+				// max_items is never above the largest page size of 500 events
+				Limit: int64(this.MaxItems),
+			})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Events = []clusterEvent{}
+			this.AutoscaleHistory = []autoscaleHistoryEntry{}
+			for _, event := range events {
+				ce := clusterEvent{
+					Timestamp:         event.Timestamp,
+					Type:              string(event.Type),
+					CurrentNumWorkers: event.Details.CurrentNumWorkers,
+					TargetNumWorkers:  event.Details.TargetNumWorkers,
+					InstanceIDs:       event.Details.InstanceIDs,
+				}
+				if event.Details.Reason != nil {
+					ce.ReasonCode = event.Details.Reason.Code
+				}
+				this.Events = append(this.Events, ce)
+				if !event.isAutoscaleEvent() {
+					continue
+				}
+				entry := autoscaleHistoryEntry{
+					Timestamp:         event.Timestamp,
+					Type:              string(event.Type),
+					CurrentNumWorkers: event.Details.CurrentNumWorkers,
+					TargetNumWorkers:  event.Details.TargetNumWorkers,
+				}
+				if event.Type == EvTypeNodesLost {
+					entry.NodesLost = int32(len(event.Details.InstanceIDs))
+				}
+				this.AutoscaleHistory = append(this.AutoscaleHistory, entry)
+			}
+			d.SetId(this.ClusterID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,195 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterEventsDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID: "abc",
+					Order:     SortDescending,
+					Limit:     100,
+				},
+				// instance_ids are present only for NODES_LOST events
+				Response: `{
+					"events": [
+						{
+							"cluster_id": "abc",
+							"timestamp": 3000,
+							"type": "NODES_LOST",
+							"details": {
+								"current_num_workers": 3,
+								"target_num_workers": 5,
+								"reason": {"code": "SPOT_INSTANCE_TERMINATION"},
+								"instance_ids": ["i-1", "i-2"]
+							}
+						},
+						{
+							"cluster_id": "abc",
+							"timestamp": 2000,
+							"type": "EDITED",
+							"details": {"user": "a@b.c"}
+						},
+						{
+							"cluster_id": "abc",
+							"timestamp": 1000,
+							"type": "RESIZING",
+							"details": {
+								"current_num_workers": 2,
+								"target_num_workers": 5
+							}
+						}
+					],
+					"total_count": 3
+				}`,
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL:         `cluster_id = "abc"`,
+		ID:          "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 3, d.Get("events.#"))
+	assert.Equal(t, "NODES_LOST", d.Get("events.0.type"))
+	assert.Equal(t, "SPOT_INSTANCE_TERMINATION", d.Get("events.0.reason_code"))
+	assert.Equal(t, []interface{}{"i-1", "i-2"}, d.Get("events.0.instance_ids"))
+	assert.Equal(t, 0, d.Get("events.1.instance_ids.#"))
+	assert.Equal(t, 2, d.Get("autoscale_history.#"))
+	assert.Equal(t, 2, d.Get("autoscale_history.0.nodes_lost"))
+	assert.Equal(t, 3, d.Get("autoscale_history.0.current_num_workers"))
+	assert.Equal(t, "RESIZING", d.Get("autoscale_history.1.type"))
+	assert.Equal(t, 0, d.Get("autoscale_history.1.nodes_lost"))
+}
+
+func TestClusterEventsDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Cluster abc does not exist",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL:         `cluster_id = "abc"`,
+		ID:          "_",
+	}.ExpectError(t, "Cluster abc does not exist")
+}
+
+func TestClusterEventsDataSource_EventTypes(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypeNodesLost, EvTypeTerminating},
+					Limit:      100,
+				},
+				Response: EventsResponse{},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL: `
+		cluster_id = "abc"
+		event_types = ["NODES_LOST", "TERMINATING"]`,
+		ID: "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 0, d.Get("events.#"))
+}
+
+func TestClusterEventsDataSource_MaxItemsBelowPageSize(t *testing.T) {
+	events := []ClusterEvent{}
+	for i := 0; i < 50; i++ {
+		events = append(events, ClusterEvent{
+			ClusterID: "abc",
+			Timestamp: int64(1000 - i),
+			Type:      EvTypeRunning,
+		})
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID: "abc",
+					Order:     SortDescending,
+					Limit:     10,
+				},
+				// page is larger than requested
+				Response: EventsResponse{
+					Events:     events,
+					TotalCount: 120,
+					NextPage: &EventsRequest{
+						ClusterID: "abc",
+						Offset:    50,
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL: `
+		cluster_id = "abc"
+		max_items = 10`,
+		ID: "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 10, d.Get("events.#"))
+	assert.Equal(t, 991, d.Get("events.9.timestamp"))
+}
+
+func TestClusterEventsDataSource_InvalidEventType(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL: `
+		cluster_id = "abc"
+		event_types = ["NODES_LOST", "NODE_LOST"]`,
+		ID: "_",
+	}.ExpectError(t, "invalid config supplied. [event_types.#] event_types.1 must be one of "+
+		"CREATING, DID_NOT_EXPAND_DISK, EXPANDED_DISK, FAILED_TO_EXPAND_DISK, "+
+		"INIT_SCRIPTS_STARTING, INIT_SCRIPTS_FINISHED, STARTING, RESTARTING, TERMINATING, "+
+		"EDITED, RUNNING, RESIZING, UPSIZE_COMPLETED, NODES_LOST, DRIVER_HEALTHY, "+
+		"DRIVER_UNAVAILABLE, SPARK_EXCEPTION, DRIVER_NOT_RESPONDING, DBFS_DOWN, "+
+		"METASTORE_DOWN, NODE_BLACKLISTED, PINNED, UNPINNED, got: NODE_LOST")
+}
+
+func TestValidateClusterEventType(t *testing.T) {
+	for _, eventType := range clusterEventTypes {
+		_, errs := validateClusterEventType(string(eventType), "event_types.0")
+		assert.Len(t, errs, 0, eventType)
+	}
+	_, errs := validateClusterEventType("resizing", "event_types.0")
+	assert.Len(t, errs, 1)
+	_, errs = validateClusterEventType(1, "event_types.0")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "expected type of event_types.0 to be string")
+}
@@ -0,0 +1,40 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceClusterPolicyAllowlist renders allowlist rule of cluster policy definition
+func DataSourceClusterPolicyAllowlist() *schema.Resource {
+	type entity struct {
+		Path       string   `json:"path"`
+		Values     []string `json:"values"`
+		Definition string   `json:"definition,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Definition = AllowlistPolicyDefinition(this.Path, this.Values)
+			d.SetId(this.Path)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
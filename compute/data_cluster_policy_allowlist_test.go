@@ -0,0 +1,39 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowlistPolicyDefinition(t *testing.T) {
+	assert.Equal(t, `{"node_type_id":{"type":"allowlist","values":["i3.xlarge","m5d.large","r5.\"quoted\""]}}`,
+		AllowlistPolicyDefinition("node_type_id", []string{
+			"m5d.large", `r5."quoted"`, "i3.xlarge", "m5d.large"}))
+	assert.Equal(t, `{"spark_version":{"type":"allowlist"}}`,
+		AllowlistPolicyDefinition("spark_version", nil))
+}
+
+func TestAllowlistPolicyDefinition_Stable(t *testing.T) {
+	assert.Equal(t,
+		AllowlistPolicyDefinition("spark_version", []string{"b", "a", "c"}),
+		AllowlistPolicyDefinition("spark_version", []string{"c", "b", "a", "a"}))
+}
+
+func TestDataSourceClusterPolicyAllowlist(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceClusterPolicyAllowlist(),
+		NonWritable: true,
+		HCL: `
+		path = "driver_node_type_id"
+		values = ["m5d.xlarge", "i3.xlarge"]
+		`,
+		ID: "_",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "driver_node_type_id", d.Id())
+	assert.Equal(t, `{"driver_node_type_id":{"type":"allowlist","values":["i3.xlarge","m5d.xlarge"]}}`,
+		d.Get("definition"))
+}
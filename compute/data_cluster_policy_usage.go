@@ -0,0 +1,107 @@
+package compute
+
+import (
+	"context"
+	"sort"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type policyUsageCluster struct {
+	ClusterID   string `json:"cluster_id"`
+	ClusterName string `json:"cluster_name,omitempty"`
+}
+
+// newClusters returns all cluster definitions, that are created for the job runs
+func (js JobSettings) newClusters() (clusters []*Cluster) {
+	if js.NewCluster != nil {
+		clusters = append(clusters, js.NewCluster)
+	}
+	for _, jc := range js.JobClusters {
+		if jc.NewCluster != nil {
+			clusters = append(clusters, jc.NewCluster)
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.NewCluster != nil {
+			clusters = append(clusters, task.NewCluster)
+		}
+	}
+	return
+}
+
+// usesPolicy returns true if any of the job clusters is governed by the policy
+func (j Job) usesPolicy(policyID string) bool {
+	if j.Settings == nil {
+		return false
+	}
+	for _, cluster := range j.Settings.newClusters() {
+		if cluster.PolicyID == policyID {
+			return true
+		}
+	}
+	return false
+}
+
+// DataSourceClusterPolicyUsage lists clusters and jobs, that reference the cluster policy,
+// so that it's known what is affected before policy is deleted or tightened
+func DataSourceClusterPolicyUsage() *schema.Resource {
+	type entity struct {
+		PolicyID string               `json:"policy_id"`
+		Clusters []policyUsageCluster `json:"clusters,omitempty" tf:"computed"`
+		JobIDs   []int64              `json:"job_ids,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			clusters, err := NewClustersAPI(ctx, m).ListAll()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Clusters = []policyUsageCluster{}
+			for _, ci := range clusters {
+				if ci.PolicyID != this.PolicyID {
+					continue
+				}
+				this.Clusters = append(this.Clusters, policyUsageCluster{
+					ClusterID:   ci.ClusterID,
+					ClusterName: ci.ClusterName,
+				})
+			}
+			sort.Slice(this.Clusters, func(i, j int) bool {
+				return this.Clusters[i].ClusterID < this.Clusters[j].ClusterID
+			})
+			jobs, err := NewJobsAPI(ctx, m).ListAll()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.JobIDs = []int64{}
+			for _, job := range jobs {
+				if job.usesPolicy(this.PolicyID) {
+					this.JobIDs = append(this.JobIDs, job.JobID)
+				}
+			}
+			sort.Slice(this.JobIDs, func(i, j int) bool {
+				return this.JobIDs[i] < this.JobIDs[j]
+			})
+			d.SetId(this.PolicyID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
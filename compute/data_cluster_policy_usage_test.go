@@ -0,0 +1,128 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterPolicyUsageDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?page_size=100",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{ClusterID: "def", ClusterName: "Second", PolicyID: "abc"},
+						{ClusterID: "xyz", ClusterName: "Other", PolicyID: "other"},
+					},
+					NextPageToken: "next",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?page_size=100&page_token=next",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{ClusterID: "bcd", ClusterName: "First", PolicyID: "abc"},
+						{ClusterID: "no-policy", ClusterName: "Unrestricted"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?expand_tasks=true&limit=25",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID: 30,
+							Settings: &JobSettings{
+								NewCluster: &Cluster{PolicyID: "abc"},
+							},
+						},
+						{
+							JobID: 20,
+							Settings: &JobSettings{
+								ExistingClusterID: "bcd",
+							},
+						},
+					},
+					HasMore: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?expand_tasks=true&limit=25&offset=2",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID: 10,
+							Settings: &JobSettings{
+								Tasks: []JobTaskSettings{
+									{
+										TaskKey:    "a",
+										NewCluster: &Cluster{PolicyID: "other"},
+									},
+									{
+										TaskKey:    "b",
+										NewCluster: &Cluster{PolicyID: "abc"},
+									},
+								},
+							},
+						},
+						{
+							JobID: 5,
+							Settings: &JobSettings{
+								JobClusters: []JobCluster{
+									{
+										JobClusterKey: "shared",
+										NewCluster:    &Cluster{PolicyID: "abc"},
+									},
+								},
+							},
+						},
+						{
+							JobID: 1,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterPolicyUsage(),
+		NonWritable: true,
+		HCL:         `policy_id = "abc"`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 2, d.Get("clusters.#"))
+	assert.Equal(t, "bcd", d.Get("clusters.0.cluster_id"))
+	assert.Equal(t, "First", d.Get("clusters.0.cluster_name"))
+	assert.Equal(t, "def", d.Get("clusters.1.cluster_id"))
+	assert.Equal(t, []interface{}{5, 10, 30}, d.Get("job_ids"))
+}
+
+func TestClusterPolicyUsageDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?page_size=100",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterPolicyUsage(),
+		NonWritable: true,
+		HCL:         `policy_id = "abc"`,
+		ID:          "_",
+	}.ExpectError(t, "Item not found")
+}
@@ -0,0 +1,94 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type taggedCluster struct {
+	ClusterID   string `json:"cluster_id"`
+	ClusterName string `json:"cluster_name,omitempty"`
+}
+
+// hasTags returns true if cluster has all of the given tags, either custom or default ones.
+// Cluster may have other tags as well.
+func (ci ClusterInfo) hasTags(tags map[string]string) bool {
+	for k, v := range tags {
+		actual, ok := ci.CustomTags[k]
+		if !ok {
+			actual, ok = ci.DefaultTags[k]
+		}
+		if !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+// DataSourceClusterTags finds clusters, that have all of the given tags
+func DataSourceClusterTags() *schema.Resource {
+	type entity struct {
+		Tags     map[string]string `json:"tags"`
+		IDs      []string          `json:"ids,omitempty" tf:"computed"`
+		Clusters []taggedCluster   `json:"clusters,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if len(this.Tags) == 0 {
+				return diag.Errorf("at least one tag must be specified")
+			}
+			clusters, err := NewClustersAPI(ctx, m).ListAllCached()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			// clusters may be listed twice, if they were created while following pages
+			matched := map[string]taggedCluster{}
+			for _, ci := range clusters {
+				if !ci.hasTags(this.Tags) {
+					continue
+				}
+				matched[ci.ClusterID] = taggedCluster{
+					ClusterID:   ci.ClusterID,
+					ClusterName: ci.ClusterName,
+				}
+			}
+			this.IDs = []string{}
+			this.Clusters = []taggedCluster{}
+			for id := range matched {
+				this.IDs = append(this.IDs, id)
+			}
+			sort.Strings(this.IDs)
+			for _, id := range this.IDs {
+				this.Clusters = append(this.Clusters, matched[id])
+			}
+			tags := []string{}
+			for k, v := range this.Tags {
+				tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+			}
+			sort.Strings(tags)
+			d.SetId(strings.Join(tags, ","))
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package compute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterTagsDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?page_size=100",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{
+							ClusterID:   "def",
+							ClusterName: "Second",
+							CustomTags: map[string]string{
+								"team": "data",
+								"env":  "prod",
+								"cost": "42",
+							},
+						},
+						{
+							ClusterID:   "xyz",
+							ClusterName: "Other team",
+							CustomTags: map[string]string{
+								"team": "ml",
+								"env":  "prod",
+							},
+						},
+					},
+					NextPageToken: "next",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?page_size=100&page_token=next",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{
+							ClusterID:   "def",
+							ClusterName: "Second",
+							CustomTags: map[string]string{
+								"team": "data",
+								"env":  "prod",
+							},
+						},
+						{
+							ClusterID:   "bcd",
+							ClusterName: "First",
+							CustomTags: map[string]string{
+								"env": "prod",
+							},
+							DefaultTags: map[string]string{
+								"team": "data",
+							},
+						},
+						{
+							ClusterID:   "no-tags",
+							ClusterName: "Untagged",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterTags(),
+		NonWritable: true,
+		HCL: `
+		tags = {
+			team = "data"
+			env = "prod"
+		}`,
+		ID: "_",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "env=prod,team=data", d.Id())
+	assert.Equal(t, []interface{}{"bcd", "def"}, d.Get("ids"))
+	assert.Equal(t, 2, d.Get("clusters.#"))
+	assert.Equal(t, "First", d.Get("clusters.0.cluster_name"))
+	assert.Equal(t, "def", d.Get("clusters.1.cluster_id"))
+}
+
+func TestClusterTagsDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/list?page_size=100",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterTags(),
+		NonWritable: true,
+		HCL: `
+		tags = {
+			team = "data"
+		}`,
+		ID: "_",
+	}.ExpectError(t, "Item not found")
+}
+
+func TestClustersAPIListAllCached(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list?page_size=100",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{ClusterID: "abc"},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		for i := 0; i < 2; i++ {
+			// second call must not reach the API, as fixture is not reused
+			clusters, err := clustersAPI.ListAllCached()
+			require.NoError(t, err)
+			assert.Len(t, clusters, 1)
+		}
+	})
+}
+
+func TestClustersAPIListAllCached_InvalidatedByChanges(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list?page_size=100",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{ClusterID: "abc"},
+				},
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/pin",
+			ExpectedRequest: ClusterID{ClusterID: "abc"},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/clusters/list?page_size=100",
+			Response: ClusterList{
+				Clusters: []ClusterInfo{
+					{ClusterID: "abc"},
+					{ClusterID: "def"},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		clusters, err := clustersAPI.ListAllCached()
+		require.NoError(t, err)
+		assert.Len(t, clusters, 1)
+
+		err = clustersAPI.Pin("abc")
+		require.NoError(t, err)
+
+		clusters, err = clustersAPI.ListAllCached()
+		require.NoError(t, err)
+		assert.Len(t, clusters, 2)
+	})
+}
@@ -0,0 +1,104 @@
+package compute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterDataSource(t *testing.T) {
+	lastActivity := time.Now().Add(-90*time.Minute).UnixNano() / int64(time.Millisecond)
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:        "abc",
+					ClusterName:      "Shared",
+					State:            ClusterStateRunning,
+					StartTime:        1600000000000,
+					LastActivityTime: lastActivity,
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		HCL:         `cluster_id = "abc"`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Shared", d.Get("cluster_name"))
+	assert.Equal(t, "RUNNING", d.Get("state"))
+	assert.Equal(t, 1600000000000, d.Get("start_time"))
+	assert.Equal(t, int(lastActivity), d.Get("last_activity_time"))
+	assert.Equal(t, 90, d.Get("idle_minutes"))
+}
+
+func TestClusterDataSource_Terminated(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:        "abc",
+					State:            ClusterStateTerminated,
+					StartTime:        1600000000000,
+					LastActivityTime: 1600000100000,
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		HCL:         `cluster_id = "abc"`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "TERMINATED", d.Get("state"))
+	assert.Equal(t, 0, d.Get("start_time"))
+	assert.Equal(t, 0, d.Get("last_activity_time"))
+	assert.Equal(t, 0, d.Get("idle_minutes"))
+}
+
+func TestClusterDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Cluster abc does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceCluster(),
+		NonWritable: true,
+		HCL:         `cluster_id = "abc"`,
+		ID:          "_",
+	}.ExpectError(t, "Cluster abc does not exist")
+}
+
+func TestClusterInfoIdleMinutes(t *testing.T) {
+	now := time.Unix(1600003600, 0)
+	assert.Equal(t, int64(60), ClusterInfo{
+		State:            ClusterStateRunning,
+		LastActivityTime: 1600000000000,
+	}.idleMinutes(now))
+	assert.Equal(t, int64(0), ClusterInfo{
+		State:            ClusterStateResizing,
+		LastActivityTime: 1600000000000,
+	}.idleMinutes(now))
+	assert.Equal(t, int64(0), ClusterInfo{
+		State: ClusterStateRunning,
+	}.idleMinutes(now))
+}
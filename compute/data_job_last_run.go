@@ -0,0 +1,82 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type lastRunTask struct {
+	TaskKey     string `json:"task_key"`
+	ClusterID   string `json:"cluster_id,omitempty"`
+	ResultState string `json:"result_state,omitempty"`
+	Duration    int64  `json:"duration,omitempty"`
+	// only reported for dbt_task runs, which upload their artifacts
+	DbtArtifactsLink string `json:"dbt_artifacts_link,omitempty"`
+}
+
+// DataSourceJobLastRun returns clusters and outcomes of tasks from the latest completed job run,
+// so that it's known where each task has run most recently
+func DataSourceJobLastRun() *schema.Resource {
+	type entity struct {
+		JobID       int64         `json:"job_id"`
+		RunID       int64         `json:"run_id,omitempty" tf:"computed"`
+		ResultState string        `json:"result_state,omitempty" tf:"computed"`
+		Tasks       []lastRunTask `json:"tasks,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			jobsAPI := NewJobsAPI(ctx, m)
+			run, err := jobsAPI.LastCompletedRun(this.JobID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Tasks = []lastRunTask{}
+			if run != nil {
+				this.RunID = run.RunID
+				this.ResultState = string(run.State.ResultState)
+				for _, task := range run.Tasks {
+					lrt := lastRunTask{
+						TaskKey:     task.TaskKey,
+						ResultState: string(task.State.ResultState),
+						Duration:    task.Duration(),
+					}
+					if task.ClusterInstance != nil {
+						lrt.ClusterID = task.ClusterInstance.ClusterID
+					}
+					if task.DbtTask != nil {
+						// artifacts are optional, so the rest of the run is still returned
+						output, err := jobsAPI.RunOutput(task.RunID)
+						if err != nil {
+							log.Printf("[WARN] cannot get output of task %s: %s", task.TaskKey, err)
+						} else if output.DbtOutput != nil {
+							lrt.DbtArtifactsLink = output.DbtOutput.ArtifactsLink
+						}
+					}
+					this.Tasks = append(this.Tasks, lrt)
+				}
+			}
+			d.SetId(fmt.Sprint(this.JobID))
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,212 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobLastRunDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&expand_tasks=true&job_id=123&limit=1",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 123,
+							RunID: 456,
+							State: RunState{
+								LifeCycleState: RunLifeCycleStateTerminated,
+								ResultState:    RunResultStateFailed,
+							},
+							Tasks: []RunTask{
+								{
+									TaskKey: "ingest",
+									State: RunState{
+										LifeCycleState: RunLifeCycleStateTerminated,
+										ResultState:    RunResultStateSuccess,
+									},
+									ClusterInstance: &ClusterInstance{
+										ClusterID: "0101-abc",
+									},
+									SetupDuration:     1000,
+									ExecutionDuration: 60000,
+									CleanupDuration:   500,
+								},
+								{
+									TaskKey: "report",
+									State: RunState{
+										LifeCycleState: RunLifeCycleStateTerminated,
+										ResultState:    RunResultStateFailed,
+									},
+									ClusterInstance: &ClusterInstance{
+										ClusterID: "0101-def",
+									},
+									StartTime: 1000,
+									EndTime:   3000,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceJobLastRun(),
+		NonWritable: true,
+		HCL:         `job_id = 123`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, 456, d.Get("run_id"))
+	assert.Equal(t, "FAILED", d.Get("result_state"))
+	assert.Equal(t, 2, d.Get("tasks.#"))
+	assert.Equal(t, "ingest", d.Get("tasks.0.task_key"))
+	assert.Equal(t, "0101-abc", d.Get("tasks.0.cluster_id"))
+	assert.Equal(t, "SUCCESS", d.Get("tasks.0.result_state"))
+	assert.Equal(t, 61500, d.Get("tasks.0.duration"))
+	assert.Equal(t, "0101-def", d.Get("tasks.1.cluster_id"))
+	assert.Equal(t, 2000, d.Get("tasks.1.duration"))
+}
+
+func TestJobLastRunDataSource_NeverRun(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&expand_tasks=true&job_id=123&limit=1",
+				Response: JobRunsList{},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceJobLastRun(),
+		NonWritable: true,
+		HCL:         `job_id = 123`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, 0, d.Get("run_id"))
+	assert.Equal(t, 0, d.Get("tasks.#"))
+}
+
+func TestJobLastRunDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&expand_tasks=true&job_id=123&limit=1",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Job 123 does not exist",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceJobLastRun(),
+		NonWritable: true,
+		HCL:         `job_id = 123`,
+		ID:          "_",
+	}.ExpectError(t, "Job 123 does not exist")
+}
+
+func TestJobLastRunDataSource_DbtArtifacts(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&expand_tasks=true&job_id=123&limit=1",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 123,
+							RunID: 456,
+							Tasks: []RunTask{
+								{
+									RunID:   457,
+									TaskKey: "transform",
+									DbtTask: &DbtTask{
+										Commands: []string{"dbt run"},
+									},
+								},
+								{
+									RunID:   458,
+									TaskKey: "notify",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=457",
+				Response: RunOutput{
+					DbtOutput: &DbtOutput{
+						ArtifactsLink: "https://storage/dbt/457/artifacts.tar.gz",
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceJobLastRun(),
+		NonWritable: true,
+		HCL:         `job_id = 123`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "https://storage/dbt/457/artifacts.tar.gz", d.Get("tasks.0.dbt_artifacts_link"))
+	assert.Equal(t, "", d.Get("tasks.1.dbt_artifacts_link"))
+}
+
+func TestJobLastRunDataSource_DbtOutputError(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&expand_tasks=true&job_id=123&limit=1",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 123,
+							RunID: 456,
+							Tasks: []RunTask{
+								{
+									RunID:   457,
+									TaskKey: "transform",
+									DbtTask: &DbtTask{
+										Commands: []string{"dbt run"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=457",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_STATE",
+					Message:   "Run output was removed",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceJobLastRun(),
+		NonWritable: true,
+		HCL:         `job_id = 123`,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 456, d.Get("run_id"))
+	assert.Equal(t, "transform", d.Get("tasks.0.task_key"))
+	assert.Equal(t, "", d.Get("tasks.0.dbt_artifacts_link"))
+}
@@ -6,12 +6,15 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // DataSourceNodeType returns smallest node depedning on the cloud
 func DataSourceNodeType() *schema.Resource {
 	s := common.StructToSchema(NodeTypeRequest{}, func(
 		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["role"].ValidateFunc = validation.StringInSlice([]string{
+			NodeTypeRoleWorker, NodeTypeRoleDriver}, false)
 		return s
 	})
 	return &schema.Resource{
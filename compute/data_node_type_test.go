@@ -162,3 +162,115 @@ func TestNodeTypeCategory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Random_02", d.Id())
 }
+
+func TestNodeTypeMinMemory_AboveInt32(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					[]NodeType{
+						{
+							NodeTypeID:     "small",
+							InstanceTypeID: "small",
+							MemoryMB:       8192,
+							NumCores:       4,
+						},
+						{
+							NodeTypeID:     "huge",
+							InstanceTypeID: "huge",
+							MemoryMB:       1 << 32,
+							NumCores:       448,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"min_memory_gb": 1 << 21,
+		},
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "huge", d.Id())
+}
+
+func nodeTypeRoleFixture() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list-node-types",
+			Response: NodeTypeList{
+				[]NodeType{
+					{
+						NodeTypeID:     "Standard_DS4_v2",
+						InstanceTypeID: "Standard_DS4_v2",
+						MemoryMB:       28672,
+						NumCores:       8,
+					},
+					{
+						NodeTypeID:     "Standard_F4s",
+						InstanceTypeID: "Standard_F4s",
+						MemoryMB:       8192,
+						NumCores:       4,
+					},
+					{
+						NodeTypeID:     "Standard_D4s_v3",
+						InstanceTypeID: "Standard_D4s_v3",
+						MemoryMB:       16384,
+						NumCores:       4,
+					},
+					{
+						NodeTypeID:     "Standard_DS3_v2",
+						InstanceTypeID: "Standard_DS3_v2",
+						MemoryMB:       14336,
+						NumCores:       4,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNodeTypeRole(t *testing.T) {
+	for _, tc := range []struct {
+		state    map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{}, "Standard_F4s"},
+		{map[string]interface{}{"role": "worker"}, "Standard_F4s"},
+		{map[string]interface{}{"role": "driver"}, "Standard_D4s_v3"},
+		{map[string]interface{}{"role": "driver", "min_cores": 8}, "Standard_DS4_v2"},
+		{map[string]interface{}{"role": "worker", "min_memory_gb": 14}, "Standard_DS3_v2"},
+	} {
+		d, err := qa.ResourceFixture{
+			Fixtures:    nodeTypeRoleFixture(),
+			Read:        true,
+			Resource:    DataSourceNodeType(),
+			NonWritable: true,
+			State:       tc.state,
+			ID:          ".",
+		}.Apply(t)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, d.Id(), "%v", tc.state)
+	}
+}
+
+func TestNodeTypeRole_Invalid(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures:    nodeTypeRoleFixture(),
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"role": "executor",
+		},
+		ID: ".",
+	}.ExpectError(t, "invalid config supplied. [role] expected role to be one of [worker driver], got executor")
+}
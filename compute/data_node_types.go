@@ -0,0 +1,91 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NodeTypeAvailability is a flattened view of node type and its quota in the current region
+type NodeTypeAvailability struct {
+	NodeTypeID         string   `json:"node_type_id,omitempty"`
+	Category           string   `json:"category,omitempty"`
+	Description        string   `json:"description,omitempty"`
+	MemoryMB           int64    `json:"memory_mb,omitempty"`
+	NumCores           float64  `json:"num_cores,omitempty"`
+	NumGPUs            int32    `json:"num_gpus,omitempty"`
+	IsDeprecated       bool     `json:"is_deprecated,omitempty"`
+	Status             []string `json:"status,omitempty"`
+	AvailableCoreQuota float64  `json:"available_core_quota,omitempty"`
+	TotalCoreQuota     float64  `json:"total_core_quota,omitempty"`
+}
+
+// HasAvailableQuota returns false only if cloud provider explicitly reports no available cores.
+// Node types without quota information are considered available.
+func (nt NodeType) HasAvailableQuota() bool {
+	if nt.NodeInfo == nil {
+		return true
+	}
+	return nt.NodeInfo.AvailableCoreQuota > 0
+}
+
+// Availability returns node type with its quota in the current region
+func (nt NodeType) Availability() NodeTypeAvailability {
+	nta := NodeTypeAvailability{
+		NodeTypeID:   nt.NodeTypeID,
+		Category:     nt.Category,
+		Description:  nt.Description,
+		MemoryMB:     nt.MemoryMB,
+		NumCores:     float64(nt.NumCores),
+		NumGPUs:      nt.NumGPUs,
+		IsDeprecated: nt.IsDeprecated,
+	}
+	if nt.NodeInfo != nil {
+		nta.Status = nt.NodeInfo.Status
+		nta.AvailableCoreQuota = float64(nt.NodeInfo.AvailableCoreQuota)
+		nta.TotalCoreQuota = float64(nt.NodeInfo.TotalCoreQuota)
+	}
+	return nta
+}
+
+// DataSourceNodeTypes returns sorted list of node types available in the current region
+func DataSourceNodeTypes() *schema.Resource {
+	type entity struct {
+		RequireQuota bool                   `json:"require_quota,omitempty"`
+		NodeTypes    []NodeTypeAvailability `json:"node_types,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			list, err := NewClustersAPI(ctx, m).ListNodeTypes()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			list.Sort()
+			for _, nt := range list.NodeTypes {
+				if this.RequireQuota && !nt.HasAvailableQuota() {
+					continue
+				}
+				this.NodeTypes = append(this.NodeTypes, nt.Availability())
+			}
+			d.SetId("_")
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package compute
+
+import (
+	"math"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var nodeTypesWithQuotaFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/list-node-types",
+	Response: NodeTypeList{
+		[]NodeType{
+			{
+				NodeTypeID: "Standard_F16s",
+				MemoryMB:   32768,
+				NumCores:   16,
+				NodeInfo: &ClusterCloudProviderNodeInfo{
+					AvailableCoreQuota: 0,
+					TotalCoreQuota:     100,
+				},
+			},
+			{
+				NodeTypeID: "Standard_F4s",
+				MemoryMB:   8192,
+				NumCores:   4,
+				Category:   "Compute Optimized",
+				NodeInfo: &ClusterCloudProviderNodeInfo{
+					Status:             []string{"NotEnabledOnSubscription"},
+					AvailableCoreQuota: 40,
+					TotalCoreQuota:     100,
+				},
+			},
+			{
+				NodeTypeID: "Standard_F8s",
+				MemoryMB:   16384,
+				NumCores:   8,
+			},
+		},
+	},
+}
+
+func TestNodeTypes(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{nodeTypesWithQuotaFixture},
+		Read:        true,
+		Resource:    DataSourceNodeTypes(),
+		NonWritable: true,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, d.Get("node_types.#"))
+	assert.Equal(t, "Standard_F4s", d.Get("node_types.0.node_type_id"))
+	assert.Equal(t, "Compute Optimized", d.Get("node_types.0.category"))
+	assert.Equal(t, 40.0, d.Get("node_types.0.available_core_quota"))
+	assert.Equal(t, "NotEnabledOnSubscription", d.Get("node_types.0.status.0"))
+	assert.Equal(t, "Standard_F8s", d.Get("node_types.1.node_type_id"))
+	assert.Equal(t, "Standard_F16s", d.Get("node_types.2.node_type_id"))
+}
+
+func TestNodeTypesRequireQuota(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{nodeTypesWithQuotaFixture},
+		Read:        true,
+		Resource:    DataSourceNodeTypes(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"require_quota": true,
+		},
+		ID: "_",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d.Get("node_types.#"))
+	assert.Equal(t, "Standard_F4s", d.Get("node_types.0.node_type_id"))
+	assert.Equal(t, "Standard_F8s", d.Get("node_types.1.node_type_id"))
+}
+
+func TestNodeTypesError(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: map[string]string{
+					"error_code": "INVALID_REQUEST",
+					"message":    "Something went wrong",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeTypes(),
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "Something went wrong")
+}
+
+func TestNodeTypes_MemoryAboveInt32(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					[]NodeType{
+						{
+							NodeTypeID: "huge",
+							MemoryMB:   math.MaxInt32 + 1,
+							NumCores:   448,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeTypes(),
+		NonWritable: true,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, math.MaxInt32+1, d.Get("node_types.0.memory_mb"))
+}
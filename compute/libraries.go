@@ -3,12 +3,60 @@ package compute
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// mavenCoordinatesRegex matches <group>:<artifact>:<version> with optional packaging and
+// classifier, like <group>:<artifact>:<packaging>:<classifier>:<version>
+var mavenCoordinatesRegex = regexp.MustCompile(`^[^:\s]+:[^:\s]+(:[^:\s]+){1,3}$`)
+
+func validateMavenCoordinates(i interface{}, p cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok || mavenCoordinatesRegex.MatchString(v) {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Invalid maven coordinates: %s", v),
+			Detail: "Coordinates must be in <group>:<artifact>:<version> format, optionally " +
+				"with packaging and classifier, like <group>:<artifact>:<packaging>:<classifier>:<version>",
+			AttributePath: p,
+		},
+	}
+}
+
+// validateMavenRepo accepts any absolute URL, as repositories could also be hosted on S3
+func validateMavenRepo(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%s must be a valid URL, like https://repo1.maven.org/maven2, but got: %s", k, v))
+	}
+	return
+}
+
+// customizeLibrarySchema validates maven libraries of the `library` blocks
+func customizeLibrarySchema(s map[string]*schema.Schema) {
+	if p, err := common.SchemaPath(s, "library", "maven", "coordinates"); err == nil {
+		p.ValidateDiagFunc = validateMavenCoordinates
+	}
+	if p, err := common.SchemaPath(s, "library", "maven", "repo"); err == nil {
+		p.ValidateFunc = validateMavenRepo
+	}
+}
+
 // NewLibrariesAPI creates LibrariesAPI instance from provider meta
 func NewLibrariesAPI(ctx context.Context, m interface{}) LibrariesAPI {
 	// TODO: context.WithValue
@@ -49,6 +97,9 @@ type Library struct { // TODO: discuss if we can make a dedicated entity just fo
 	Pypi  *PyPi  `json:"pypi,omitempty" tf:"group:lib"`
 	Maven *Maven `json:"maven,omitempty" tf:"group:lib"`
 	Cran  *Cran  `json:"cran,omitempty" tf:"group:lib"`
+	// VerifyOnly libraries are never installed or uninstalled by the provider,
+	// but refresh fails if they are not installed on the cluster
+	VerifyOnly bool `json:"verify_only,omitempty"`
 }
 
 // TypeAndKey can be used for computing differences
@@ -70,19 +121,31 @@ func (library Library) TypeAndKey() (string, string) {
 	return "", ""
 }
 
+// String returns human-readable identity of a library, like `maven:com.foo:bar:1.0`
+func (library Library) String() string {
+	libraryType, key := library.TypeAndKey()
+	return fmt.Sprintf("%s:%s", strings.TrimPrefix(libraryType, "library_"), key)
+}
+
 // ClusterLibraryList is request body for install and uninstall
 type ClusterLibraryList struct {
 	ClusterID string    `json:"cluster_id,omitempty" url:"cluster_id,omitempty"`
 	Libraries []Library `json:"libraries,omitempty" url:"libraries,omitempty" tf:"slice_set,alias:library"`
 }
 
-// Diff returns install/uninstall lists given a cluster lib status
-func (cll *ClusterLibraryList) Diff(cls ClusterLibraryStatuses) (ClusterLibraryList, ClusterLibraryList) {
+// Diff returns install/uninstall lists given a cluster lib status. Verify-only libraries
+// of the config and of the previous state are neither installed nor uninstalled.
+func (cll *ClusterLibraryList) Diff(cls ClusterLibraryStatuses,
+	previous ClusterLibraryList) (ClusterLibraryList, ClusterLibraryList) {
 	inConfig := map[string]Library{}
 	for _, lib := range cll.Libraries {
 		_, key := lib.TypeAndKey()
 		inConfig[key] = lib
 	}
+	verifyOnly := previous.verifyOnlyKeys()
+	for key := range cll.verifyOnlyKeys() {
+		verifyOnly[key] = true
+	}
 	inState := map[string]Library{}
 	for _, status := range cls.LibraryStatuses {
 		lib := *status.Library
@@ -93,14 +156,14 @@ func (cll *ClusterLibraryList) Diff(cls ClusterLibraryStatuses) (ClusterLibraryL
 	toUninstall := ClusterLibraryList{ClusterID: cll.ClusterID}
 	for key, lib := range inConfig {
 		_, exists := inState[key]
-		if exists {
+		if exists || lib.VerifyOnly {
 			continue
 		}
 		toInstall.Libraries = append(toInstall.Libraries, lib)
 	}
 	for key, lib := range inState {
 		_, exists := inConfig[key]
-		if exists {
+		if exists || verifyOnly[key] {
 			continue
 		}
 		toUninstall.Libraries = append(toUninstall.Libraries, lib)
@@ -108,6 +171,29 @@ func (cll *ClusterLibraryList) Diff(cls ClusterLibraryStatuses) (ClusterLibraryL
 	return toInstall, toUninstall
 }
 
+// verifyOnlyKeys returns keys of libraries, that are only verified
+func (cll ClusterLibraryList) verifyOnlyKeys() map[string]bool {
+	keys := map[string]bool{}
+	for _, lib := range cll.Libraries {
+		if lib.VerifyOnly {
+			_, key := lib.TypeAndKey()
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// managed returns libraries, that have to be installed by the provider
+func (cll ClusterLibraryList) managed() ClusterLibraryList {
+	managed := ClusterLibraryList{ClusterID: cll.ClusterID}
+	for _, lib := range cll.Libraries {
+		if !lib.VerifyOnly {
+			managed.Libraries = append(managed.Libraries, lib)
+		}
+	}
+	return managed
+}
+
 // LibraryStatus is the status on a given cluster when using the libraries status api
 type LibraryStatus struct {
 	Library                         *Library `json:"library,omitempty"`
@@ -139,6 +225,58 @@ func (cls ClusterLibraryStatuses) ToLibraryList() ClusterLibraryList {
 	return cll
 }
 
+// verify fails if any of the verify-only libraries is not installed on the cluster. While
+// the cluster is not running, libraries only have to be attached to it.
+func (cls ClusterLibraryStatuses) verify(libraries ClusterLibraryList, running bool) error {
+	statuses := map[string]string{}
+	for _, lib := range cls.LibraryStatuses {
+		_, key := lib.Library.TypeAndKey()
+		statuses[key] = lib.Status
+	}
+	notInstalled := []string{}
+	for _, lib := range libraries.Libraries {
+		if !lib.VerifyOnly {
+			continue
+		}
+		_, key := lib.TypeAndKey()
+		status, attached := statuses[key]
+		switch {
+		case !attached:
+			notInstalled = append(notInstalled, fmt.Sprintf("%s is not attached", lib))
+		case running && status != "INSTALLED":
+			notInstalled = append(notInstalled, fmt.Sprintf("%s is %s", lib, status))
+		}
+	}
+	if len(notInstalled) == 0 {
+		return nil
+	}
+	sort.Strings(notInstalled)
+	return fmt.Errorf("verify_only libraries are not installed on cluster %s: %s",
+		cls.ClusterID, strings.Join(notInstalled, ", "))
+}
+
+// markVerifyOnly keeps verify-only flag of the libraries, so that it doesn't produce a diff
+func (cll *ClusterLibraryList) markVerifyOnly(verifyOnly map[string]bool) {
+	for i, lib := range cll.Libraries {
+		_, key := lib.TypeAndKey()
+		cll.Libraries[i].VerifyOnly = verifyOnly[key]
+	}
+}
+
+// installedCount returns how many of the libraries are already installed on the cluster
+func (cls ClusterLibraryStatuses) installedCount() (installed int, total int) {
+	for _, lib := range cls.LibraryStatuses {
+		if lib.IsLibraryInstalledOnAllClusters {
+			continue
+		}
+		total++
+		if lib.Status == "INSTALLED" {
+			installed++
+		}
+	}
+	return
+}
+
 // IsRetryNeeded returns first bool if there needs to be retry.
 // If there needs to be retry, error message will explain why.
 // If retry does not need to happen and error is not nil - it failed.
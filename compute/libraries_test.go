@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -102,6 +105,65 @@ func TestClusterLibraryStatuses_Errors(t *testing.T) {
 	assert.False(t, need)
 }
 
+func TestClusterLibraryList_DiffVerifyOnly(t *testing.T) {
+	config := ClusterLibraryList{
+		ClusterID: "abc",
+		Libraries: []Library{
+			{Whl: "dbfs://audit.whl", VerifyOnly: true},
+			{Jar: "dbfs://foo.jar"},
+		},
+	}
+	previous := ClusterLibraryList{
+		Libraries: []Library{
+			{Egg: "dbfs://removed.egg", VerifyOnly: true},
+		},
+	}
+	toInstall, toUninstall := config.Diff(ClusterLibraryStatuses{
+		LibraryStatuses: []LibraryStatus{
+			{Library: &Library{Egg: "dbfs://removed.egg"}},
+			{Library: &Library{Pypi: &PyPi{Package: "requests"}}},
+		},
+	}, previous)
+	assert.Equal(t, []Library{{Jar: "dbfs://foo.jar"}}, toInstall.Libraries)
+	assert.Equal(t, []Library{{Pypi: &PyPi{Package: "requests"}}}, toUninstall.Libraries)
+}
+
+func TestClusterLibraryStatuses_Verify(t *testing.T) {
+	cls := ClusterLibraryStatuses{
+		ClusterID: "abc",
+		LibraryStatuses: []LibraryStatus{
+			{
+				Library: &Library{Whl: "dbfs://audit.whl"},
+				Status:  "INSTALLED",
+			},
+			{
+				Library: &Library{Jar: "dbfs://foo.jar"},
+				Status:  "FAILED",
+			},
+		},
+	}
+	configured := ClusterLibraryList{
+		Libraries: []Library{
+			{Whl: "dbfs://audit.whl", VerifyOnly: true},
+			{Jar: "dbfs://foo.jar", VerifyOnly: true},
+			{Egg: "dbfs://bar.egg", VerifyOnly: true},
+			{Pypi: &PyPi{Package: "requests"}},
+		},
+	}
+	err := cls.verify(configured, true)
+	assert.EqualError(t, err, "verify_only libraries are not installed on cluster abc: "+
+		"egg:dbfs://bar.egg is not attached, jar:dbfs://foo.jar is FAILED")
+
+	err = cls.verify(configured, false)
+	assert.EqualError(t, err, "verify_only libraries are not installed on cluster abc: "+
+		"egg:dbfs://bar.egg is not attached")
+
+	err = cls.verify(ClusterLibraryList{
+		Libraries: []Library{{Whl: "dbfs://audit.whl", VerifyOnly: true}},
+	}, true)
+	assert.NoError(t, err)
+}
+
 func TestAccLibraryCreate(t *testing.T) {
 	cloud := os.Getenv("CLOUD_ENV")
 	if cloud == "" {
@@ -150,3 +212,59 @@ func TestAccLibraryCreate(t *testing.T) {
 	assert.NoError(t, err, err)
 	assert.Equal(t, len(libraryStatusList.LibraryStatuses), len(libraries))
 }
+
+func TestValidateMavenCoordinates(t *testing.T) {
+	for _, v := range []string{
+		"com.microsoft.azure:azure-eventhubs-spark_2.12:2.3.18",
+		"org.jsoup:jsoup:jar:1.7.2",
+		"net.sf.json-lib:json-lib:jar:jdk15:2.4",
+	} {
+		assert.Len(t, validateMavenCoordinates(v, cty.Path{}), 0, v)
+	}
+	for _, v := range []string{
+		"org.jsoup:jsoup",
+		"org.jsoup::1.7.2",
+		"org.jsoup:jsoup:1.7.2 ",
+		"a:b:c:d:e:f",
+	} {
+		diags := validateMavenCoordinates(v, cty.Path{})
+		require.Len(t, diags, 1, v)
+		assert.Equal(t, "Invalid maven coordinates: "+v, diags[0].Summary)
+	}
+}
+
+func TestValidateMavenRepo(t *testing.T) {
+	for _, v := range []string{"https://repo1.maven.org/maven2", "s3://maven-repo/release"} {
+		_, errs := validateMavenRepo(v, "repo")
+		assert.Len(t, errs, 0, v)
+	}
+	_, errs := validateMavenRepo("repo1.maven.org", "repo")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "repo must be a valid URL, like https://repo1.maven.org/maven2, but got: repo1.maven.org")
+}
+
+func TestResourceJobCreate_InvalidMavenCoordinates(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Shared/test"
+		}
+		library {
+			maven {
+				coordinates = "org.jsoup:jsoup"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. [library] Invalid maven coordinates: org.jsoup:jsoup")
+}
+
+func TestJobLibrarySchemaHasNoVerifyOnly(t *testing.T) {
+	for _, path := range [][]string{{"library"}, {"task", "library"}} {
+		p, err := common.SchemaPath(jobSchema, path...)
+		require.NoError(t, err)
+		assert.NotContains(t, p.Elem.(*schema.Resource).Schema, "verify_only")
+	}
+	assert.Contains(t, clusterSchema["library"].Elem.(*schema.Resource).Schema, "verify_only")
+}
@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -11,8 +12,17 @@ import (
 type AutoScale struct {
 	MinWorkers int32 `json:"min_workers,omitempty"`
 	MaxWorkers int32 `json:"max_workers,omitempty"`
+	// Mode is supported only by job and pipeline clusters
+	Mode string `json:"mode,omitempty"`
 }
 
+const (
+	// AutoScaleModeEnhanced optimizes cluster utilization for streaming and batch workloads
+	AutoScaleModeEnhanced = "ENHANCED"
+	// AutoScaleModeLegacy is the original autoscaling of clusters
+	AutoScaleModeLegacy = "LEGACY"
+)
+
 // Availability is a type for describing AWS availability on cluster nodes
 type Availability string
 
@@ -37,6 +47,28 @@ const (
 	AzureAvailabilitySpotWithFallback = "SPOT_WITH_FALLBACK_AZURE"
 )
 
+// https://docs.databricks.com/dev-tools/api/latest/clusters.html#clustersource
+const (
+	// ClusterSourceUI is for clusters created through the UI
+	ClusterSourceUI Availability = "UI"
+	// ClusterSourceJob is for clusters created by the job scheduler
+	ClusterSourceJob Availability = "JOB"
+	// ClusterSourceAPI is for clusters created through the API
+	ClusterSourceAPI Availability = "API"
+)
+
+// AzureSpotBidMaxPriceOnDemand is a special value of spot_bid_max_price, that instructs Azure
+// not to evict spot instances based on price, but to pay up to the on-demand price instead
+const AzureSpotBidMaxPriceOnDemand = -1
+
+// https://docs.gcp.databricks.com/dev-tools/api/latest/clusters.html#clustergcpattributes
+const (
+	// GcpZoneAuto lets Databricks pick any zone with capacity within the workspace region
+	GcpZoneAuto = "auto"
+	// GcpZoneHA lets Databricks pick a zone with high availability within the workspace region
+	GcpZoneHA = "HA"
+)
+
 // AzureDiskVolumeType is disk type on azure vms
 type AzureDiskVolumeType string
 
@@ -148,6 +180,7 @@ type AzureAttributes struct {
 type GcpAttributes struct {
 	UsePreemptibleExecutors bool   `json:"use_preemptible_executors,omitempty" tf:"computed"`
 	GoogleServiceAccount    string `json:"google_service_account,omitempty" tf:"computed"`
+	ZoneID                  string `json:"zone_id,omitempty" tf:"computed"`
 }
 
 // DbfsStorageInfo contains the destination string for DBFS
@@ -178,6 +211,17 @@ type StorageInfo struct {
 	S3   *S3StorageInfo   `json:"s3,omitempty" tf:"group:storage"`
 }
 
+// destination returns location of the storage, regardless of its type
+func (si StorageInfo) destination() string {
+	if si.Dbfs != nil {
+		return si.Dbfs.Destination
+	}
+	if si.S3 != nil {
+		return si.S3.Destination
+	}
+	return ""
+}
+
 // InitScriptStorageInfo captures the allowed sources of init scripts.
 type InitScriptStorageInfo struct {
 	Dbfs *DbfsStorageInfo `json:"dbfs,omitempty" tf:"group:storage"`
@@ -185,6 +229,14 @@ type InitScriptStorageInfo struct {
 	File *LocalFileInfo   `json:"file,omitempty" tf:"optional"`
 }
 
+// destination returns location of the init script, regardless of its type
+func (si InitScriptStorageInfo) destination() string {
+	if si.File != nil {
+		return si.File.Destination
+	}
+	return StorageInfo{Dbfs: si.Dbfs, S3: si.S3}.destination()
+}
+
 // SparkNodeAwsAttributes is the struct that determines if the node is a spot instance or not
 type SparkNodeAwsAttributes struct {
 	IsSpot bool `json:"is_spot,omitempty"`
@@ -233,7 +285,7 @@ type NodeInstanceType struct {
 // NodeType encapsulates information about a given node when using the list-node-types api
 type NodeType struct {
 	NodeTypeID            string                        `json:"node_type_id,omitempty"`
-	MemoryMB              int32                         `json:"memory_mb,omitempty"`
+	MemoryMB              int64                         `json:"memory_mb,omitempty"`
 	NumCores              float32                       `json:"num_cores,omitempty"`
 	NumGPUs               int32                         `json:"num_gpus,omitempty"`
 	SupportEBSVolumes     bool                          `json:"support_ebs_volumes,omitempty"`
@@ -255,7 +307,38 @@ type NodeType struct {
 // DockerBasicAuth contains the auth information when fetching containers
 type DockerBasicAuth struct {
 	Username string `json:"username" tf:"force_new"`
-	Password string `json:"password" tf:"force_new"`
+	Password string `json:"password,omitempty" tf:"force_new"`
+	// SecretReference is sent to the API as password in {{secrets/scope/key}} format,
+	// that is resolved by Databricks at cluster start time
+	SecretReference string `json:"secret_reference,omitempty" tf:"force_new"`
+}
+
+// this type alias hack is required for Marshaller to work without an infinite loop
+type aDockerBasicAuth DockerBasicAuth
+
+// MarshalJSON sends secret reference as password, because the API resolves
+// {{secrets/scope/key}} references in place of literal values
+func (auth DockerBasicAuth) MarshalJSON() ([]byte, error) {
+	if auth.SecretReference != "" {
+		auth.Password = auth.SecretReference
+		auth.SecretReference = ""
+	}
+	return json.Marshal(aDockerBasicAuth(auth))
+}
+
+// UnmarshalJSON restores secret reference, that is returned by the API as password,
+// so that it's not replaced on every plan
+func (auth *DockerBasicAuth) UnmarshalJSON(data []byte) error {
+	var raw aDockerBasicAuth
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*auth = DockerBasicAuth(raw)
+	if isSecretReference(auth.Password) {
+		auth.SecretReference = auth.Password
+		auth.Password = ""
+	}
+	return nil
 }
 
 // DockerImage contains the image url and the auth for DCS
@@ -275,15 +358,16 @@ type Cluster struct {
 	EnableElasticDisk         bool       `json:"enable_elastic_disk,omitempty" tf:"computed"`
 	EnableLocalDiskEncryption bool       `json:"enable_local_disk_encryption,omitempty" tf:"computed"`
 
-	NodeTypeID             string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
-	DriverNodeTypeID       string           `json:"driver_node_type_id,omitempty" tf:"group:node_type,computed"`
-	InstancePoolID         string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
-	DriverInstancePoolID   string           `json:"driver_instance_pool_id,omitempty" tf:"group:node_type,computed"`
-	PolicyID               string           `json:"policy_id,omitempty"`
-	AwsAttributes          *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	AzureAttributes        *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	GcpAttributes          *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	AutoterminationMinutes int32            `json:"autotermination_minutes,omitempty"`
+	NodeTypeID               string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
+	DriverNodeTypeID         string           `json:"driver_node_type_id,omitempty" tf:"group:node_type,computed"`
+	InstancePoolID           string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
+	DriverInstancePoolID     string           `json:"driver_instance_pool_id,omitempty" tf:"group:node_type,computed"`
+	PolicyID                 string           `json:"policy_id,omitempty"`
+	ApplyPolicyDefaultValues bool             `json:"apply_policy_default_values,omitempty"`
+	AwsAttributes            *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	AzureAttributes          *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	GcpAttributes            *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	AutoterminationMinutes   int32            `json:"autotermination_minutes,omitempty"`
 
 	SparkConf    map[string]string `json:"spark_conf,omitempty"`
 	SparkEnvVars map[string]string `json:"spark_env_vars,omitempty"`
@@ -294,58 +378,132 @@ type Cluster struct {
 	ClusterLogConf *StorageInfo            `json:"cluster_log_conf,omitempty"`
 	DockerImage    *DockerImage            `json:"docker_image,omitempty"`
 
-	SingleUserName   string `json:"single_user_name,omitempty"`
-	IdempotencyToken string `json:"idempotency_token,omitempty" tf:"force_new"`
+	SingleUserName             string                            `json:"single_user_name,omitempty"`
+	DataSecurityMode           string                            `json:"data_security_mode,omitempty"`
+	EnhancedSecurityMonitoring *EnhancedSecurityMonitoringConfig `json:"enhanced_security_monitoring,omitempty"`
+	IdempotencyToken           string                            `json:"idempotency_token,omitempty" tf:"force_new"`
+
+	// disk settings, that are explicitly set to false in configuration
+	disabledDiskSettings []string
+}
+
+// this type alias hack is required for Marshaller to work without an infinite loop
+type aCluster Cluster
+
+// MarshalJSON is required to overcome the limitations of `omitempty` usage with reflect_resource.go
+// for disk settings, that are computed by the API unless explicitly disabled.
+func (cluster Cluster) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(aCluster(cluster))
+	if err != nil || len(cluster.disabledDiskSettings) == 0 {
+		return raw, err
+	}
+	var request map[string]interface{}
+	err = json.Unmarshal(raw, &request)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range cluster.disabledDiskSettings {
+		request[key] = false
+	}
+	return json.Marshal(request)
+}
+
+const (
+	// ClusterProfileConfKey is the spark conf key, that holds workload profile of the cluster
+	ClusterProfileConfKey = "spark.databricks.cluster.profile"
+	// ClusterProfileSingleNode is the profile of clusters without workers
+	ClusterProfileSingleNode = "singleNode"
+	// ClusterProfileServerless is the profile of high-concurrency clusters
+	ClusterProfileServerless = "serverless"
+)
+
+// Profile returns workload profile of the cluster, or empty string for standard clusters
+func (cluster Cluster) Profile() string {
+	return cluster.SparkConf[ClusterProfileConfKey]
+}
+
+// IsSingleNode returns true if cluster runs with single node profile
+func (cluster Cluster) IsSingleNode() bool {
+	return cluster.Profile() == ClusterProfileSingleNode
+}
+
+// IsServerless returns true if cluster runs with serverless (high-concurrency) profile
+func (cluster Cluster) IsServerless() bool {
+	return cluster.Profile() == ClusterProfileServerless
+}
+
+const (
+	// DataSecurityModeNone is the mode of clusters without Unity Catalog access
+	DataSecurityModeNone = "NONE"
+	// DataSecurityModeSingleUser is the mode of Unity Catalog clusters, that can be used by single_user_name only
+	DataSecurityModeSingleUser = "SINGLE_USER"
+	// DataSecurityModeUserIsolation is the mode of Unity Catalog clusters, that are shared by multiple users
+	DataSecurityModeUserIsolation = "USER_ISOLATION"
+)
+
+// EnhancedSecurityMonitoringConfig locks down network egress of the cluster,
+// formerly known as data exfiltration protection
+type EnhancedSecurityMonitoringConfig struct {
+	IsEnabled bool `json:"is_enabled,omitempty"`
 }
 
 // ClusterList shows existing clusters
 type ClusterList struct {
-	Clusters []ClusterInfo `json:"clusters,omitempty"`
+	Clusters      []ClusterInfo `json:"clusters,omitempty"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+// ClusterListRequest is the paginated request to list clusters
+type ClusterListRequest struct {
+	PageSize  int    `url:"page_size,omitempty"`
+	PageToken string `url:"page_token,omitempty"`
 }
 
 // ClusterInfo contains the information when getting cluster info from the get request.
 type ClusterInfo struct {
-	NumWorkers                int32              `json:"num_workers,omitempty"`
-	AutoScale                 *AutoScale         `json:"autoscale,omitempty"`
-	ClusterID                 string             `json:"cluster_id,omitempty"`
-	CreatorUserName           string             `json:"creator_user_name,omitempty"`
-	Driver                    *SparkNode         `json:"driver,omitempty"`
-	Executors                 []SparkNode        `json:"executors,omitempty"`
-	SparkContextID            int64              `json:"spark_context_id,omitempty"`
-	JdbcPort                  int32              `json:"jdbc_port,omitempty"`
-	ClusterName               string             `json:"cluster_name,omitempty"`
-	SparkVersion              string             `json:"spark_version"`
-	SparkConf                 map[string]string  `json:"spark_conf,omitempty"`
-	AwsAttributes             *AwsAttributes     `json:"aws_attributes,omitempty"`
-	AzureAttributes           *AzureAttributes   `json:"azure_attributes,omitempty"`
-	GcpAttributes             *GcpAttributes     `json:"gcp_attributes,omitempty"`
-	NodeTypeID                string             `json:"node_type_id,omitempty"`
-	DriverNodeTypeID          string             `json:"driver_node_type_id,omitempty"`
-	SSHPublicKeys             []string           `json:"ssh_public_keys,omitempty"`
-	CustomTags                map[string]string  `json:"custom_tags,omitempty"`
-	ClusterLogConf            *StorageInfo       `json:"cluster_log_conf,omitempty"`
-	InitScripts               []StorageInfo      `json:"init_scripts,omitempty"`
-	SparkEnvVars              map[string]string  `json:"spark_env_vars,omitempty"`
-	AutoterminationMinutes    int32              `json:"autotermination_minutes,omitempty"`
-	EnableElasticDisk         bool               `json:"enable_elastic_disk,omitempty"`
-	EnableLocalDiskEncryption bool               `json:"enable_local_disk_encryption,omitempty"`
-	InstancePoolID            string             `json:"instance_pool_id,omitempty"`
-	DriverInstancePoolID      string             `json:"driver_instance_pool_id,omitempty" tf:"computed"`
-	PolicyID                  string             `json:"policy_id,omitempty"`
-	SingleUserName            string             `json:"single_user_name,omitempty"`
-	ClusterSource             Availability       `json:"cluster_source,omitempty"`
-	DockerImage               *DockerImage       `json:"docker_image,omitempty"`
-	State                     ClusterState       `json:"state"`
-	StateMessage              string             `json:"state_message,omitempty"`
-	StartTime                 int64              `json:"start_time,omitempty"`
-	TerminateTime             int64              `json:"terminate_time,omitempty"`
-	LastStateLossTime         int64              `json:"last_state_loss_time,omitempty"`
-	LastActivityTime          int64              `json:"last_activity_time,omitempty"`
-	ClusterMemoryMb           int64              `json:"cluster_memory_mb,omitempty"`
-	ClusterCores              float32            `json:"cluster_cores,omitempty"`
-	DefaultTags               map[string]string  `json:"default_tags"`
-	ClusterLogStatus          *LogSyncStatus     `json:"cluster_log_status,omitempty"`
-	TerminationReason         *TerminationReason `json:"termination_reason,omitempty"`
+	NumWorkers                 int32                             `json:"num_workers,omitempty"`
+	AutoScale                  *AutoScale                        `json:"autoscale,omitempty"`
+	ClusterID                  string                            `json:"cluster_id,omitempty"`
+	CreatorUserName            string                            `json:"creator_user_name,omitempty"`
+	Driver                     *SparkNode                        `json:"driver,omitempty"`
+	Executors                  []SparkNode                       `json:"executors,omitempty"`
+	SparkContextID             int64                             `json:"spark_context_id,omitempty"`
+	JdbcPort                   int32                             `json:"jdbc_port,omitempty"`
+	ClusterName                string                            `json:"cluster_name,omitempty"`
+	SparkVersion               string                            `json:"spark_version"`
+	SparkConf                  map[string]string                 `json:"spark_conf,omitempty"`
+	AwsAttributes              *AwsAttributes                    `json:"aws_attributes,omitempty"`
+	AzureAttributes            *AzureAttributes                  `json:"azure_attributes,omitempty"`
+	GcpAttributes              *GcpAttributes                    `json:"gcp_attributes,omitempty"`
+	NodeTypeID                 string                            `json:"node_type_id,omitempty"`
+	DriverNodeTypeID           string                            `json:"driver_node_type_id,omitempty"`
+	SSHPublicKeys              []string                          `json:"ssh_public_keys,omitempty"`
+	CustomTags                 map[string]string                 `json:"custom_tags,omitempty"`
+	ClusterLogConf             *StorageInfo                      `json:"cluster_log_conf,omitempty"`
+	InitScripts                []StorageInfo                     `json:"init_scripts,omitempty"`
+	SparkEnvVars               map[string]string                 `json:"spark_env_vars,omitempty"`
+	AutoterminationMinutes     int32                             `json:"autotermination_minutes,omitempty"`
+	EnableElasticDisk          bool                              `json:"enable_elastic_disk,omitempty"`
+	EnableLocalDiskEncryption  bool                              `json:"enable_local_disk_encryption,omitempty"`
+	InstancePoolID             string                            `json:"instance_pool_id,omitempty"`
+	DriverInstancePoolID       string                            `json:"driver_instance_pool_id,omitempty" tf:"computed"`
+	PolicyID                   string                            `json:"policy_id,omitempty"`
+	SingleUserName             string                            `json:"single_user_name,omitempty"`
+	DataSecurityMode           string                            `json:"data_security_mode,omitempty"`
+	EnhancedSecurityMonitoring *EnhancedSecurityMonitoringConfig `json:"enhanced_security_monitoring,omitempty"`
+	ClusterSource              Availability                      `json:"cluster_source,omitempty"`
+	DockerImage                *DockerImage                      `json:"docker_image,omitempty"`
+	State                      ClusterState                      `json:"state"`
+	StateMessage               string                            `json:"state_message,omitempty"`
+	StartTime                  int64                             `json:"start_time,omitempty"`
+	TerminateTime              int64                             `json:"terminate_time,omitempty"`
+	LastStateLossTime          int64                             `json:"last_state_loss_time,omitempty"`
+	LastActivityTime           int64                             `json:"last_activity_time,omitempty"`
+	ClusterMemoryMb            int64                             `json:"cluster_memory_mb,omitempty"`
+	ClusterCores               float32                           `json:"cluster_cores,omitempty"`
+	DefaultTags                map[string]string                 `json:"default_tags"`
+	ClusterLogStatus           *LogSyncStatus                    `json:"cluster_log_status,omitempty"`
+	TerminationReason          *TerminationReason                `json:"termination_reason,omitempty"`
 }
 
 // IsRunningOrResizing returns true if cluster is running or resizing
@@ -353,17 +511,79 @@ func (ci *ClusterInfo) IsRunningOrResizing() bool {
 	return ci.State == ClusterStateRunning || ci.State == ClusterStateResizing
 }
 
+// effectiveZoneID returns the zone, where cluster nodes were provisioned
+func (ci *ClusterInfo) effectiveZoneID() string {
+	if ci.GcpAttributes != nil && ci.GcpAttributes.ZoneID != "" {
+		return ci.GcpAttributes.ZoneID
+	}
+	if ci.AwsAttributes != nil {
+		return ci.AwsAttributes.ZoneID
+	}
+	return ""
+}
+
+// GetDriverIP returns private IP address of the driver, that is known only for running clusters
+func (ci *ClusterInfo) GetDriverIP() (string, error) {
+	if ci.Driver == nil || ci.Driver.PrivateIP == "" {
+		return "", fmt.Errorf("private IP of the driver of cluster %s is not known, "+
+			"as cluster is %s", ci.ClusterID, ci.State)
+	}
+	return ci.Driver.PrivateIP, nil
+}
+
+// GetDriverPublicDNS returns public DNS name of the driver, that is known only for running clusters
+func (ci *ClusterInfo) GetDriverPublicDNS() (string, error) {
+	if ci.Driver == nil || ci.Driver.PublicDNS == "" {
+		return "", fmt.Errorf("public DNS of the driver of cluster %s is not known, "+
+			"as cluster is %s", ci.ClusterID, ci.State)
+	}
+	return ci.Driver.PublicDNS, nil
+}
+
 // ClusterID holds cluster ID
 type ClusterID struct {
 	ClusterID string `json:"cluster_id,omitempty" url:"cluster_id,omitempty"`
 }
 
+// ResizeRequest changes the number of workers of the running cluster without restarting it
+type ResizeRequest struct {
+	ClusterID  string     `json:"cluster_id"`
+	NumWorkers int32      `json:"num_workers,omitempty"`
+	Autoscale  *AutoScale `json:"autoscale,omitempty"`
+}
+
+type aResizeRequest ResizeRequest
+
+// MarshalJSON always sends num_workers for fixed-size clusters, so that they could be resized
+// down to zero workers, just like Cluster does.
+func (r ResizeRequest) MarshalJSON() ([]byte, error) {
+	if r.Autoscale != nil {
+		return json.Marshal(aResizeRequest(r))
+	}
+	return json.Marshal(struct {
+		ClusterID  string `json:"cluster_id"`
+		NumWorkers int32  `json:"num_workers"`
+	}{r.ClusterID, r.NumWorkers})
+}
+
 // ClusterPolicy defines cluster policy
 type ClusterPolicy struct {
 	PolicyID           string `json:"policy_id,omitempty"`
 	Name               string `json:"name"`
 	Definition         string `json:"definition"`
 	CreatedAtTimeStamp int64  `json:"created_at_timestamp"`
+
+	// family-based policies inherit definition of the policy family
+	PolicyFamilyID                  string `json:"policy_family_id,omitempty"`
+	PolicyFamilyDefinitionOverrides string `json:"policy_family_definition_overrides,omitempty"`
+}
+
+// PolicyFamily is a template of cluster policy definition, that is provided by Databricks
+type PolicyFamily struct {
+	PolicyFamilyID string `json:"policy_family_id"`
+	Name           string `json:"name,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Definition     string `json:"definition"`
 }
 
 // ClusterPolicyCreate is the endity used for request
@@ -386,24 +606,31 @@ type InstancePoolAwsAttributes struct {
 	SpotBidPricePercent int32        `json:"spot_bid_price_percent,omitempty" tf:"force_new"`
 }
 
-// InstancePoolAzureAttributes contains aws attributes for Azure Databricks deployments for instance pools
+// InstancePoolAzureAttributes contains aws attributes for Azure Databricks deployments for instance pools.
+// API doesn't have the eviction policy of spot instances, so only the max price could be set.
 // https://docs.microsoft.com/en-us/azure/databricks/dev-tools/api/latest/instance-pools#clusterinstancepoolazureattributes
 type InstancePoolAzureAttributes struct {
 	Availability    Availability `json:"availability,omitempty" tf:"force_new"`
 	SpotBidMaxPrice float64      `json:"spot_bid_max_price,omitempty" tf:"force_new"`
 }
 
+// InstancePoolGcpAttributes contains gcp attributes for GCP Databricks deployments for instance pools
+type InstancePoolGcpAttributes struct {
+	ZoneID string `json:"zone_id,omitempty" tf:"computed,force_new"`
+}
+
 // InstancePoolDiskType contains disk type information for each of the different cloud service providers
 type InstancePoolDiskType struct {
 	AzureDiskVolumeType string `json:"azure_disk_volume_type,omitempty" tf:"force_new"`
 	EbsVolumeType       string `json:"ebs_volume_type,omitempty" tf:"force_new"`
 }
 
-// InstancePoolDiskSpec contains disk size, type and count information for the pool
+// InstancePoolDiskSpec contains disk size, type and count information for the pool.
+// Disks of existing pool instances cannot be changed, so any change recreates the pool.
 type InstancePoolDiskSpec struct {
-	DiskType  *InstancePoolDiskType `json:"disk_type,omitempty"`
-	DiskCount int32                 `json:"disk_count,omitempty"`
-	DiskSize  int32                 `json:"disk_size,omitempty"`
+	DiskType  *InstancePoolDiskType `json:"disk_type,omitempty" tf:"force_new"`
+	DiskCount int32                 `json:"disk_count,omitempty" tf:"force_new"`
+	DiskSize  int32                 `json:"disk_size,omitempty" tf:"force_new"`
 }
 
 // InstancePool describes the instance pool object on Databricks
@@ -415,12 +642,36 @@ type InstancePool struct {
 	IdleInstanceAutoTerminationMinutes int32                        `json:"idle_instance_autotermination_minutes"`
 	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty" tf:"force_new,suppress_diff"`
 	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty" tf:"force_new,suppress_diff"`
+	GcpAttributes                      *InstancePoolGcpAttributes   `json:"gcp_attributes,omitempty" tf:"force_new,suppress_diff"`
 	NodeTypeID                         string                       `json:"node_type_id" tf:"force_new"`
 	CustomTags                         map[string]string            `json:"custom_tags,omitempty" tf:"force_new"`
 	EnableElasticDisk                  bool                         `json:"enable_elastic_disk,omitempty" tf:"force_new"`
 	DiskSpec                           *InstancePoolDiskSpec        `json:"disk_spec,omitempty" tf:"force_new"`
 	PreloadedSparkVersions             []string                     `json:"preloaded_spark_versions,omitempty" tf:"force_new"`
 	PreloadedDockerImages              []DockerImage                `json:"preloaded_docker_images,omitempty" tf:"force_new,slice_set,alias:preloaded_docker_image"`
+	Status                             *InstancePoolStatus          `json:"status,omitempty" tf:"computed"`
+}
+
+// effectiveZoneID returns the zone, where pool instances are provisioned
+func (ip *InstancePool) effectiveZoneID() string {
+	if ip.GcpAttributes != nil && ip.GcpAttributes.ZoneID != "" {
+		return ip.GcpAttributes.ZoneID
+	}
+	if ip.AwsAttributes != nil {
+		return ip.AwsAttributes.ZoneID
+	}
+	return ""
+}
+
+// PendingInstanceError is the reason, why pool instance cannot be launched
+type PendingInstanceError struct {
+	InstanceID string `json:"instance_id,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// InstancePoolStatus contains launch errors of pending pool instances
+type InstancePoolStatus struct {
+	PendingInstanceErrors []PendingInstanceError `json:"pending_instance_errors,omitempty"`
 }
 
 // InstancePoolStats contains the stats on a given pool
@@ -439,6 +690,7 @@ type InstancePoolAndStats struct {
 	MaxCapacity                        int32                        `json:"max_capacity,omitempty"`
 	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty"`
 	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty"`
+	GcpAttributes                      *InstancePoolGcpAttributes   `json:"gcp_attributes,omitempty"`
 	NodeTypeID                         string                       `json:"node_type_id"`
 	DefaultTags                        map[string]string            `json:"default_tags,omitempty" tf:"computed"`
 	CustomTags                         map[string]string            `json:"custom_tags,omitempty"`
@@ -448,6 +700,7 @@ type InstancePoolAndStats struct {
 	PreloadedSparkVersions             []string                     `json:"preloaded_spark_versions,omitempty"`
 	State                              string                       `json:"state,omitempty"`
 	Stats                              *InstancePoolStats           `json:"stats,omitempty"`
+	Status                             *InstancePoolStatus          `json:"status,omitempty"`
 	PreloadedDockerImages              []DockerImage                `json:"preloaded_docker_images,omitempty" tf:"slice_set,alias:preloaded_docker_image"`
 }
 
@@ -493,12 +746,28 @@ func (l *NodeTypeList) Sort() {
 	})
 }
 
+// Locations of notebooks of notebook tasks
+const (
+	NotebookSourceGit       = "GIT"
+	NotebookSourceWorkspace = "WORKSPACE"
+)
+
 // NotebookTask contains the information for notebook jobs
 type NotebookTask struct {
 	NotebookPath   string            `json:"notebook_path"`
+	Source         string            `json:"source,omitempty" tf:"computed"`
 	BaseParameters map[string]string `json:"base_parameters,omitempty"`
 }
 
+// isFromGit returns true if the notebook is taken from git_source of the job. Without
+// explicit source, notebooks are taken from git_source, whenever the job has it.
+func (nt *NotebookTask) isFromGit(hasGitSource bool) bool {
+	if nt.Source == "" {
+		return hasGitSource
+	}
+	return nt.Source == NotebookSourceGit
+}
+
 // SparkPythonTask contains the information for python jobs
 type SparkPythonTask struct {
 	PythonFile string   `json:"python_file"`
@@ -523,6 +792,8 @@ type PythonWheelTask struct {
 	PackageName     string            `json:"package_name,omitempty"`
 	Parameters      []string          `json:"parameters,omitempty"`
 	NamedParameters map[string]string `json:"named_parameters,omitempty"`
+	// AutoAddWheelLibrary is handled by the provider and is never sent to the API
+	AutoAddWheelLibrary bool `json:"auto_add_wheel_library,omitempty"`
 }
 
 // PipelineTask contains the information for pipeline jobs
@@ -530,6 +801,16 @@ type PipelineTask struct {
 	PipelineID string `json:"pipeline_id"`
 }
 
+// DbtTask runs dbt commands from the project in the repository of the job
+type DbtTask struct {
+	Commands          []string `json:"commands"`
+	ProjectDirectory  string   `json:"project_directory,omitempty"`
+	ProfilesDirectory string   `json:"profiles_directory,omitempty"`
+	Catalog           string   `json:"catalog,omitempty"`
+	Schema            string   `json:"schema,omitempty"`
+	WarehouseID       string   `json:"warehouse_id,omitempty"`
+}
+
 // EmailNotifications contains the information for email notifications after job completion
 type EmailNotifications struct {
 	OnStart               []string `json:"on_start,omitempty"`
@@ -538,6 +819,57 @@ type EmailNotifications struct {
 	NoAlertForSkippedRuns bool     `json:"no_alert_for_skipped_runs,omitempty"`
 }
 
+// TaskNotificationSettings control which runs of the task send email notifications
+type TaskNotificationSettings struct {
+	NoAlertForSkippedRuns  bool `json:"no_alert_for_skipped_runs,omitempty"`
+	NoAlertForCanceledRuns bool `json:"no_alert_for_canceled_runs,omitempty"`
+	AlertOnLastAttempt     bool `json:"alert_on_last_attempt,omitempty"`
+}
+
+// Webhook refers to a notification destination configured in the workspace
+type Webhook struct {
+	ID string `json:"id"`
+}
+
+// WebhookNotifications contains the notification destinations called on job events
+type WebhookNotifications struct {
+	OnStart   []Webhook `json:"on_start,omitempty"`
+	OnSuccess []Webhook `json:"on_success,omitempty"`
+	OnFailure []Webhook `json:"on_failure,omitempty"`
+}
+
+// NotificationDestination is a webhook destination, that is configured by workspace admins
+type NotificationDestination struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// NotificationDestinationList ...
+type NotificationDestinationList struct {
+	Results       []NotificationDestination `json:"results,omitempty"`
+	NextPageToken string                    `json:"next_page_token,omitempty"`
+}
+
+// NotificationDestinationListRequest is the paginated request to list notification destinations
+type NotificationDestinationListRequest struct {
+	PageToken string `url:"page_token,omitempty"`
+}
+
+// GitSource contains the Git repository, from which notebooks of the job are taken
+type GitSource struct {
+	URL      string `json:"git_url" tf:"alias:url"`
+	Provider string `json:"git_provider,omitempty" tf:"alias:provider"`
+	Branch   string `json:"git_branch,omitempty" tf:"alias:branch"`
+	Tag      string `json:"git_tag,omitempty" tf:"alias:tag"`
+	Commit   string `json:"git_commit,omitempty" tf:"alias:commit"`
+}
+
+// JobRunAs contains the identity the job runs as. Only one of the fields could be set.
+type JobRunAs struct {
+	UserName             string `json:"user_name,omitempty"`
+	ServicePrincipalName string `json:"service_principal_name,omitempty"`
+}
+
 // CronSchedule contains the information for the quartz cron expression
 type CronSchedule struct {
 	QuartzCronExpression string `json:"quartz_cron_expression"`
@@ -545,6 +877,24 @@ type CronSchedule struct {
 	PauseStatus          string `json:"pause_status,omitempty" tf:"computed"`
 }
 
+// FileArrivalTriggerConf starts runs of the job, when new files arrive to the location
+type FileArrivalTriggerConf struct {
+	URL                           string `json:"url"`
+	MinTimeBetweenTriggersSeconds int32  `json:"min_time_between_triggers_seconds,omitempty"`
+	WaitAfterLastChangeSeconds    int32  `json:"wait_after_last_change_seconds,omitempty"`
+}
+
+// JobTrigger contains the conditions, that start runs of the job
+type JobTrigger struct {
+	FileArrival *FileArrivalTriggerConf `json:"file_arrival"`
+	PauseStatus string                  `json:"pause_status,omitempty" tf:"computed"`
+}
+
+// ContinuousConf makes the job always have an active run
+type ContinuousConf struct {
+	PauseStatus string `json:"pause_status,omitempty" tf:"computed"`
+}
+
 type TaskDependency struct {
 	TaskKey string `json:"task_key,omitempty"`
 }
@@ -556,6 +906,7 @@ type JobTaskSettings struct {
 
 	ExistingClusterID      string              `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
 	NewCluster             *Cluster            `json:"new_cluster,omitempty" tf:"group:cluster_type"`
+	JobClusterKey          string              `json:"job_cluster_key,omitempty" tf:"group:cluster_type"`
 	Libraries              []Library           `json:"libraries,omitempty" tf:"slice_set,alias:library"`
 	NotebookTask           *NotebookTask       `json:"notebook_task,omitempty" tf:"group:task_type"`
 	SparkJarTask           *SparkJarTask       `json:"spark_jar_task,omitempty" tf:"group:task_type"`
@@ -563,16 +914,26 @@ type JobTaskSettings struct {
 	SparkSubmitTask        *SparkSubmitTask    `json:"spark_submit_task,omitempty" tf:"group:task_type"`
 	PipelineTask           *PipelineTask       `json:"pipeline_task,omitempty" tf:"group:task_type"`
 	PythonWheelTask        *PythonWheelTask    `json:"python_wheel_task,omitempty" tf:"group:task_type"`
+	DbtTask                *DbtTask            `json:"dbt_task,omitempty" tf:"group:task_type"`
 	EmailNotifications     *EmailNotifications `json:"email_notifications,omitempty" tf:"suppress_diff"`
 	TimeoutSeconds         int32               `json:"timeout_seconds,omitempty"`
 	MaxRetries             int32               `json:"max_retries,omitempty"`
 	MinRetryIntervalMillis int32               `json:"min_retry_interval_millis,omitempty"`
 	RetryOnTimeout         bool                `json:"retry_on_timeout,omitempty" tf:"computed"`
+	// not suppressed like email_notifications, so that removal of the block is planned
+	NotificationSettings *TaskNotificationSettings `json:"notification_settings,omitempty"`
+}
+
+// JobCluster is the cluster specification, that could be shared by tasks of the same job
+type JobCluster struct {
+	JobClusterKey string   `json:"job_cluster_key"`
+	NewCluster    *Cluster `json:"new_cluster"`
 }
 
 // JobSettings contains the information for configuring a job on databricks
 type JobSettings struct {
-	Name string `json:"name,omitempty" tf:"default:Untitled"`
+	Name        string `json:"name,omitempty" tf:"default:Untitled"`
+	Description string `json:"description,omitempty"`
 
 	// BEGIN Jobs API 2.0
 	ExistingClusterID      string           `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
@@ -591,28 +952,128 @@ type JobSettings struct {
 	// END Jobs API 2.0
 
 	// BEGIN Jobs API 2.1
-	Tasks  []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
-	Format string            `json:"format,omitempty" tf:"computed"`
+	Tasks       []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
+	JobClusters []JobCluster      `json:"job_clusters,omitempty" tf:"alias:job_cluster"`
+	Format      string            `json:"format,omitempty" tf:"computed"`
+	GitSource   *GitSource        `json:"git_source,omitempty"`
 	// END Jobs API 2.1
 
-	Schedule           *CronSchedule       `json:"schedule,omitempty"`
-	MaxConcurrentRuns  int32               `json:"max_concurrent_runs,omitempty"`
-	EmailNotifications *EmailNotifications `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	Schedule             *CronSchedule         `json:"schedule,omitempty"`
+	Trigger              *JobTrigger           `json:"trigger,omitempty"`
+	Continuous           *ContinuousConf       `json:"continuous,omitempty"`
+	MaxConcurrentRuns    int32                 `json:"max_concurrent_runs,omitempty"`
+	EmailNotifications   *EmailNotifications   `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	WebhookNotifications *WebhookNotifications `json:"webhook_notifications,omitempty"`
+	RunAs                *JobRunAs             `json:"run_as,omitempty" tf:"suppress_diff"`
 }
 
 func (js *JobSettings) isMultiTask() bool {
 	return js.Format == "MULTI_TASK" || len(js.Tasks) > 0
 }
 
+// normalizeFormat fills format of jobs, that were created before Jobs API 2.1,
+// so that the state is consistent regardless of job origin
+func (js *JobSettings) normalizeFormat() {
+	if js.Format != "" {
+		return
+	}
+	if len(js.Tasks) > 0 {
+		js.Format = "MULTI_TASK"
+	} else {
+		js.Format = "SINGLE_TASK"
+	}
+}
+
+// stripFormat removes format before create or reset, as the one in state could be filled
+// by normalizeFormat and no longer match the tasks of the job. API derives format from tasks.
+func (js *JobSettings) stripFormat() {
+	if js.Format == "SINGLE_TASK" || len(js.Tasks) > 0 {
+		js.Format = ""
+	}
+}
+
 func (js *JobSettings) sortTasksByKey() {
 	sort.Slice(js.Tasks, func(i, j int) bool {
 		return js.Tasks[i].TaskKey < js.Tasks[j].TaskKey
 	})
 }
 
+// RunSubmit is the request to submit one-time run, that is not visible as a job
+type RunSubmit struct {
+	RunName              string                `json:"run_name,omitempty"`
+	Tasks                []JobTaskSettings     `json:"tasks,omitempty"`
+	GitSource            *GitSource            `json:"git_source,omitempty"`
+	TimeoutSeconds       int32                 `json:"timeout_seconds,omitempty"`
+	IdempotencyToken     string                `json:"idempotency_token,omitempty"`
+	WebhookNotifications *WebhookNotifications `json:"webhook_notifications,omitempty"`
+}
+
+// ToRunSubmit projects job settings into the one-time run of the same tasks. Schedule,
+// trigger, continuous and other settings of recurring runs are dropped. As run-submit
+// has no shared job clusters, their definitions are copied into tasks, that use them.
+// Single-task jobs are converted into one task with `main` key.
+func (js JobSettings) ToRunSubmit(runName string) RunSubmit {
+	rs := RunSubmit{
+		RunName:              runName,
+		GitSource:            js.GitSource,
+		TimeoutSeconds:       js.TimeoutSeconds,
+		WebhookNotifications: js.WebhookNotifications,
+	}
+	if !js.isMultiTask() {
+		rs.Tasks = []JobTaskSettings{{
+			TaskKey:                "main",
+			ExistingClusterID:      js.ExistingClusterID,
+			NewCluster:             js.NewCluster,
+			Libraries:              js.Libraries,
+			NotebookTask:           js.NotebookTask,
+			SparkJarTask:           js.SparkJarTask,
+			SparkPythonTask:        js.SparkPythonTask,
+			SparkSubmitTask:        js.SparkSubmitTask,
+			PipelineTask:           js.PipelineTask,
+			PythonWheelTask:        js.PythonWheelTask,
+			MaxRetries:             js.MaxRetries,
+			MinRetryIntervalMillis: js.MinRetryIntervalMillis,
+			RetryOnTimeout:         js.RetryOnTimeout,
+		}}
+		return rs
+	}
+	jobClusters := map[string]*Cluster{}
+	for _, jc := range js.JobClusters {
+		jobClusters[jc.JobClusterKey] = jc.NewCluster
+	}
+	for _, task := range js.Tasks {
+		if cluster, ok := jobClusters[task.JobClusterKey]; ok {
+			task.NewCluster = cluster
+			task.JobClusterKey = ""
+		}
+		rs.Tasks = append(rs.Tasks, task)
+	}
+	return rs
+}
+
+// TasksOnRuntime returns keys of tasks, that run on new clusters with spark_version matching
+// the predicate, like deprecated runtimes. Tasks, that use shared job clusters, are included
+// as well. Task of single-task job has `main` key.
+func (js JobSettings) TasksOnRuntime(predicate func(version string) bool) (taskKeys []string) {
+	for _, task := range js.ToRunSubmit("").Tasks {
+		if task.NewCluster != nil && predicate(task.NewCluster.SparkVersion) {
+			taskKeys = append(taskKeys, task.TaskKey)
+		}
+	}
+	return
+}
+
 // JobList ...
 type JobList struct {
-	Jobs []Job `json:"jobs"`
+	Jobs    []Job `json:"jobs"`
+	HasMore bool  `json:"has_more,omitempty"`
+}
+
+// JobListRequest is the paginated request to list jobs
+type JobListRequest struct {
+	Offset      int  `url:"offset,omitempty"`
+	Limit       int  `url:"limit,omitempty"`
+	ExpandTasks bool `url:"expand_tasks,omitempty"`
 }
 
 // Job contains the information when using a GET request from the Databricks Jobs api
@@ -639,11 +1100,67 @@ type RunParameters struct {
 	SparkSubmitParams []string          `json:"spark_submit_params,omitempty"`
 }
 
+// RunLifeCycleState is for describing possible states of the job run
+type RunLifeCycleState string
+
+const (
+	// RunLifeCycleStateQueued indicates that the run waits for concurrency limits of the job.
+	RunLifeCycleStateQueued RunLifeCycleState = "QUEUED"
+	// RunLifeCycleStatePending indicates that the run has been triggered and its cluster is starting.
+	RunLifeCycleStatePending RunLifeCycleState = "PENDING"
+	// RunLifeCycleStateRunning indicates that the task of this run is being executed.
+	RunLifeCycleStateRunning RunLifeCycleState = "RUNNING"
+	// RunLifeCycleStateTerminating indicates that the task of this run has completed
+	// and the cluster and execution context are being cleaned up.
+	RunLifeCycleStateTerminating RunLifeCycleState = "TERMINATING"
+	// RunLifeCycleStateTerminated indicates that the task of this run has completed.
+	RunLifeCycleStateTerminated RunLifeCycleState = "TERMINATED"
+	// RunLifeCycleStateSkipped indicates that the run was skipped, because a previous run
+	// of the same job was already active.
+	RunLifeCycleStateSkipped RunLifeCycleState = "SKIPPED"
+	// RunLifeCycleStateInternalError indicates an exceptional state of the run, like
+	// a failure in the service or cloud provider.
+	RunLifeCycleStateInternalError RunLifeCycleState = "INTERNAL_ERROR"
+	// RunLifeCycleStateBlocked indicates that the run is blocked on an upstream dependency.
+	RunLifeCycleStateBlocked RunLifeCycleState = "BLOCKED"
+	// RunLifeCycleStateWaitingForRetry indicates that the run waits for a retry to be triggered.
+	RunLifeCycleStateWaitingForRetry RunLifeCycleState = "WAITING_FOR_RETRY"
+)
+
+// RunResultState is for describing the outcome of the terminated job run
+type RunResultState string
+
+const (
+	// RunResultStateSuccess indicates that the task completed successfully.
+	RunResultStateSuccess RunResultState = "SUCCESS"
+	// RunResultStateFailed indicates that the task completed with an error.
+	RunResultStateFailed RunResultState = "FAILED"
+	// RunResultStateTimedOut indicates that the run was stopped after reaching the timeout.
+	RunResultStateTimedOut RunResultState = "TIMEDOUT"
+	// RunResultStateCanceled indicates that the run was canceled at user request.
+	RunResultStateCanceled RunResultState = "CANCELED"
+)
+
 // RunState ...
 type RunState struct {
-	ResultState    string `json:"result_state,omitempty"`
-	LifeCycleState string `json:"life_cycle_state,omitempty"`
-	StateMessage   string `json:"state_message,omitempty"`
+	ResultState    RunResultState    `json:"result_state,omitempty"`
+	LifeCycleState RunLifeCycleState `json:"life_cycle_state,omitempty"`
+	StateMessage   string            `json:"state_message,omitempty"`
+}
+
+// IsTerminal returns true if the run cannot change its state anymore
+func (rs RunState) IsTerminal() bool {
+	switch rs.LifeCycleState {
+	case RunLifeCycleStateTerminated, RunLifeCycleStateSkipped, RunLifeCycleStateInternalError:
+		return true
+	}
+	return false
+}
+
+// IsSuccessful returns true if the run has completed successfully
+func (rs RunState) IsSuccessful() bool {
+	return rs.LifeCycleState == RunLifeCycleStateTerminated &&
+		rs.ResultState == RunResultStateSuccess
 }
 
 // JobRun is a simplified representation of corresponding entity
@@ -657,6 +1174,50 @@ type JobRun struct {
 	RuntType    string   `json:"run_type,omitempty"`
 
 	OverridingParameters RunParameters `json:"overriding_parameters,omitempty"`
+
+	EndTime int64     `json:"end_time,omitempty"`
+	Tasks   []RunTask `json:"tasks,omitempty"`
+}
+
+// ClusterInstance is the cluster, that was used by the run
+type ClusterInstance struct {
+	ClusterID      string `json:"cluster_id,omitempty"`
+	SparkContextID string `json:"spark_context_id,omitempty"`
+}
+
+// RunTask is the run of a single task of multi-task job
+type RunTask struct {
+	RunID             int64            `json:"run_id,omitempty"`
+	TaskKey           string           `json:"task_key,omitempty"`
+	State             RunState         `json:"state,omitempty"`
+	ClusterInstance   *ClusterInstance `json:"cluster_instance,omitempty"`
+	StartTime         int64            `json:"start_time,omitempty"`
+	EndTime           int64            `json:"end_time,omitempty"`
+	SetupDuration     int64            `json:"setup_duration,omitempty"`
+	ExecutionDuration int64            `json:"execution_duration,omitempty"`
+	CleanupDuration   int64            `json:"cleanup_duration,omitempty"`
+	DbtTask           *DbtTask         `json:"dbt_task,omitempty"`
+}
+
+// DbtOutput points to artifacts of dbt task run, like run_results.json
+type DbtOutput struct {
+	ArtifactsLink    string            `json:"artifacts_link,omitempty"`
+	ArtifactsHeaders map[string]string `json:"artifacts_headers,omitempty"`
+}
+
+// RunOutput is the output of a single task run
+type RunOutput struct {
+	Error     string     `json:"error,omitempty"`
+	DbtOutput *DbtOutput `json:"dbt_output,omitempty"`
+}
+
+// Duration returns the time in milliseconds, that it took to run the task
+func (rt RunTask) Duration() int64 {
+	duration := rt.SetupDuration + rt.ExecutionDuration + rt.CleanupDuration
+	if duration == 0 && rt.EndTime > rt.StartTime {
+		duration = rt.EndTime - rt.StartTime
+	}
+	return duration
 }
 
 // JobRunsListRequest ...
@@ -666,6 +1227,7 @@ type JobRunsListRequest struct {
 	CompletedOnly bool  `url:"completed_only,omitempty"`
 	Offset        int32 `url:"offset,omitempty"`
 	Limit         int32 `url:"limit,omitempty"`
+	ExpandTasks   bool  `url:"expand_tasks,omitempty"`
 }
 
 // JobRunsList ..
@@ -739,6 +1301,16 @@ const (
 	EvTypeUnpinned            ClusterEventType = "UNPINNED"
 )
 
+// clusterEventTypes lists all event types, that could be used to filter cluster events
+var clusterEventTypes = []ClusterEventType{
+	EvTypeCreating, EvTypeDidNotExpandDisk, EvTypeExpandedDisk, EvTypeFailedToExpandDisk,
+	EvTypeInitScriptsStarting, EvTypeInitScriptsFinished, EvTypeStarting, EvTypeRestarting,
+	EvTypeTerminating, EvTypeEdited, EvTypeRunning, EvTypeResizing, EvTypeUpsizeCompleted,
+	EvTypeNodesLost, EvTypeDriverHealthy, EvTypeDriverUnavailable, EvTypeSparkException,
+	EvTypeDriverNotResponding, EvTypeDbfsDown, EvTypeMetastoreDown, EvTypeNodeBlacklisted,
+	EvTypePinned, EvTypeUnpinned,
+}
+
 // EventsRequest - request structure
 // https://docs.databricks.com/dev-tools/api/latest/clusters.html#request-structure
 type EventsRequest struct {
@@ -774,6 +1346,26 @@ type EventDetails struct {
 	ResizeCause         *ResizeCause       `json:"cause,omitempty"`
 	Reason              *TerminationReason `json:"reason,omitempty"`
 	User                string             `json:"user"`
+	// InitScripts is reported with INIT_SCRIPTS_FINISHED events
+	InitScripts *InitScriptEventDetails `json:"init_scripts,omitempty"`
+	// InstanceIDs are the cloud instances, that were lost, reported with NODES_LOST events
+	InstanceIDs []string `json:"instance_ids,omitempty"`
+}
+
+// InitScriptExecutionDetails is the outcome of a single init script on a node
+type InitScriptExecutionDetails struct {
+	InitScriptStorageInfo
+	Status                   string `json:"status,omitempty"`
+	ErrorMessage             string `json:"error_message,omitempty"`
+	ExecutionDurationSeconds int32  `json:"execution_duration_seconds,omitempty"`
+}
+
+// InitScriptEventDetails contains results of cluster-scoped and global init scripts
+type InitScriptEventDetails struct {
+	Cluster []InitScriptExecutionDetails `json:"cluster,omitempty"`
+	Global  []InitScriptExecutionDetails `json:"global,omitempty"`
+	// ReportedForNode is the private IP address of the node, where init scripts ran
+	ReportedForNode string `json:"reported_for_node,omitempty"`
 }
 
 // ClusterEvent - event information
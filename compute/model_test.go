@@ -91,3 +91,71 @@ func TestClusterState_CanReach(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		sparkConf  map[string]string
+		profile    string
+		singleNode bool
+		serverless bool
+	}{
+		{"standard", nil, "", false, false},
+		{"standard with conf", map[string]string{"spark.speculation": "true"}, "", false, false},
+		{"single node", map[string]string{
+			"spark.databricks.cluster.profile": "singleNode",
+			"spark.master":                     "local[*]",
+		}, "singleNode", true, false},
+		{"serverless", map[string]string{
+			"spark.databricks.cluster.profile": "serverless",
+		}, "serverless", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := Cluster{SparkConf: tt.sparkConf}
+			if got := cluster.Profile(); got != tt.profile {
+				t.Errorf("Profile() = %v, want %v", got, tt.profile)
+			}
+			if got := cluster.IsSingleNode(); got != tt.singleNode {
+				t.Errorf("IsSingleNode() = %v, want %v", got, tt.singleNode)
+			}
+			if got := cluster.IsServerless(); got != tt.serverless {
+				t.Errorf("IsServerless() = %v, want %v", got, tt.serverless)
+			}
+		})
+	}
+}
+
+func TestClusterInfoDriverAddresses(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver *SparkNode
+		ip     string
+		dns    string
+	}{
+		{"not running", nil, "", ""},
+		{"private only", &SparkNode{PrivateIP: "10.0.0.1"}, "10.0.0.1", ""},
+		{"public", &SparkNode{
+			PrivateIP: "10.0.0.1",
+			PublicDNS: "ec2-1-2-3-4.compute-1.amazonaws.com",
+		}, "10.0.0.1", "ec2-1-2-3-4.compute-1.amazonaws.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ci := ClusterInfo{ClusterID: "abc", State: ClusterStateTerminated, Driver: tt.driver}
+			ip, err := ci.GetDriverIP()
+			if ip != tt.ip || (err == nil) != (tt.ip != "") {
+				t.Errorf("GetDriverIP() = %v, %v, want %v", ip, err, tt.ip)
+			}
+			dns, err := ci.GetDriverPublicDNS()
+			if dns != tt.dns || (err == nil) != (tt.dns != "") {
+				t.Errorf("GetDriverPublicDNS() = %v, %v, want %v", dns, err, tt.dns)
+			}
+		})
+	}
+	ci := ClusterInfo{ClusterID: "abc", State: ClusterStateTerminated}
+	_, err := ci.GetDriverIP()
+	if err == nil || err.Error() != "private IP of the driver of cluster abc is not known, as cluster is TERMINATED" {
+		t.Errorf("GetDriverIP() error = %v", err)
+	}
+}
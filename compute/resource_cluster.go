@@ -2,15 +2,22 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 )
@@ -30,6 +37,7 @@ func ResourceCluster() *schema.Resource {
 			d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewClustersAPI(ctx, c).PermanentDelete(d.Id())
 		},
+		CustomizeDiff: resourceClusterCustomizeDiff,
 		Schema:        clusterSchema,
 		SchemaVersion: 2,
 		Timeouts: &schema.ResourceTimeout{
@@ -40,6 +48,203 @@ func ResourceCluster() *schema.Resource {
 	}.ToResource()
 }
 
+func resourceClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+	if err := validateClusterNodeTypeDiff(d); err != nil {
+		return err
+	}
+	if err := validateDockerImageDigestDiff(d); err != nil {
+		return err
+	}
+	if err := validateSSHPublicKeysDiff(d); err != nil {
+		return err
+	}
+	if err := validateSparkVersionDiff(d); err != nil {
+		return err
+	}
+	isClone := d.Get("clone_from_cluster_id").(string) != "" || !d.NewValueKnown("clone_from_cluster_id")
+	if d.Id() == "" && isClone {
+		// definition of the new clone is known only after the source cluster is fetched
+		return nil
+	}
+	warnAboutSparkVersion(ctx, d, c)
+	if err := validateNodeTypesDiff(ctx, d, c, "node_type_id", "driver_node_type_id"); err != nil {
+		return err
+	}
+	return validateClusterPolicyDiff(ctx, d, c)
+}
+
+// validateClusterPolicyDiff checks new or changed cluster against its policy
+func validateClusterPolicyDiff(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+	if d.Get("policy_id").(string) == "" && d.NewValueKnown("policy_id") {
+		return nil
+	}
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	var cluster Cluster
+	err := common.DiffToStructPointer(d, clusterSchema, &cluster)
+	if err != nil {
+		return err
+	}
+	return validateClusterPolicy(d, "policy_id", cluster, NewClusterPoliciesAPI(ctx, c))
+}
+
+// logPlanWarning logs non-empty advisory warning, that is found during plan. Warnings from
+// CustomizeDiff are not supported by Terraform, so they are visible only with TF_LOG=WARN.
+// Warnings, that depend only on the value of a single attribute, should be returned from
+// ValidateDiagFunc instead, so that they are shown by terraform plan.
+func logPlanWarning(warning string) {
+	if warning == "" {
+		return
+	}
+	log.Printf("[WARN] %s", warning)
+}
+
+// warnAboutSparkVersion logs a warning, if new spark_version is not among the runtimes
+// currently offered by the workspace, as deprecated runtimes are removed from the list.
+// The check needs the API client, so it cannot be done in ValidateDiagFunc.
+func warnAboutSparkVersion(ctx context.Context, d *schema.ResourceDiff, c interface{}) {
+	if d.Get("skip_version_validation").(bool) || d.Get("spark_version").(string) == "" ||
+		!d.HasChange("spark_version") || !d.NewValueKnown("spark_version") {
+		return
+	}
+	logPlanWarning(NewClustersAPI(ctx, c).sparkVersionWarning(d.Get("spark_version").(string)))
+}
+
+// maxAutoterminationMinutes is the longest inactivity of all-purpose cluster, that is
+// considered reasonable from the cost perspective
+const maxAutoterminationMinutes = 24 * 60
+
+// validateAutoterminationMinutes warns, if all-purpose cluster never terminates or stays
+// idle for too long. Only clusters of databricks_cluster resource are checked, as those
+// are all-purpose clusters created through the API.
+func validateAutoterminationMinutes(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(int)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be int", k)}
+	}
+	if warning := autoterminationWarning(int32(v), ClusterSourceAPI); warning != "" {
+		warnings = append(warnings, warning)
+	}
+	return
+}
+
+// autoterminationWarning returns cost-control advice for all-purpose clusters, that never
+// terminate or terminate after more than a day. Job clusters terminate with their runs.
+func autoterminationWarning(autoterminationMinutes int32, source Availability) string {
+	if source == ClusterSourceJob {
+		return ""
+	}
+	if autoterminationMinutes == 0 {
+		return "autotermination_minutes = 0 disables automatic termination of all-purpose " +
+			"cluster, so it is billed until terminated manually. Consider the default of 60 minutes"
+	}
+	if autoterminationMinutes > maxAutoterminationMinutes {
+		return fmt.Sprintf("all-purpose cluster terminates only after %d minutes of inactivity. "+
+			"Consider autotermination_minutes of at most %d to control costs",
+			autoterminationMinutes, maxAutoterminationMinutes)
+	}
+	return ""
+}
+
+func (a ClustersAPI) sparkVersionWarning(sparkVersion string) string {
+	sparkVersions, err := a.ListSparkVersions()
+	if err != nil {
+		return fmt.Sprintf("cannot verify spark_version %s: %s", sparkVersion, err)
+	}
+	for _, v := range sparkVersions.SparkVersions {
+		if v.Version == sparkVersion {
+			return ""
+		}
+	}
+	return fmt.Sprintf("spark_version %s is deprecated or no longer available. Upgrade to "+
+		"a supported runtime, that could be looked up with databricks_spark_version data source, "+
+		"or set skip_version_validation, if the version is known to be valid", sparkVersion)
+}
+
+// validateClusterNodeTypeDiff complements ConflictsWith of instance_pool_id, that doesn't
+// catch values known only during plan. node_type_id and driver_instance_pool_id are computed,
+// so for existing clusters their planned values are echoed from state and are checked only when changed.
+func validateClusterNodeTypeDiff(d *schema.ResourceDiff) error {
+	fromConfig := func(k string) string {
+		if !d.HasChange(k) {
+			return ""
+		}
+		return d.Get(k).(string)
+	}
+	instancePoolID := d.Get("instance_pool_id").(string)
+	if instancePoolID != "" && fromConfig("node_type_id") != "" {
+		return fmt.Errorf("cannot specify both instance_pool_id and node_type_id")
+	}
+	if instancePoolID == "" && fromConfig("driver_instance_pool_id") != "" {
+		return fmt.Errorf("driver_instance_pool_id can only be specified together with instance_pool_id")
+	}
+	return nil
+}
+
+// validateSparkVersionDiff rejects removal of spark_version from existing clusters, that
+// is not caught by AtLeastOneOf of cloned clusters, unless it was copied from the source
+func validateSparkVersionDiff(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChange("spark_version") ||
+		d.Get("spark_version").(string) != "" || !d.NewValueKnown("spark_version") {
+		return nil
+	}
+	if cloned, ok := d.Get("cloned_attributes").(*schema.Set); ok && cloned.Contains("spark_version") {
+		return nil
+	}
+	return fmt.Errorf("spark_version is required, unless clone_from_cluster_id is set")
+}
+
+// validateNodeTypesDiff checks changed node type attributes during plan, once their values are known
+func validateNodeTypesDiff(ctx context.Context, d *schema.ResourceDiff, c interface{}, attrs ...string) error {
+	for _, attr := range attrs {
+		nodeTypeID := d.Get(attr).(string)
+		if nodeTypeID == "" || !d.HasChange(attr) || !d.NewValueKnown(attr) {
+			continue
+		}
+		if err := NewClustersAPI(ctx, c).validateNodeType(attr, nodeTypeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSSHPublicKeysDiff rejects SSH access to clusters shared by multiple users,
+// as the API doesn't allow it for USER_ISOLATION mode
+func validateSSHPublicKeysDiff(d *schema.ResourceDiff) error {
+	if d.Get("data_security_mode").(string) != DataSecurityModeUserIsolation {
+		return nil
+	}
+	if keys, ok := d.GetOk("ssh_public_keys"); ok && len(keys.([]interface{})) > 0 {
+		return fmt.Errorf("ssh_public_keys cannot be specified when data_security_mode is %s",
+			DataSecurityModeUserIsolation)
+	}
+	return nil
+}
+
+func validateDockerImageDigestDiff(d *schema.ResourceDiff) error {
+	if !d.Get("require_docker_image_digest").(bool) {
+		return nil
+	}
+	url, ok := d.GetOk("docker_image.0.url")
+	if !ok || isDockerImagePinned(url.(string)) {
+		return nil
+	}
+	return fmt.Errorf("docker_image.url must be pinned by digest, "+
+		"like repository@sha256:<digest>, but got: %s", url)
+}
+
+func sparkVersionDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || d.Get("strict_spark_version").(bool) {
+		return false
+	}
+	if sameRuntimeKeys(old, new) {
+		log.Printf("[DEBUG] Suppressing diff for k=%#v old=%#v new=%#v", k, old, new)
+		return true
+	}
+	return false
+}
+
 func sparkConfDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 	isPossiblyLegacyConfig := k == "spark_conf.%" && old == "1" && new == "0"
 	isLegacyConfig := k == "spark_conf.spark.databricks.delta.preview.enabled"
@@ -50,20 +255,432 @@ func sparkConfDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool
 	return false
 }
 
+// digest-pinned images are referenced as repository@sha256:<64 hex characters>
+var dockerImageDigestRegex = regexp.MustCompile(`@sha256:[a-f0-9]{64}$`)
+
+func isDockerImagePinned(url string) bool {
+	return dockerImageDigestRegex.MatchString(url)
+}
+
+// secret references are resolved by Databricks, when they are used as values of cluster settings
+var secretReferenceRegex = regexp.MustCompile(`^\{\{secrets/[^/{}]+/[^/{}]+\}\}$`)
+
+func isSecretReference(v string) bool {
+	return secretReferenceRegex.MatchString(v)
+}
+
+// validateDockerPassword allows only literal passwords, as secret references are read
+// back into secret_reference, so that references in password would produce a diff
+func validateDockerPassword(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok || !strings.Contains(v, "{{secrets/") {
+		return
+	}
+	errors = append(errors, fmt.Errorf("%s must not be a secret reference, "+
+		"use secret_reference with {{secrets/<scope>/<key>}} instead", k))
+	return
+}
+
+// validateSecretReference ensures value is {{secrets/<scope>/<key>}}
+func validateSecretReference(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok || isSecretReference(v) {
+		return
+	}
+	errors = append(errors, fmt.Errorf("%s must be in {{secrets/<scope>/<key>}} format, but got: %s", k, v))
+	return
+}
+
+// customizeDockerBasicAuthSchema validates docker credentials of cluster-like blocks
+func customizeDockerBasicAuthSchema(s map[string]*schema.Schema) {
+	for _, block := range []string{"docker_image", "preloaded_docker_image"} {
+		if p, err := common.SchemaPath(s, block, "basic_auth", "password"); err == nil {
+			p.ValidateFunc = validateDockerPassword
+		}
+		if p, err := common.SchemaPath(s, block, "basic_auth", "secret_reference"); err == nil {
+			p.ValidateFunc = validateSecretReference
+		}
+	}
+}
+
+func (auth *DockerBasicAuth) validate() error {
+	if auth == nil {
+		return nil
+	}
+	if (auth.Password == "") == (auth.SecretReference == "") {
+		return fmt.Errorf("exactly one of basic_auth.password or basic_auth.secret_reference must be specified")
+	}
+	return nil
+}
+
+// validateDockerImageURL warns, when image is referenced by a mutable tag, like `:latest`
+func validateDockerImageURL(i interface{}, p cty.Path) diag.Diagnostics {
+	url, ok := i.(string)
+	if !ok || isDockerImagePinned(url) {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Summary: fmt.Sprintf("Docker image %s is not pinned by digest", url),
+			Detail: "Mutable tags make cluster configuration non-reproducible. " +
+				"Consider referencing the image as repository@sha256:<digest>",
+			Severity:      diag.Warning,
+			AttributePath: p,
+		},
+	}
+}
+
+var gcpZoneRegex = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+-[a-z]$`)
+
+func validateGcpZoneID(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == GcpZoneAuto || v == GcpZoneHA || gcpZoneRegex.MatchString(v) {
+		return
+	}
+	errors = append(errors, fmt.Errorf("%s must be %s, %s or a zone like us-central1-a, got: %s",
+		k, GcpZoneAuto, GcpZoneHA, v))
+	return
+}
+
+// platform picks concrete zone for `auto` and `HA`, which should not produce a diff
+func gcpZoneIDSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && (new == GcpZoneAuto || new == GcpZoneHA)
+}
+
+func customizeGcpZoneIDSchema(s map[string]*schema.Schema) {
+	if p, err := common.SchemaPath(s, "gcp_attributes", "zone_id"); err == nil {
+		p.ValidateFunc = validateGcpZoneID
+		p.DiffSuppressFunc = gcpZoneIDSuppressFunc
+	}
+	s["effective_zone_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// validateGcpZone checks concrete zone against zones of the workspace region, if those could be listed
+func (a ClustersAPI) validateGcpZone(zone string) error {
+	if zone == "" || zone == GcpZoneAuto || zone == GcpZoneHA {
+		return nil
+	}
+	zones, err := a.ListZones()
+	if err != nil {
+		log.Printf("[WARN] Cannot list zones to validate %s: %s", zone, err)
+		return nil
+	}
+	if len(zones.Zones) == 0 {
+		return nil
+	}
+	for _, z := range zones.Zones {
+		if z == zone {
+			return nil
+		}
+	}
+	return fmt.Errorf("zone %s is not available in the workspace region. Available zones: %s",
+		zone, strings.Join(zones.Zones, ", "))
+}
+
+func validateClusterGcpZone(clusters ClustersAPI, cluster Cluster) error {
+	if !clusters.client.IsGcp() || cluster.GcpAttributes == nil {
+		return nil
+	}
+	return clusters.validateGcpZone(cluster.GcpAttributes.ZoneID)
+}
+
+// readInstancePool returns the pool, that is referenced by the cluster attribute
+func (a ClustersAPI) readInstancePool(attr, instancePoolID string) (*InstancePool, error) {
+	pool, err := NewInstancePoolsAPI(a.context, a.client).Read(instancePoolID)
+	if common.IsMissing(err) {
+		return nil, fmt.Errorf("%s: instance pool %s does not exist", attr, instancePoolID)
+	}
+	if err != nil {
+		log.Printf("[WARN] Cannot read instance pool %s to validate %s: %s", instancePoolID, attr, err)
+		return nil, nil
+	}
+	return &pool, nil
+}
+
+// validateInstancePools checks that worker and driver pools exist and have node types,
+// on which the cluster could be launched. Node types are checked only when resolvable.
+func (a ClustersAPI) validateInstancePools(cluster Cluster) error {
+	workerPool, err := a.readInstancePool("instance_pool_id", cluster.InstancePoolID)
+	if err != nil {
+		return err
+	}
+	driverPool := workerPool
+	if cluster.DriverInstancePoolID != "" && cluster.DriverInstancePoolID != cluster.InstancePoolID {
+		driverPool, err = a.readInstancePool("driver_instance_pool_id", cluster.DriverInstancePoolID)
+		if err != nil {
+			return err
+		}
+	}
+	if workerPool == nil || driverPool == nil {
+		return nil
+	}
+	var problems []string
+	workerZone, driverZone := workerPool.effectiveZoneID(), driverPool.effectiveZoneID()
+	if workerZone != "" && driverZone != "" && workerZone != driverZone &&
+		!strings.EqualFold(workerZone, "auto") && !strings.EqualFold(driverZone, "auto") {
+		problems = append(problems, fmt.Sprintf("worker pool %s is in %s zone, but driver pool %s is in %s zone",
+			workerPool.InstancePoolID, workerZone, driverPool.InstancePoolID, driverZone))
+	}
+	sparkVersion := strings.ToLower(cluster.SparkVersion)
+	isPhoton := strings.Contains(sparkVersion, "photon")
+	isGpu := strings.Contains(sparkVersion, "gpu")
+	if isPhoton || isGpu {
+		problems = append(problems, a.instancePoolNodeTypeProblems(cluster.SparkVersion,
+			isPhoton, isGpu, workerPool, driverPool)...)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("cluster cannot be launched from instance pools: %s",
+			strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// instancePoolNodeTypeProblems checks that node types of pools support the runtime of the cluster
+func (a ClustersAPI) instancePoolNodeTypeProblems(sparkVersion string, isPhoton, isGpu bool,
+	workerPool, driverPool *InstancePool) (problems []string) {
+	nodeTypes, err := a.ListNodeTypes()
+	if err != nil {
+		log.Printf("[WARN] Cannot list node types to validate instance pools: %s", err)
+		return
+	}
+	nodeTypeByID := map[string]NodeType{}
+	for _, nt := range nodeTypes.NodeTypes {
+		nodeTypeByID[nt.NodeTypeID] = nt
+	}
+	for _, role := range []struct {
+		name   string
+		pool   *InstancePool
+		photon func(NodeType) bool
+	}{
+		{"worker", workerPool, func(nt NodeType) bool { return nt.PhotonWorkerCapable }},
+		{"driver", driverPool, func(nt NodeType) bool { return nt.PhotonDriverCapable }},
+	} {
+		nt, ok := nodeTypeByID[role.pool.NodeTypeID]
+		if !ok {
+			continue
+		}
+		if isPhoton && !role.photon(nt) {
+			problems = append(problems, fmt.Sprintf("node type %s of %s pool %s cannot run Photon %s",
+				nt.NodeTypeID, role.name, role.pool.InstancePoolID, role.name))
+		}
+		if isGpu && nt.NumGPUs == 0 {
+			problems = append(problems, fmt.Sprintf("node type %s of %s pool %s has no GPUs, that are required by %s",
+				nt.NodeTypeID, role.name, role.pool.InstancePoolID, sparkVersion))
+		}
+	}
+	return
+}
+
+func validateClusterInstancePools(clusters ClustersAPI, cluster Cluster) error {
+	if cluster.InstancePoolID == "" {
+		return nil
+	}
+	return clusters.validateInstancePools(cluster)
+}
+
+// listSecretKeys returns keys of secrets in the scope. Secrets package depends on compute,
+// so the list is fetched directly.
+func (a ClustersAPI) listSecretKeys(scope string) (map[string]bool, error) {
+	var secretsList struct {
+		Secrets []struct {
+			Key string `json:"key"`
+		} `json:"secrets,omitempty"`
+	}
+	err := a.client.Get(a.context, "/secrets/list", map[string]string{
+		"scope": scope,
+	}, &secretsList)
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]bool{}
+	for _, secret := range secretsList.Secrets {
+		keys[secret.Key] = true
+	}
+	return keys, nil
+}
+
+// validateSecretReferences checks that secrets referenced by spark_env_vars, that are
+// commonly consumed by init scripts, exist. Content of init scripts is not inspected.
+func (a ClustersAPI) validateSecretReferences(envVars map[string]string) error {
+	names := make([]string, 0, len(envVars))
+	for name, value := range envVars {
+		if isSecretReference(value) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	scopes := map[string]map[string]bool{}
+	var problems []string
+	for _, name := range names {
+		// {{secrets/<scope>/<key>}}
+		parts := strings.Split(strings.Trim(envVars[name], "{}"), "/")
+		scope, key := parts[1], parts[2]
+		keys, listed := scopes[scope]
+		if !listed {
+			var err error
+			keys, err = a.listSecretKeys(scope)
+			if common.IsMissing(err) {
+				keys = map[string]bool{}
+				problems = append(problems, fmt.Sprintf("secret scope %s does not exist", scope))
+			} else if err != nil {
+				log.Printf("[WARN] Cannot list secrets of %s scope to validate spark_env_vars: %s", scope, err)
+				keys = nil
+			}
+			scopes[scope] = keys
+		}
+		if keys == nil || keys[key] {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("spark_env_vars.%s references missing secret %s in %s scope",
+			name, key, scope))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid secret references: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validateClusterSecretReferences(clusters ClustersAPI, cluster Cluster) error {
+	if len(cluster.SparkEnvVars) == 0 {
+		return nil
+	}
+	return clusters.validateSecretReferences(cluster.SparkEnvVars)
+}
+
+func (cluster Cluster) isEnhancedSecurityMonitoringEnabled() bool {
+	return cluster.EnhancedSecurityMonitoring != nil && cluster.EnhancedSecurityMonitoring.IsEnabled
+}
+
+// enhancedSecurityMonitoringConfKey is the workspace configuration flag of enhanced security feature
+const enhancedSecurityMonitoringConfKey = "enableEnhancedSecurityMonitoring"
+
+// validateEnhancedSecurityMonitoring checks that enhanced security feature is enabled for the workspace
+func (a ClustersAPI) validateEnhancedSecurityMonitoring() error {
+	conf := map[string]string{}
+	err := a.client.Get(a.context, "/workspace-conf", map[string]string{
+		"keys": enhancedSecurityMonitoringConfKey,
+	}, &conf)
+	if err != nil {
+		log.Printf("[WARN] Cannot check if enhanced security monitoring is enabled: %s", err)
+		return nil
+	}
+	if conf[enhancedSecurityMonitoringConfKey] != "true" {
+		return fmt.Errorf("enhanced_security_monitoring cannot be enabled, because enhanced " +
+			"security feature is not enabled for the workspace")
+	}
+	return nil
+}
+
+func validateClusterEnhancedSecurityMonitoring(clusters ClustersAPI, cluster Cluster) error {
+	if !cluster.isEnhancedSecurityMonitoringEnabled() {
+		return nil
+	}
+	return clusters.validateEnhancedSecurityMonitoring()
+}
+
+// knownPythonPaths are Python interpreters shipped with Databricks runtimes
+var knownPythonPaths = map[string]bool{
+	"/databricks/python3/bin/python3": true,
+	"/usr/bin/python3":                true,
+}
+
+// validatePysparkPython warns about Python interpreters, that are unlikely to exist on the cluster
+func validatePysparkPython(i interface{}, p cty.Path) (diags diag.Diagnostics) {
+	envVars, ok := i.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, k := range []string{"PYSPARK_PYTHON", "PYSPARK_DRIVER_PYTHON"} {
+		v, ok := envVars[k].(string)
+		if !ok || v == "" || knownPythonPaths[v] {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Summary: fmt.Sprintf("%s points to %s, which is unlikely to exist on Databricks runtimes", k, v),
+			Detail: "Jobs fail when Python interpreter is not found on the cluster. " +
+				"Consider using /databricks/python3/bin/python3",
+			Severity:      diag.Warning,
+			AttributePath: append(p, cty.IndexStep{Key: cty.StringVal(k)}),
+		})
+	}
+	return diags
+}
+
+// sensitiveClusterFields are not displayed in plan output of cluster-like blocks
+var sensitiveClusterFields = [][]string{
+	{"spark_env_vars"},
+	{"docker_image", "basic_auth", "password"},
+	{"preloaded_docker_image", "basic_auth", "password"},
+	{"cluster_log_conf", "s3", "kms_key"},
+	{"init_scripts", "s3", "kms_key"},
+}
+
+func markClusterSensitiveFields(s map[string]*schema.Schema) {
+	for _, path := range sensitiveClusterFields {
+		if p, err := common.SchemaPath(s, path...); err == nil {
+			p.Sensitive = true
+		}
+	}
+	customizeDockerBasicAuthSchema(s)
+}
+
 func resourceClusterSchema() map[string]*schema.Schema {
 	return common.StructToSchema(Cluster{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
-		s["spark_conf"].DiffSuppressFunc = sparkConfDiffSuppressFunc
-		// adds `libraries` configuration block
-		s["library"] = common.StructToSchema(ClusterLibraryList{},
-			func(ss map[string]*schema.Schema) map[string]*schema.Schema {
-				return ss
-			})["library"]
-
-		p, err := common.SchemaPath(s, "docker_image", "basic_auth", "password")
-		if err == nil {
-			p.Sensitive = true
+		computeSpecSchema(s, computeSpecOptions{
+			allowLibraries:        true,
+			allowAutoterminate:    true,
+			allowIdempotencyToken: true,
+		})
+		s["spark_conf"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			return sparkConfDiffSuppressFunc(k, old, new, d) ||
+				clusterModeDiffSuppressFunc("spark_conf", clusterModeSparkConf)(k, old, new, d)
+		}
+		s["custom_tags"].DiffSuppressFunc = clusterModeDiffSuppressFunc("custom_tags", clusterModeCustomTags)
+		s["spark_version"].DiffSuppressFunc = sparkVersionDiffSuppressFunc
+		s["cluster_mode"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				ClusterModeStandard,
+				ClusterModeHighConcurrency,
+				ClusterModeSingleNode,
+			}, false),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// clusters, that have the mode set through spark_conf, are the same
+				sparkConf, _ := d.GetChange("spark_conf")
+				return old == "" && new == clusterModeFromSparkConf(sparkConf.(map[string]interface{}))
+			},
+		}
+		s["strict_spark_version"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return old == "" && new == "false"
+			},
+		}
+		s["skip_version_validation"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return old == "" && new == "false"
+			},
+		}
+		s["require_docker_image_digest"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return old == "" && new == "false"
+			},
 		}
-		s["autotermination_minutes"].Default = 60
 		s["cluster_id"] = &schema.Schema{
 			Type:     schema.TypeString,
 			Optional: true,
@@ -96,44 +713,311 @@ func resourceClusterSchema() map[string]*schema.Schema {
 			Type:     schema.TypeMap,
 			Computed: true,
 		}
-		s["num_workers"] = &schema.Schema{
-			Type:             schema.TypeInt,
-			Optional:         true,
-			Default:          0,
-			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
-		}
 		s["url"] = &schema.Schema{
 			Type:     schema.TypeString,
 			Computed: true,
 		}
+		s["driver_private_ip"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		s["driver_public_dns"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		s["clone_from_cluster_id"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		}
+		s["cloned_attributes"] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+		// spark_version is copied from the source cluster, when it's cloned
+		s["spark_version"].Required = false
+		s["spark_version"].Optional = true
+		s["spark_version"].Default = ""
+		s["spark_version"].AtLeastOneOf = []string{"spark_version", "clone_from_cluster_id"}
+		s["node_type_id"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			// node type of pool-backed clusters is determined by the pool
+			return new == "" && d.Get("instance_pool_id").(string) != ""
+		}
+		customizeGcpZoneIDSchema(s)
+		for k, v := range s {
+			if !v.Optional || nonClusterConfigFields[k] {
+				continue
+			}
+			suppress := v.DiffSuppressFunc
+			defaultValue := v.Default
+			v.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+				if clonedClusterDiffSuppressFunc(k, old, new, defaultValue, d) {
+					return true
+				}
+				return suppress != nil && suppress(k, old, new, d)
+			}
+		}
 		return s
 	})
 }
 
+// clonedClusterDiffSuppressFunc ignores removal of attributes, that were copied from
+// the source cluster and are not configured explicitly, so that cloned cluster
+// is compared only with configuration and the snapshot of the source in the state.
+// Only attributes recorded in cloned_attributes during create are suppressed.
+func clonedClusterDiffSuppressFunc(k, old, new string, defaultValue interface{}, d *schema.ResourceData) bool {
+	cloned, ok := d.Get("cloned_attributes").(*schema.Set)
+	if !ok || !cloned.Contains(strings.Split(k, ".")[0]) {
+		return false
+	}
+	if defaultValue != nil && !strings.Contains(k, ".") && fmt.Sprint(defaultValue) == new {
+		log.Printf("[DEBUG] Suppressing default for cloned k=%#v old=%#v new=%#v", k, old, new)
+		return true
+	}
+	if strings.HasSuffix(k, ".%") || strings.HasSuffix(k, ".#") {
+		oldCount, _ := strconv.Atoi(old)
+		newCount, _ := strconv.Atoi(new)
+		return newCount < oldCount
+	}
+	switch new {
+	case "", "0", "false":
+		if old != new {
+			log.Printf("[DEBUG] Suppressing diff for cloned k=%#v old=%#v new=%#v", k, old, new)
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// ClusterModeStandard is the mode of clusters without a workload profile
+	ClusterModeStandard = "STANDARD"
+	// ClusterModeHighConcurrency is the mode of clusters shared by multiple users
+	ClusterModeHighConcurrency = "HIGH_CONCURRENCY"
+	// ClusterModeSingleNode is the mode of clusters without workers
+	ClusterModeSingleNode = "SINGLE_NODE"
+)
+
+// clusterModeSparkConf is spark_conf, that is implied by cluster_mode
+var clusterModeSparkConf = map[string]map[string]string{
+	ClusterModeHighConcurrency: {
+		ClusterProfileConfKey: ClusterProfileServerless,
+	},
+	ClusterModeSingleNode: {
+		ClusterProfileConfKey: ClusterProfileSingleNode,
+		"spark.master":        "local[*]",
+	},
+}
+
+// clusterModeCustomTags is custom_tags, that are implied by cluster_mode
+var clusterModeCustomTags = map[string]map[string]string{
+	ClusterModeHighConcurrency: {"ResourceClass": "Serverless"},
+	ClusterModeSingleNode:      {"ResourceClass": "SingleNode"},
+}
+
+// clusterModeFromSparkConf returns cluster_mode, that is equivalent to the workload profile
+func clusterModeFromSparkConf(sparkConf map[string]interface{}) string {
+	switch sparkConf[ClusterProfileConfKey] {
+	case ClusterProfileSingleNode:
+		return ClusterModeSingleNode
+	case ClusterProfileServerless:
+		return ClusterModeHighConcurrency
+	case nil, "":
+		return ClusterModeStandard
+	}
+	return ""
+}
+
+// clusterModeDiffSuppressFunc ignores entries of the map attribute, that are returned by
+// the API, but are not configured explicitly, because they are implied by cluster_mode
+func clusterModeDiffSuppressFunc(attr string, implied map[string]map[string]string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		entries := implied[d.Get("cluster_mode").(string)]
+		if len(entries) == 0 {
+			return false
+		}
+		if k == attr+".%" {
+			// removal of other entries is still visible through their own diffs
+			oldCount, _ := strconv.Atoi(old)
+			newCount, _ := strconv.Atoi(new)
+			removed := oldCount - newCount
+			return removed > 0 && removed <= len(entries)
+		}
+		value, ok := entries[strings.TrimPrefix(k, attr+".")]
+		if ok && new == "" && old == value {
+			log.Printf("[DEBUG] Suppressing diff for k=%#v old=%#v new=%#v", k, old, new)
+			return true
+		}
+		return false
+	}
+}
+
+// applyClusterMode adds spark_conf and custom_tags, that are required by cluster_mode
+func (cluster *Cluster) applyClusterMode(mode string) error {
+	if mode == "" {
+		return nil
+	}
+	if mode == ClusterModeSingleNode && (cluster.NumWorkers > 0 || cluster.Autoscale != nil) {
+		return fmt.Errorf("cluster_mode %s cannot have num_workers or autoscale", mode)
+	}
+	if mode == ClusterModeStandard && cluster.Profile() != "" {
+		return fmt.Errorf("spark_conf.%s = %s conflicts with cluster_mode %s",
+			ClusterProfileConfKey, cluster.Profile(), mode)
+	}
+	apply := func(attr string, m map[string]string, implied map[string]string) (map[string]string, error) {
+		if len(implied) > 0 && m == nil {
+			m = map[string]string{}
+		}
+		for k, v := range implied {
+			if current, ok := m[k]; ok && current != v {
+				return nil, fmt.Errorf("%s.%s = %s conflicts with cluster_mode %s", attr, k, current, mode)
+			}
+			m[k] = v
+		}
+		return m, nil
+	}
+	sparkConf, err := apply("spark_conf", cluster.SparkConf, clusterModeSparkConf[mode])
+	if err != nil {
+		return err
+	}
+	customTags, err := apply("custom_tags", cluster.CustomTags, clusterModeCustomTags[mode])
+	if err != nil {
+		return err
+	}
+	cluster.SparkConf = sparkConf
+	cluster.CustomTags = customTags
+	return nil
+}
+
 func validateClusterDefinition(cluster Cluster) error {
 	// TODO: rewrite with CustomizeDiff
+	if cluster.isEnhancedSecurityMonitoringEnabled() && cluster.SingleUserName != "" {
+		return fmt.Errorf("single_user_name cannot be specified when enhanced_security_monitoring is enabled")
+	}
+	if cluster.DockerImage != nil {
+		if err := cluster.DockerImage.BasicAuth.validate(); err != nil {
+			return err
+		}
+	}
 	if cluster.NumWorkers > 0 || cluster.Autoscale != nil {
 		return nil
 	}
-	profile := cluster.SparkConf["spark.databricks.cluster.profile"]
 	master := cluster.SparkConf["spark.master"]
 	resourceClass := cluster.CustomTags["ResourceClass"]
-	if profile == "singleNode" && strings.HasPrefix(master, "local") && resourceClass == "SingleNode" {
+	if cluster.IsSingleNode() && strings.HasPrefix(master, "local") && resourceClass == "SingleNode" {
 		return nil
 	}
 	return fmt.Errorf("NumWorkers could be 0 only for SingleNode clusters. See https://docs.databricks.com/clusters/single-node.html for more details")
 }
 
+// isClusterAttributeConfigured returns true, if the attribute is set in configuration
+// to something else than its default value
+func isClusterAttributeConfigured(d *schema.ResourceData, k string) bool {
+	v, ok := d.GetOk(k)
+	if !ok {
+		return false
+	}
+	if s := clusterSchema[k]; s != nil && s.Default != nil {
+		return fmt.Sprint(s.Default) != fmt.Sprint(v)
+	}
+	return true
+}
+
+// cloneCluster converts the source cluster into a definition of the new cluster and
+// overlays it with explicitly configured attributes. Server-side fields, like state or
+// default_tags, are not part of the cluster definition and are dropped. Names of attributes,
+// that are copied from the source and not configured, are returned as well.
+func cloneCluster(source ClusterInfo, configured Cluster,
+	isConfigured func(string) bool) (cluster Cluster, cloned []string, err error) {
+	raw, err := json.Marshal(source)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(raw, &cluster)
+	if err != nil {
+		return
+	}
+	cluster.ClusterID = ""
+	clusterValue := reflect.ValueOf(&cluster).Elem()
+	configuredValue := reflect.ValueOf(configured)
+	clusterType := clusterValue.Type()
+	for i := 0; i < clusterType.NumField(); i++ {
+		field := clusterType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || !isConfigured(name) {
+			continue
+		}
+		clusterValue.Field(i).Set(configuredValue.Field(i))
+	}
+	// cluster size is either fixed or autoscaled
+	if isConfigured("autoscale") {
+		cluster.NumWorkers = 0
+	} else if isConfigured("num_workers") {
+		cluster.Autoscale = nil
+	}
+	// nodes come either from instance pools or have explicit node types
+	if isConfigured("node_type_id") {
+		cluster.InstancePoolID = ""
+		cluster.DriverInstancePoolID = ""
+	} else if cluster.InstancePoolID != "" {
+		cluster.NodeTypeID = ""
+		cluster.DriverNodeTypeID = ""
+	}
+	for i := 0; i < clusterType.NumField(); i++ {
+		name := strings.Split(clusterType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || isConfigured(name) || clusterValue.Field(i).IsZero() {
+			continue
+		}
+		cloned = append(cloned, name)
+	}
+	return
+}
+
 func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+	started := time.Now()
 	var cluster Cluster
 	clusters := NewClustersAPI(ctx, c)
 	err := common.DataToStructPointer(d, clusterSchema, &cluster)
 	if err != nil {
 		return err
 	}
+	if sourceID := d.Get("clone_from_cluster_id").(string); sourceID != "" {
+		source, err := clusters.Get(sourceID)
+		if err != nil {
+			return err
+		}
+		var cloned []string
+		cluster, cloned, err = cloneCluster(source, cluster, func(k string) bool {
+			return isClusterAttributeConfigured(d, k)
+		})
+		if err != nil {
+			return err
+		}
+		d.Set("cloned_attributes", cloned)
+	}
+	if cluster.SparkVersion == "" {
+		return fmt.Errorf("spark_version is required, unless clone_from_cluster_id is set")
+	}
+	if err = cluster.applyClusterMode(d.Get("cluster_mode").(string)); err != nil {
+		return err
+	}
 	if err = validateClusterDefinition(cluster); err != nil {
 		return err
 	}
+	if err = validateClusterGcpZone(clusters, cluster); err != nil {
+		return err
+	}
+	if err = validateClusterInstancePools(clusters, cluster); err != nil {
+		return err
+	}
+	if err = validateClusterSecretReferences(clusters, cluster); err != nil {
+		return err
+	}
+	if err = validateClusterEnhancedSecurityMonitoring(clusters, cluster); err != nil {
+		return err
+	}
+	readExplicitDiskSettings(d, &cluster, c.IsAzure())
 	modifyClusterRequest(&cluster)
 	clusterInfo, err := clusters.Create(cluster)
 	if err != nil {
@@ -153,14 +1037,16 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 		return err
 	}
 	librariesAPI := NewLibrariesAPI(ctx, c)
-	if len(libraryList.Libraries) > 0 {
-		if err = librariesAPI.Install(libraryList); err != nil {
+	if managed := libraryList.managed(); len(managed.Libraries) > 0 {
+		managed.ClusterID = clusterInfo.ClusterID
+		if err = librariesAPI.Install(managed); err != nil {
 			return err
 		}
 		if _, err := waitForLibrariesInstalled(librariesAPI, clusterInfo); err != nil {
 			return err
 		}
 	}
+	logProgress(c, clusterInfo.ClusterID, "created in %s", time.Since(started).Round(time.Second))
 	return nil
 }
 
@@ -182,12 +1068,34 @@ func setPinnedStatus(d *schema.ResourceData, clusterAPI ClustersAPI) error {
 	return d.Set("is_pinned", pinnedEvent == EvTypePinned)
 }
 
+// readNodeTypeFromPool fills node_type_id of clusters, that get their nodes from
+// the instance pool, but don't have node type returned by the API
+func readNodeTypeFromPool(ctx context.Context, c *common.DatabricksClient, clusterInfo *ClusterInfo) error {
+	if clusterInfo.InstancePoolID == "" || clusterInfo.NodeTypeID != "" {
+		return nil
+	}
+	pool, err := NewInstancePoolsAPI(ctx, c).Read(clusterInfo.InstancePoolID)
+	if common.IsMissing(err) {
+		log.Printf("[WARN] Instance pool %s of cluster %s is not found",
+			clusterInfo.InstancePoolID, clusterInfo.ClusterID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	clusterInfo.NodeTypeID = pool.NodeTypeID
+	return nil
+}
+
 func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 	clusterAPI := NewClustersAPI(ctx, c)
 	clusterInfo, err := clusterAPI.Get(d.Id())
 	if err != nil {
 		return err
 	}
+	if err = readNodeTypeFromPool(ctx, c, &clusterInfo); err != nil {
+		return err
+	}
 	if err = common.StructToData(clusterInfo, clusterSchema, d); err != nil {
 		return err
 	}
@@ -195,12 +1103,26 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 		return err
 	}
 	d.Set("url", c.FormatURL("#setting/clusters/", d.Id(), "/configuration"))
+	d.Set("effective_zone_id", clusterInfo.effectiveZoneID())
+	// driver addresses are known only while the cluster is running
+	driverIP, _ := clusterInfo.GetDriverIP()
+	d.Set("driver_private_ip", driverIP)
+	driverDNS, _ := clusterInfo.GetDriverPublicDNS()
+	d.Set("driver_public_dns", driverDNS)
+	var configured ClusterLibraryList
+	if err = common.DataToStructPointer(d, clusterSchema, &configured); err != nil {
+		return err
+	}
 	librariesAPI := NewLibrariesAPI(ctx, c)
 	libsClusterStatus, err := waitForLibrariesInstalled(librariesAPI, clusterInfo)
 	if err != nil {
 		return err
 	}
+	if err = libsClusterStatus.verify(configured, clusterInfo.IsRunningOrResizing()); err != nil {
+		return err
+	}
 	libList := libsClusterStatus.ToLibraryList()
+	libList.markVerifyOnly(configured.verifyOnlyKeys())
 	return common.StructToData(libList, clusterSchema, d)
 }
 
@@ -221,6 +1143,9 @@ func waitForLibrariesInstalled(
 			result = &libsClusterStatus
 			return nil
 		}
+		installed, total := libsClusterStatus.installedCount()
+		logProgress(libraries.client, clusterInfo.ClusterID, "%d of %d libraries INSTALLED",
+			installed, total)
 		retry, err := libsClusterStatus.IsRetryNeeded()
 		if retry {
 			return resource.RetryableError(err)
@@ -234,10 +1159,39 @@ func waitForLibrariesInstalled(
 	return
 }
 
+// nonClusterConfigFields are handled by the provider and are not part of cluster definition
+var nonClusterConfigFields = map[string]bool{
+	"library":                     true,
+	"is_pinned":                   true,
+	"require_docker_image_digest": true,
+	"strict_spark_version":        true,
+	"skip_version_validation":     true,
+	"clone_from_cluster_id":       true,
+	"cloned_attributes":           true,
+}
+
+// clusterSizeFields are changed through resize API, that doesn't restart running cluster
+var clusterSizeFields = map[string]bool{
+	"num_workers": true,
+	"autoscale":   true,
+}
+
+// hasOnlyClusterSizeChanged returns true if only the number of workers has changed
+func hasOnlyClusterSizeChanged(d *schema.ResourceData) bool {
+	for k := range clusterSchema {
+		if nonClusterConfigFields[k] || clusterSizeFields[k] {
+			continue
+		}
+		if d.HasChange(k) {
+			return false
+		}
+	}
+	return d.HasChanges("num_workers", "autoscale")
+}
+
 func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	for k := range clusterSchema {
-		// TODO: create a map if we'll add more non-cluster config parameters in the future
-		if k == "library" || k == "is_pinned" {
+		if nonClusterConfigFields[k] {
 			continue
 		}
 		if d.HasChange(k) {
@@ -247,6 +1201,142 @@ func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	return false
 }
 
+// ChangeImpact describes how a change of cluster configuration affects the cluster
+type ChangeImpact string
+
+const (
+	// ChangeImpactNone means that cluster configuration did not change
+	ChangeImpactNone ChangeImpact = "none"
+	// ChangeImpactEdit means that change is applied without cluster restart
+	ChangeImpactEdit ChangeImpact = "edit"
+	// ChangeImpactRestart means that running cluster is restarted to apply the change
+	ChangeImpactRestart ChangeImpact = "restart"
+	// ChangeImpactRecreate means that cluster is deleted and created again
+	ChangeImpactRecreate ChangeImpact = "recreate"
+)
+
+// clusterFieldsWithoutRestart are applied to running cluster without restarting it
+var clusterFieldsWithoutRestart = map[string]bool{
+	"cluster_name": true,
+	"custom_tags":  true,
+	"num_workers":  true,
+	"autoscale":    true,
+}
+
+// changedFields returns JSON names of struct fields, that differ between old and new values
+// and are present in the schema. Missing pointers are compared as zero structs.
+func changedFields(s map[string]*schema.Schema, old, new reflect.Value) (changed []string) {
+	if old.Kind() == reflect.Ptr || new.Kind() == reflect.Ptr {
+		t := old.Type().Elem()
+		if old.IsNil() {
+			old = reflect.New(t)
+		}
+		if new.IsNil() {
+			new = reflect.New(t)
+		}
+		old, new = old.Elem(), new.Elem()
+	}
+	for i := 0; i < old.NumField(); i++ {
+		name := strings.Split(old.Type().Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := s[name]; !ok {
+			continue
+		}
+		if reflect.DeepEqual(old.Field(i).Interface(), new.Field(i).Interface()) {
+			continue
+		}
+		changed = append(changed, name)
+	}
+	return
+}
+
+// requiresNew returns true if any changed field or nested field is force_new
+func requiresNew(s map[string]*schema.Schema, old, new reflect.Value) bool {
+	for _, name := range changedFields(s, old, new) {
+		if s[name].ForceNew {
+			return true
+		}
+		nested, ok := s[name].Elem.(*schema.Resource)
+		if !ok {
+			continue
+		}
+		field, _ := fieldByJSONName(old, name)
+		if field.Kind() != reflect.Ptr {
+			continue
+		}
+		newField, _ := fieldByJSONName(new, name)
+		if requiresNew(nested.Schema, field, newField) {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.New(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0] == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// ChangeImpact returns how the change from old to new configuration affects the cluster,
+// based on which fields changed and whether they are force_new
+func (old Cluster) ChangeImpact(new Cluster) ChangeImpact {
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	changed := changedFields(clusterSchema, oldValue, newValue)
+	if len(changed) == 0 {
+		return ChangeImpactNone
+	}
+	if requiresNew(clusterSchema, oldValue, newValue) {
+		return ChangeImpactRecreate
+	}
+	for _, name := range changed {
+		if !clusterFieldsWithoutRestart[name] {
+			return ChangeImpactRestart
+		}
+	}
+	return ChangeImpactEdit
+}
+
+// clusterChangeImpact returns how the planned change of the cluster affects it,
+// by comparing configuration from the state with the planned one
+func clusterChangeImpact(d *schema.ResourceData) (ChangeImpact, error) {
+	previous := (&schema.Resource{Schema: clusterSchema}).Data(nil)
+	for k := range clusterSchema {
+		old, _ := d.GetChange(k)
+		if err := previous.Set(k, old); err != nil {
+			return ChangeImpactNone, err
+		}
+	}
+	var old, new Cluster
+	if err := common.DataToStructPointer(previous, clusterSchema, &old); err != nil {
+		return ChangeImpactNone, err
+	}
+	if err := common.DataToStructPointer(d, clusterSchema, &new); err != nil {
+		return ChangeImpactNone, err
+	}
+	return old.ChangeImpact(new), nil
+}
+
+// previousLibraryList returns libraries from the state before the update, so that
+// libraries, that were only verified, are not uninstalled when removed from config
+func previousLibraryList(d *schema.ResourceData) (libraries ClusterLibraryList, err error) {
+	previous := (&schema.Resource{Schema: clusterSchema}).Data(nil)
+	old, _ := d.GetChange("library")
+	if err = previous.Set("library", old); err != nil {
+		return
+	}
+	err = common.DataToStructPointer(previous, clusterSchema, &libraries)
+	return
+}
+
 // https://github.com/databrickslabs/terraform-provider-databricks/issues/824
 func fixInstancePoolChangeIfAny(d *schema.ResourceData, cluster *Cluster) {
 	oldInstancePool, newInstancePool := d.GetChange("instance_pool_id")
@@ -259,6 +1349,7 @@ func fixInstancePoolChangeIfAny(d *schema.ResourceData, cluster *Cluster) {
 }
 
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+	started := time.Now()
 	clusters := NewClustersAPI(ctx, c)
 	clusterID := d.Id()
 	cluster := Cluster{ClusterID: clusterID}
@@ -269,13 +1360,53 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	var clusterInfo ClusterInfo
 	if hasClusterConfigChanged(d) {
 		log.Printf("[DEBUG] Cluster state has changed!")
+		var impact ChangeImpact
+		impact, err = clusterChangeImpact(d)
+		if err != nil {
+			return err
+		}
+		logProgress(c, clusterID, "applying configuration change with %s impact", impact)
+		if err = cluster.applyClusterMode(d.Get("cluster_mode").(string)); err != nil {
+			return err
+		}
 		err = validateClusterDefinition(cluster)
 		if err != nil {
 			return err
 		}
+		if err = validateClusterGcpZone(clusters, cluster); err != nil {
+			return err
+		}
+		if d.HasChanges("instance_pool_id", "driver_instance_pool_id", "spark_version") {
+			if err = validateClusterInstancePools(clusters, cluster); err != nil {
+				return err
+			}
+		}
+		if d.HasChange("spark_env_vars") {
+			if err = validateClusterSecretReferences(clusters, cluster); err != nil {
+				return err
+			}
+		}
+		if err = validateClusterEnhancedSecurityMonitoring(clusters, cluster); err != nil {
+			return err
+		}
+		readExplicitDiskSettings(d, &cluster, c.IsAzure())
 		modifyClusterRequest(&cluster)
 		fixInstancePoolChangeIfAny(d, &cluster)
-		clusterInfo, err = clusters.Edit(cluster)
+		if hasOnlyClusterSizeChanged(d) {
+			clusterInfo, err = clusters.Get(clusterID)
+			if err != nil {
+				return err
+			}
+		}
+		if clusterInfo.IsRunningOrResizing() {
+			clusterInfo, err = clusters.Resize(ResizeRequest{
+				ClusterID:  clusterID,
+				NumWorkers: cluster.NumWorkers,
+				Autoscale:  cluster.Autoscale,
+			})
+		} else {
+			clusterInfo, err = clusters.Edit(cluster)
+		}
 		if err != nil {
 			return err
 		}
@@ -307,8 +1438,12 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err != nil {
 		return err
 	}
+	previousLibraries, err := previousLibraryList(d)
+	if err != nil {
+		return err
+	}
 	libraryList.ClusterID = clusterID
-	libsToInstall, libsToUninstall := libraryList.Diff(libsClusterStatus)
+	libsToInstall, libsToUninstall := libraryList.Diff(libsClusterStatus, previousLibraries)
 	if len(libsToUninstall.Libraries) > 0 || len(libsToInstall.Libraries) > 0 {
 		tmpClusterInfo := clusterInfo
 		if !clusterInfo.IsRunningOrResizing() {
@@ -327,9 +1462,28 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 			}
 		}
 	}
+	logProgress(c, clusterID, "updated in %s", time.Since(started).Round(time.Second))
 	return nil
 }
 
+// explicitDiskSettings are computed by the API, so false has to be sent explicitly
+// for the drift to be detected, when the API does not honor it
+var explicitDiskSettings = []string{"enable_elastic_disk", "enable_local_disk_encryption"}
+
+func readExplicitDiskSettings(d *schema.ResourceData, cluster *Cluster, isAzure bool) {
+	for _, key := range explicitDiskSettings {
+		v, exists := d.GetOkExists(key)
+		if !exists || v.(bool) {
+			continue
+		}
+		if key == "enable_elastic_disk" && isAzure {
+			log.Printf("[WARN] enable_elastic_disk is always enabled on Azure, " +
+				"so setting it to false has no effect")
+		}
+		cluster.disabledDiskSettings = append(cluster.disabledDiskSettings, key)
+	}
+}
+
 // modifyClusterRequest helps remove all request fields that should not be submitted when instance pool is selected.
 func modifyClusterRequest(clusterModel *Cluster) {
 	// Instance profile id does not exist or not set
@@ -353,6 +1507,14 @@ func modifyClusterRequest(clusterModel *Cluster) {
 		clusterModel.GcpAttributes = &gcpAttributes
 	}
 	clusterModel.EnableElasticDisk = false
+	// elastic disk is configured by the instance pool
+	disabledDiskSettings := []string{}
+	for _, key := range clusterModel.disabledDiskSettings {
+		if key != "enable_elastic_disk" {
+			disabledDiskSettings = append(disabledDiskSettings, key)
+		}
+	}
+	clusterModel.disabledDiskSettings = disabledDiskSettings
 	clusterModel.NodeTypeID = ""
 	clusterModel.DriverNodeTypeID = ""
 }
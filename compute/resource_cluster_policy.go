@@ -2,9 +2,17 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -21,6 +29,281 @@ type ClusterPoliciesAPI struct {
 	context context.Context
 }
 
+// policyRule is a single constraint of cluster policy definition
+type policyRule struct {
+	Type         string        `json:"type"`
+	Value        interface{}   `json:"value,omitempty"`
+	Values       []interface{} `json:"values,omitempty"`
+	Pattern      string        `json:"pattern,omitempty"`
+	MinValue     *float64      `json:"minValue,omitempty"`
+	MaxValue     *float64      `json:"maxValue,omitempty"`
+	DefaultValue interface{}   `json:"defaultValue,omitempty"`
+}
+
+// violation returns description of why value doesn't conform to the rule or empty string
+func (rule policyRule) violation(value interface{}) string {
+	switch rule.Type {
+	case "fixed":
+		if fmt.Sprint(value) != fmt.Sprint(rule.Value) {
+			return fmt.Sprintf("must be %v", rule.Value)
+		}
+	case "forbidden":
+		return "is forbidden"
+	case "allowlist":
+		if !rule.hasValue(value) {
+			return fmt.Sprintf("must be one of %v", rule.Values)
+		}
+	case "blocklist":
+		if rule.hasValue(value) {
+			return fmt.Sprintf("must not be one of %v", rule.Values)
+		}
+	case "regex":
+		matched, err := regexp.MatchString(rule.Pattern, fmt.Sprint(value))
+		if err == nil && !matched {
+			return fmt.Sprintf("must match %s", rule.Pattern)
+		}
+	case "range":
+		number, ok := value.(float64)
+		if !ok {
+			return ""
+		}
+		if rule.MinValue != nil && number < *rule.MinValue {
+			return fmt.Sprintf("must be at least %v", *rule.MinValue)
+		}
+		if rule.MaxValue != nil && number > *rule.MaxValue {
+			return fmt.Sprintf("must be at most %v", *rule.MaxValue)
+		}
+	}
+	return ""
+}
+
+func (rule policyRule) hasValue(value interface{}) bool {
+	for _, v := range rule.Values {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyPathRegex converts policy attribute path, like init_scripts.*.dbfs.destination,
+// to regular expression. Forbidden attributes also forbid all of their nested attributes.
+func policyPathRegex(key string, rule policyRule) *regexp.Regexp {
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(key), `\*`, `[^.]+`)
+	if rule.Type == "forbidden" {
+		return regexp.MustCompile(`^` + pattern + `(\..+)?$`)
+	}
+	return regexp.MustCompile(`^` + pattern + `$`)
+}
+
+// flattenPolicyAttributes converts JSON representation of cluster to the flat map
+// of attribute paths used in cluster policy definitions
+func flattenPolicyAttributes(prefix string, value interface{}, attributes map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, nested := range v {
+			flattenPolicyAttributes(prefix+k+".", nested, attributes)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			flattenPolicyAttributes(fmt.Sprintf("%s%d.", prefix, i), nested, attributes)
+		}
+	default:
+		attributes[strings.TrimSuffix(prefix, ".")] = v
+	}
+}
+
+// validateAgainstPolicy checks configured attributes of the cluster against the rules of
+// policy definition, so that violations are reported during plan and not on cluster start.
+func (cluster Cluster) validateAgainstPolicy(definition string) error {
+	rules := map[string]policyRule{}
+	err := json.Unmarshal([]byte(definition), &rules)
+	if err != nil {
+		return fmt.Errorf("cannot parse cluster policy definition: %w", err)
+	}
+	raw, err := json.Marshal(cluster)
+	if err != nil {
+		return err
+	}
+	var document interface{}
+	err = json.Unmarshal(raw, &document)
+	if err != nil {
+		return err
+	}
+	attributes := map[string]interface{}{}
+	flattenPolicyAttributes("", document, attributes)
+	if cluster.Autoscale != nil {
+		// num_workers is always serialized, but autoscaling clusters are sized by autoscale block
+		delete(attributes, "num_workers")
+	}
+	violations := []string{}
+	for key, rule := range rules {
+		re := policyPathRegex(key, rule)
+		for attribute, value := range attributes {
+			if !re.MatchString(attribute) {
+				continue
+			}
+			if rule.Type == "range" && strings.HasPrefix(attribute, "autoscale.") {
+				// checked by autoscaleDiagnostics
+				continue
+			}
+			if msg := rule.violation(value); msg != "" {
+				violations = append(violations, fmt.Sprintf("%s %s", attribute, msg))
+			}
+		}
+	}
+	for _, d := range cluster.autoscaleDiagnostics(rules) {
+		violations = append(violations, d.Summary)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return fmt.Errorf("cluster does not conform to policy %s: %s",
+		cluster.PolicyID, strings.Join(violations, "; "))
+}
+
+// autoscaleDiagnostics checks autoscale bounds of the cluster against range rules of the policy.
+// Unlike other attributes, zero min_workers is omitted from JSON, so bounds are checked explicitly.
+func (cluster Cluster) autoscaleDiagnostics(rules map[string]policyRule) (diags diag.Diagnostics) {
+	if cluster.Autoscale == nil {
+		return nil
+	}
+	bounds := []struct {
+		field string
+		value int32
+	}{
+		{"min_workers", cluster.Autoscale.MinWorkers},
+		{"max_workers", cluster.Autoscale.MaxWorkers},
+	}
+	for _, bound := range bounds {
+		rule, ok := rules["autoscale."+bound.field]
+		if !ok || rule.Type != "range" {
+			continue
+		}
+		if msg := rule.violation(float64(bound.value)); msg != "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("autoscale.%s %s", bound.field, msg),
+				AttributePath: cty.GetAttrPath("autoscale").IndexInt(0).GetAttr(bound.field),
+			})
+		}
+	}
+	return diags
+}
+
+// defaultNumber returns the number, that the rule fixes or uses by default
+func (rule policyRule) defaultNumber() (int32, bool) {
+	value := rule.DefaultValue
+	if rule.Type == "fixed" {
+		value = rule.Value
+	}
+	number, ok := value.(float64)
+	if !ok {
+		return 0, false
+	}
+	return int32(number), true
+}
+
+// EffectiveAutoscale returns the autoscale range, that the cluster gets under the policy.
+// Bounds, that are not configured on the cluster, are taken from fixed or default values
+// of the policy. ok is false for fixed size clusters or if there's no complete range.
+func (cluster Cluster) EffectiveAutoscale(policyDef string) (min, max int32, ok bool) {
+	if cluster.Autoscale == nil && cluster.NumWorkers > 0 {
+		return 0, 0, false
+	}
+	rules := map[string]policyRule{}
+	if policyDef != "" {
+		if err := json.Unmarshal([]byte(policyDef), &rules); err != nil {
+			return 0, 0, false
+		}
+	}
+	bound := func(field string, configured int32) (int32, bool) {
+		if cluster.Autoscale != nil && configured > 0 {
+			return configured, true
+		}
+		if rule, ok := rules["autoscale."+field]; ok {
+			if number, ok := rule.defaultNumber(); ok {
+				return number, true
+			}
+		}
+		// zero min_workers is omitted from configuration, but is valid
+		return 0, cluster.Autoscale != nil && field == "min_workers"
+	}
+	var configuredMin, configuredMax int32
+	if cluster.Autoscale != nil {
+		configuredMin, configuredMax = cluster.Autoscale.MinWorkers, cluster.Autoscale.MaxWorkers
+	}
+	min, minOk := bound("min_workers", configuredMin)
+	max, maxOk := bound("max_workers", configuredMax)
+	if !minOk || !maxOk || max == 0 {
+		return 0, 0, false
+	}
+	return min, max, true
+}
+
+// ToPolicyDefinition returns JSON policy definition, that fixes configured attributes
+// of the cluster. It's used to bootstrap a policy from a known-good cluster.
+func (cluster Cluster) ToPolicyDefinition() string {
+	definition := map[string]policyRule{}
+	fix := func(key string, value interface{}) {
+		definition[key] = policyRule{Type: "fixed", Value: value}
+	}
+	fixString := func(key, value string) {
+		if value != "" {
+			fix(key, value)
+		}
+	}
+	fixString("spark_version", cluster.SparkVersion)
+	fixString("node_type_id", cluster.NodeTypeID)
+	fixString("driver_node_type_id", cluster.DriverNodeTypeID)
+	fixString("instance_pool_id", cluster.InstancePoolID)
+	fixString("driver_instance_pool_id", cluster.DriverInstancePoolID)
+	if cluster.Autoscale != nil {
+		fix("autoscale.min_workers", cluster.Autoscale.MinWorkers)
+		fix("autoscale.max_workers", cluster.Autoscale.MaxWorkers)
+	} else {
+		fix("num_workers", cluster.NumWorkers)
+	}
+	if cluster.AwsAttributes != nil {
+		fixString("aws_attributes.availability", string(cluster.AwsAttributes.Availability))
+	}
+	if cluster.AzureAttributes != nil {
+		fixString("azure_attributes.availability", string(cluster.AzureAttributes.Availability))
+	}
+	if cluster.GcpAttributes != nil && cluster.GcpAttributes.UsePreemptibleExecutors {
+		fix("gcp_attributes.use_preemptible_executors", true)
+	}
+	for k, v := range cluster.CustomTags {
+		fix("custom_tags."+k, v)
+	}
+	// map of strings to structs with primitive values always marshals
+	raw, _ := json.Marshal(definition)
+	return string(raw)
+}
+
+// AllowlistPolicyDefinition returns JSON policy definition with a single allowlist rule for
+// the attribute path. Values are deduplicated and sorted, so that definition is stable
+// regardless of the order of values, that usually come from data sources.
+func AllowlistPolicyDefinition(path string, values []string) string {
+	unique := map[string]bool{}
+	for _, v := range values {
+		unique[v] = true
+	}
+	allowed := []string{}
+	for v := range unique {
+		allowed = append(allowed, v)
+	}
+	sort.Strings(allowed)
+	rule := policyRule{Type: "allowlist"}
+	for _, v := range allowed {
+		rule.Values = append(rule.Values, v)
+	}
+	// map of strings to structs with primitive values always marshals
+	raw, _ := json.Marshal(map[string]policyRule{path: rule})
+	return string(raw)
+}
+
 type policyIDWrapper struct {
 	PolicyID string `json:"policy_id,omitempty" url:"policy_id,omitempty"`
 }
@@ -55,6 +338,68 @@ func (a ClusterPoliciesAPI) Delete(policyID string) error {
 	return a.client.Post(a.context, "/policies/clusters/delete", policyIDWrapper{policyID}, nil)
 }
 
+// GetPolicyFamily returns policy family
+func (a ClusterPoliciesAPI) GetPolicyFamily(policyFamilyID string) (family PolicyFamily, err error) {
+	err = a.client.Get(a.context, "/policy-families/"+policyFamilyID, nil, &family)
+	return
+}
+
+// EffectiveDefinition returns definition of the policy, where family-based policies have
+// the rules of the policy family merged with their overrides, as the workspace does.
+func (a ClusterPoliciesAPI) EffectiveDefinition(policy ClusterPolicy) (string, error) {
+	if policy.PolicyFamilyID == "" {
+		return policy.Definition, nil
+	}
+	family, err := a.GetPolicyFamily(policy.PolicyFamilyID)
+	if err != nil {
+		return "", fmt.Errorf("cannot get policy family %s: %w", policy.PolicyFamilyID, err)
+	}
+	return mergePolicyDefinitions(family.Definition, policy.PolicyFamilyDefinitionOverrides)
+}
+
+// mergePolicyDefinitions returns definition, where rules of overrides replace the rules
+// of the base definition for the same attribute paths
+func mergePolicyDefinitions(base, overrides string) (string, error) {
+	rules := map[string]json.RawMessage{}
+	for _, definition := range []string{base, overrides} {
+		if definition == "" {
+			continue
+		}
+		err := json.Unmarshal([]byte(definition), &rules)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse cluster policy definition: %w", err)
+		}
+	}
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// validateClusterPolicy checks new cluster against its cluster policy. Policies, that are
+// created within the same apply, have unknown identifiers and cannot be verified in plan.
+func validateClusterPolicy(d *schema.ResourceDiff, key string, cluster Cluster,
+	policies ClusterPoliciesAPI) error {
+	if !d.NewValueKnown(key) {
+		log.Printf("[WARN] Cannot verify cluster policy compliance for %s, "+
+			"because policy_id is not known until apply", key)
+		return nil
+	}
+	if cluster.PolicyID == "" {
+		return nil
+	}
+	policy, err := policies.Get(cluster.PolicyID)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster policy %s: %w", cluster.PolicyID, err)
+	}
+	definition, err := policies.EffectiveDefinition(policy)
+	if err != nil {
+		return err
+	}
+	return cluster.validateAgainstPolicy(definition)
+}
+
 func parsePolicyFromData(d *schema.ResourceData) (*ClusterPolicy, error) {
 	clusterPolicy := new(ClusterPolicy)
 	clusterPolicy.PolicyID = d.Id()
@@ -1,12 +1,17 @@
 package compute
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceClusterPolicyRead(t *testing.T) {
@@ -236,3 +241,278 @@ func TestResourceClusterPolicyDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestClusterToPolicyDefinition_Autoscale(t *testing.T) {
+	cluster := Cluster{
+		ClusterName:  "golden",
+		SparkVersion: "7.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		Autoscale: &AutoScale{
+			MinWorkers: 1,
+			MaxWorkers: 10,
+		},
+		AwsAttributes: &AwsAttributes{
+			Availability: AwsAvailabilitySpot,
+		},
+		CustomTags: map[string]string{
+			"Team": "data",
+		},
+		AutoterminationMinutes: 20,
+	}
+	var definition map[string]map[string]interface{}
+	err := json.Unmarshal([]byte(cluster.ToPolicyDefinition()), &definition)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"spark_version":               {"type": "fixed", "value": "7.3.x-scala2.12"},
+		"node_type_id":                {"type": "fixed", "value": "i3.xlarge"},
+		"autoscale.min_workers":       {"type": "fixed", "value": 1.0},
+		"autoscale.max_workers":       {"type": "fixed", "value": 10.0},
+		"aws_attributes.availability": {"type": "fixed", "value": "SPOT"},
+		"custom_tags.Team":            {"type": "fixed", "value": "data"},
+	}, definition)
+}
+
+func TestClusterToPolicyDefinition_FixedSize(t *testing.T) {
+	cluster := Cluster{
+		SparkVersion:   "7.3.x-scala2.12",
+		InstancePoolID: "pool",
+		NumWorkers:     4,
+		AzureAttributes: &AzureAttributes{
+			Availability: AzureAvailabilityOnDemand,
+		},
+	}
+	assert.Equal(t, `{"azure_attributes.availability":{"type":"fixed","value":"ON_DEMAND_AZURE"},`+
+		`"instance_pool_id":{"type":"fixed","value":"pool"},`+
+		`"num_workers":{"type":"fixed","value":4},`+
+		`"spark_version":{"type":"fixed","value":"7.3.x-scala2.12"}}`, cluster.ToPolicyDefinition())
+}
+
+func TestClusterValidateAgainstPolicy(t *testing.T) {
+	definition := `{
+		"spark_version": {"type": "fixed", "value": "7.3.x-scala2.12"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+		"autoscale.max_workers": {"type": "range", "maxValue": 10},
+		"instance_pool_id": {"type": "forbidden"},
+		"init_scripts": {"type": "forbidden"},
+		"custom_tags.*": {"type": "regex", "pattern": "^[a-z]+$"},
+		"spark_conf.spark.databricks.cluster.profile": {"type": "blocklist", "values": ["serverless"]}
+	}`
+	cluster := Cluster{
+		PolicyID:     "abc",
+		SparkVersion: "7.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		Autoscale: &AutoScale{
+			MinWorkers: 1,
+			MaxWorkers: 10,
+		},
+		CustomTags: map[string]string{
+			"Team": "data",
+		},
+	}
+	assert.NoError(t, cluster.validateAgainstPolicy(definition))
+
+	cluster.SparkVersion = "8.3.x-scala2.12"
+	cluster.NodeTypeID = "r3.xlarge"
+	cluster.Autoscale.MaxWorkers = 20
+	cluster.InstancePoolID = "pool"
+	cluster.InitScripts = []InitScriptStorageInfo{
+		{
+			Dbfs: &DbfsStorageInfo{
+				Destination: "dbfs:/init.sh",
+			},
+		},
+	}
+	cluster.CustomTags["Team"] = "Data"
+	cluster.SparkConf = map[string]string{
+		"spark.databricks.cluster.profile": "serverless",
+	}
+	assert.EqualError(t, cluster.validateAgainstPolicy(definition),
+		"cluster does not conform to policy abc: "+
+			"autoscale.max_workers must be at most 10; "+
+			"custom_tags.Team must match ^[a-z]+$; "+
+			"init_scripts.0.dbfs.destination is forbidden; "+
+			"instance_pool_id is forbidden; "+
+			"node_type_id must be one of [i3.xlarge i3.2xlarge]; "+
+			"spark_conf.spark.databricks.cluster.profile must not be one of [serverless]; "+
+			"spark_version must be 7.3.x-scala2.12")
+}
+
+func TestClusterValidateAgainstPolicy_Autoscale(t *testing.T) {
+	definition := `{
+		"num_workers": {"type": "range", "minValue": 2, "maxValue": 10},
+		"autoscale.max_workers": {"type": "range", "maxValue": 10}
+	}`
+	cluster := Cluster{
+		PolicyID: "abc",
+		Autoscale: &AutoScale{
+			MinWorkers: 2,
+			MaxWorkers: 8,
+		},
+	}
+	assert.NoError(t, cluster.validateAgainstPolicy(definition))
+
+	cluster.Autoscale = nil
+	assert.EqualError(t, cluster.validateAgainstPolicy(definition),
+		"cluster does not conform to policy abc: num_workers must be at least 2")
+}
+
+func TestClusterValidateAgainstPolicy_InvalidDefinition(t *testing.T) {
+	err := Cluster{}.validateAgainstPolicy(`{`)
+	assert.EqualError(t, err, "cannot parse cluster policy definition: unexpected end of JSON input")
+}
+
+func TestMergePolicyDefinitions(t *testing.T) {
+	definition, err := mergePolicyDefinitions(
+		`{"node_type_id": {"type": "fixed", "value": "i3.xlarge"}, "autotermination_minutes": {"type": "fixed", "value": 60}}`,
+		`{"autotermination_minutes": {"type": "fixed", "value": 30}}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"node_type_id": {"type": "fixed", "value": "i3.xlarge"},
+		"autotermination_minutes": {"type": "fixed", "value": 30}
+	}`, definition)
+
+	_, err = mergePolicyDefinitions(`{}`, `[`)
+	assert.EqualError(t, err, "cannot parse cluster policy definition: unexpected end of JSON input")
+}
+
+// familyPolicyFixtures return policy, that inherits node_type_id from policy family
+var familyPolicyFixtures = []qa.HTTPFixture{
+	{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/policies/clusters/get?policy_id=fam",
+		Response: ClusterPolicy{
+			PolicyID:                        "fam",
+			PolicyFamilyID:                  "personal-vm",
+			PolicyFamilyDefinitionOverrides: `{"autotermination_minutes": {"type": "fixed", "value": 30}}`,
+		},
+	},
+	{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/policy-families/personal-vm",
+		Response: PolicyFamily{
+			PolicyFamilyID: "personal-vm",
+			Name:           "Personal Compute",
+			Definition:     `{"node_type_id": {"type": "fixed", "value": "i3.xlarge"}}`,
+		},
+	},
+}
+
+func TestClusterPoliciesAPIEffectiveDefinition(t *testing.T) {
+	qa.HTTPFixturesApply(t, familyPolicyFixtures, func(ctx context.Context, client *common.DatabricksClient) {
+		policiesAPI := NewClusterPoliciesAPI(ctx, client)
+		definition, err := policiesAPI.EffectiveDefinition(ClusterPolicy{Definition: `{}`})
+		require.NoError(t, err)
+		assert.Equal(t, `{}`, definition)
+
+		policy, err := policiesAPI.Get("fam")
+		require.NoError(t, err)
+		definition, err = policiesAPI.EffectiveDefinition(policy)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"node_type_id": {"type": "fixed", "value": "i3.xlarge"},
+			"autotermination_minutes": {"type": "fixed", "value": 30}
+		}`, definition)
+	})
+}
+
+func TestResourceClusterCreate_FamilyPolicyFixesNodeType(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{nodeTypesFixture, sparkVersionsFixture}, familyPolicyFixtures...),
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name  = "Personal"
+		spark_version = "7.1-scala12"
+		node_type_id  = "i3.2xlarge"
+		num_workers   = 1
+		autotermination_minutes = 30
+		policy_id     = "fam"`,
+	}.ExpectError(t, "cluster does not conform to policy fam: node_type_id must be i3.xlarge")
+}
+
+func TestResourceJobCreate_FamilyPolicyFixesNodeType(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: familyPolicyFixtures,
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		new_cluster {
+			policy_id = "fam"
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "i3.2xlarge"
+			num_workers = 1
+		}
+		notebook_task {
+			notebook_path = "/Featurizer"
+		}`,
+	}.ExpectError(t, "invalid job cluster: cluster does not conform to policy fam: "+
+		"node_type_id must be i3.xlarge")
+}
+
+func TestClusterAutoscaleDiagnostics(t *testing.T) {
+	rules := map[string]policyRule{}
+	err := json.Unmarshal([]byte(`{
+		"autoscale.min_workers": {"type": "range", "minValue": 1, "maxValue": 5},
+		"autoscale.max_workers": {"type": "range", "maxValue": 10}
+	}`), &rules)
+	require.NoError(t, err)
+
+	assert.Len(t, Cluster{}.autoscaleDiagnostics(rules), 0)
+	assert.Len(t, Cluster{
+		Autoscale: &AutoScale{
+			MinWorkers: 1,
+			MaxWorkers: 10,
+		},
+	}.autoscaleDiagnostics(rules), 0)
+
+	diags := Cluster{
+		Autoscale: &AutoScale{
+			MaxWorkers: 20,
+		},
+	}.autoscaleDiagnostics(rules)
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "autoscale.min_workers must be at least 1", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("autoscale").IndexInt(0).GetAttr("min_workers"), diags[0].AttributePath)
+	assert.Equal(t, "autoscale.max_workers must be at most 10", diags[1].Summary)
+
+	err = Cluster{
+		PolicyID: "abc",
+		Autoscale: &AutoScale{
+			MaxWorkers: 5,
+		},
+	}.validateAgainstPolicy(`{"autoscale.min_workers": {"type": "range", "minValue": 1}}`)
+	assert.EqualError(t, err, "cluster does not conform to policy abc: autoscale.min_workers must be at least 1")
+}
+
+func TestClusterEffectiveAutoscale(t *testing.T) {
+	policy := `{
+		"autoscale.min_workers": {"type": "range", "maxValue": 4, "defaultValue": 2},
+		"autoscale.max_workers": {"type": "fixed", "value": 8}
+	}`
+	tests := []struct {
+		name     string
+		cluster  Cluster
+		policy   string
+		min, max int32
+		ok       bool
+	}{
+		{"cluster specified", Cluster{Autoscale: &AutoScale{MinWorkers: 1, MaxWorkers: 5}}, policy, 1, 5, true},
+		{"cluster without policy", Cluster{Autoscale: &AutoScale{MaxWorkers: 5}}, "", 0, 5, true},
+		{"policy defaulted", Cluster{}, policy, 2, 8, true},
+		{"partially policy defaulted", Cluster{Autoscale: &AutoScale{MinWorkers: 3}}, policy, 3, 8, true},
+		{"neither", Cluster{}, `{"spark_version": {"type": "fixed", "value": "10.4.x-scala2.12"}}`, 0, 0, false},
+		{"fixed size", Cluster{NumWorkers: 3}, policy, 0, 0, false},
+		{"invalid policy", Cluster{}, `{`, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, ok := tt.cluster.EffectiveAutoscale(tt.policy)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.min, min)
+			assert.Equal(t, tt.max, max)
+		})
+	}
+}
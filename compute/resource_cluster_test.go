@@ -1,6 +1,8 @@
 package compute
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -8,13 +10,52 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// sparkVersionsFixture is used to verify spark_version of clusters during plan
+var sparkVersionsFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/spark-versions",
+	Response: SparkVersionsList{
+		SparkVersions: []SparkVersion{
+			{
+				Version:     "7.1-scala12",
+				Description: "7.1 (includes Apache Spark 3.0.0, Scala 2.12)",
+			},
+		},
+	},
+}
+
+// nodeTypesFixture is used to verify node_type_id and driver_node_type_id of clusters during plan
+var nodeTypesFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/list-node-types",
+	Response: NodeTypeList{
+		NodeTypes: []NodeType{
+			{NodeTypeID: "i3.xlarge"},
+			{NodeTypeID: "i3.2xlarge"},
+			{NodeTypeID: "r5d.large"},
+			{NodeTypeID: "m4.large", IsDeprecated: true},
+			{NodeTypeID: "n1-standard-4"},
+			{NodeTypeID: "Standard_F4s"},
+			{NodeTypeID: "Standard_DS3_v2"},
+		},
+	},
+}
+
 func TestResourceClusterCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/create",
@@ -81,9 +122,89 @@ func TestResourceClusterCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceClusterCreate_ApplyPolicyDefaultValues(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=def",
+				Response: ClusterPolicy{
+					PolicyID:   "def",
+					Definition: `{"autotermination_minutes": {"type": "fixed", "value": 60}}`,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:               2,
+					ClusterName:              "Policy defaults",
+					SparkVersion:             "7.1-scala12",
+					NodeTypeID:               "i3.xlarge",
+					PolicyID:                 "def",
+					ApplyPolicyDefaultValues: true,
+					AutoterminationMinutes:   60,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             2,
+					ClusterName:            "Policy defaults",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					PolicyID:               "def",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name  = "Policy defaults"
+		spark_version = "7.1-scala12"
+		node_type_id  = "i3.xlarge"
+		num_workers   = 2
+		policy_id     = "def"
+		apply_policy_default_values = true
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, true, d.Get("apply_policy_default_values"))
+}
+
 func TestResourceClusterCreatePinned(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/create",
@@ -165,6 +286,8 @@ func TestResourceClusterCreatePinned(t *testing.T) {
 func TestResourceClusterCreate_WithLibraries(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/create",
@@ -213,6 +336,13 @@ func TestResourceClusterCreate_WithLibraries(t *testing.T) {
 				ExpectedRequest: ClusterLibraryList{
 					ClusterID: "abc",
 					Libraries: []Library{
+						{
+							Maven: &Maven{
+								Coordinates: "foo:bar:baz:0.1.0",
+								Exclusions:  []string{"org.apache:flink:base"},
+								Repo:        "s3://maven-repo-in-s3/release",
+							},
+						},
 						{
 							Pypi: &PyPi{
 								Package: "seaborn==1.2.4",
@@ -221,13 +351,6 @@ func TestResourceClusterCreate_WithLibraries(t *testing.T) {
 						{
 							Whl: "dbfs://baz.whl",
 						},
-						{
-							Maven: &Maven{
-								Coordinates: "foo:bar:baz:0.1.0",
-								Exclusions:  []string{"org.apache:flink:base"},
-								Repo:        "s3://maven-repo-in-s3/release",
-							},
-						},
 						{
 							Egg: "dbfs://bar.egg",
 						},
@@ -361,6 +484,8 @@ func TestResourceClusterCreate_WithLibraries(t *testing.T) {
 func TestResourceClusterCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/create",
@@ -402,6 +527,10 @@ func TestResourceClusterRead(t *testing.T) {
 					AutoScale: &AutoScale{
 						MaxWorkers: 4,
 					},
+					Driver: &SparkNode{
+						PrivateIP: "10.0.0.1",
+						PublicDNS: "ec2-1-2-3-4.compute-1.amazonaws.com",
+					},
 				},
 			},
 			{
@@ -446,9 +575,11 @@ func TestResourceClusterRead(t *testing.T) {
 	assert.Equal(t, "Shared Autoscaling", d.Get("cluster_name"))
 	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
 	assert.Equal(t, 4, d.Get("autoscale.0.max_workers"))
-	assert.Equal(t, "requests", d.Get("library.754562683.pypi.0.package"))
+	assert.Equal(t, "requests", d.Get("library.3491914042.pypi.0.package"))
 	assert.Equal(t, "RUNNING", d.Get("state"))
 	assert.Equal(t, false, d.Get("is_pinned"))
+	assert.Equal(t, "10.0.0.1", d.Get("driver_private_ip"))
+	assert.Equal(t, "ec2-1-2-3-4.compute-1.amazonaws.com", d.Get("driver_public_dns"))
 
 	for k, v := range d.State().Attributes {
 		fmt.Printf("assert.Equal(t, %#v, d.Get(%#v))\n", v, k)
@@ -499,6 +630,8 @@ func TestResourceClusterRead_Error(t *testing.T) {
 func TestResourceClusterUpdate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:       "GET",
 				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
@@ -657,6 +790,64 @@ func TestResourceClusterUpdateWithPinned(t *testing.T) {
 	assert.Equal(t, "abc", d.Id(), "Id should be the same as in reading")
 }
 
+func TestResourceClusterUpdate_SkipVersionValidationWithoutEdit(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "POST",
+				Resource:     "/api/2.0/clusters/events",
+				ReuseRequest: true,
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"autotermination_minutes": "15",
+			"cluster_name":            "Shared Autoscaling",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "100",
+		},
+		State: map[string]interface{}{
+			"autotermination_minutes": 15,
+			"cluster_name":            "Shared Autoscaling",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             100,
+			"skip_version_validation": true,
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("skip_version_validation"))
+}
+
 func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T) {
 	terminated := qa.HTTPFixture{
 		Method:   "GET",
@@ -693,6 +884,8 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 	}
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			terminated, // 1 of ...
 			{
 				Method:   "POST",
@@ -832,6 +1025,8 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 func TestResourceClusterUpdate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:   "GET",
 				Resource: "/api/2.0/clusters/get?cluster_id=abc",
@@ -914,6 +1109,8 @@ func TestResourceClusterDelete_Error(t *testing.T) {
 func TestResourceClusterCreate_SingleNode(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/create",
@@ -1001,6 +1198,10 @@ func TestResourceClusterCreate_SingleNode(t *testing.T) {
 
 func TestResourceClusterCreate_SingleNodeFail(t *testing.T) {
 	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+		},
 		Create:   true,
 		Resource: ResourceCluster(),
 		State: map[string]interface{}{
@@ -1106,3 +1307,2043 @@ func TestModifyClusterRequestGcp(t *testing.T) {
 	assert.Equal(t, "", c.DriverNodeTypeID)
 	assert.Equal(t, false, c.EnableElasticDisk)
 }
+
+func TestValidateDockerImageURL(t *testing.T) {
+	pinned := "databricksruntime/standard@sha256:" + strings.Repeat("a1", 32)
+	assert.Len(t, validateDockerImageURL(pinned, cty.Path{}), 0)
+
+	diags := validateDockerImageURL("databricksruntime/standard:latest", cty.Path{})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Docker image databricksruntime/standard:latest is not pinned by digest", diags[0].Summary)
+}
+
+func TestResourceClusterCreate_DockerImageStrictDigest(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Docker Cluster"
+		spark_version = "7.3.x-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		require_docker_image_digest = true
+		docker_image {
+			url = "databricksruntime/standard:latest"
+		}`,
+	}.ExpectError(t, "docker_image.url must be pinned by digest, like "+
+		"repository@sha256:<digest>, but got: databricksruntime/standard:latest")
+}
+
+func TestValidateDockerBasicAuthSecrets(t *testing.T) {
+	_, errs := validateDockerPassword("literal", "password")
+	assert.Len(t, errs, 0)
+	for _, v := range []string{"{{secrets/docker/token}}", "{{secrets/docker}}"} {
+		_, errs = validateDockerPassword(v, "password")
+		require.Len(t, errs, 1, v)
+		assert.EqualError(t, errs[0], "password must not be a secret reference, "+
+			"use secret_reference with {{secrets/<scope>/<key>}} instead")
+	}
+
+	_, errs = validateSecretReference("{{secrets/docker/token}}", "secret_reference")
+	assert.Len(t, errs, 0)
+	_, errs = validateSecretReference("token", "secret_reference")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "secret_reference must be in {{secrets/<scope>/<key>}} format, but got: token")
+}
+
+func TestDockerBasicAuthMarshalJSON(t *testing.T) {
+	raw, err := json.Marshal(DockerBasicAuth{
+		Username:        "user",
+		SecretReference: "{{secrets/docker/token}}",
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"username":"user","password":"{{secrets/docker/token}}"}`, string(raw))
+}
+
+func TestDockerBasicAuthUnmarshalJSON(t *testing.T) {
+	var auth DockerBasicAuth
+	err := json.Unmarshal([]byte(`{"username":"user","password":"{{secrets/docker/token}}"}`), &auth)
+	require.NoError(t, err)
+	assert.Equal(t, DockerBasicAuth{
+		Username:        "user",
+		SecretReference: "{{secrets/docker/token}}",
+	}, auth)
+
+	err = json.Unmarshal([]byte(`{"username":"user","password":"literal"}`), &auth)
+	require.NoError(t, err)
+	assert.Equal(t, DockerBasicAuth{
+		Username: "user",
+		Password: "literal",
+	}, auth)
+}
+
+func TestResourceClusterCreate_DockerPasswordSecretReference(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Docker Cluster"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		docker_image {
+			url = "databricksruntime/standard:latest"
+			basic_auth {
+				username = "user"
+				password = "{{secrets/docker/token}}"
+			}
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "invalid config supplied. [docker_image.#.basic_auth.#.password] "+
+		"docker_image.0.basic_auth.0.password must not be a secret reference, "+
+		"use secret_reference with {{secrets/<scope>/<key>}} instead")
+}
+
+func TestResourceClusterCreate_DockerSecretReference(t *testing.T) {
+	dockerImage := &DockerImage{
+		URL: "databricksruntime/standard:latest",
+		BasicAuth: &DockerBasicAuth{
+			Username:        "user",
+			SecretReference: "{{secrets/docker/token}}",
+		},
+	}
+	hcl := `
+		cluster_name = "Docker Cluster"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		docker_image {
+			url = "databricksruntime/standard:latest"
+			basic_auth {
+				username = "user"
+				secret_reference = "{{secrets/docker/token}}"
+			}
+		}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					ClusterName:            "Docker Cluster",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					DockerImage: &DockerImage{
+						URL: "databricksruntime/standard:latest",
+						BasicAuth: &DockerBasicAuth{
+							Username: "user",
+							Password: "{{secrets/docker/token}}",
+						},
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "Docker Cluster",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					// API returns secret reference as password
+					DockerImage: dockerImage,
+					State:       ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL:      hcl,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "{{secrets/docker/token}}", d.Get("docker_image.0.basic_auth.0.secret_reference"))
+	assert.Equal(t, "", d.Get("docker_image.0.basic_auth.0.password"))
+
+	// next plan doesn't replace the cluster
+	diff, err := ResourceCluster().Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"cluster_name":  "Docker Cluster",
+			"spark_version": "7.1-scala12",
+			"node_type_id":  "i3.xlarge",
+			"num_workers":   1,
+			"docker_image": []interface{}{
+				map[string]interface{}{
+					"url": "databricksruntime/standard:latest",
+					"basic_auth": []interface{}{
+						map[string]interface{}{
+							"username":         "user",
+							"secret_reference": "{{secrets/docker/token}}",
+						},
+					},
+				},
+			},
+		}), nil)
+	require.NoError(t, err)
+	if diff != nil {
+		assert.False(t, diff.RequiresNew(), "unexpected replacement: %v", diff.Attributes)
+	}
+}
+
+func TestResourceClusterCreate_DockerPasswordAndSecretReference(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{nodeTypesFixture},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Docker Cluster"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		skip_version_validation = true
+		docker_image {
+			url = "databricksruntime/standard:latest"
+			basic_auth {
+				username = "user"
+				password = "literal"
+				secret_reference = "{{secrets/docker/token}}"
+			}
+		}`,
+	}.ExpectError(t, "exactly one of basic_auth.password or basic_auth.secret_reference must be specified")
+}
+
+func TestResourceClusterCreate_UnknownDriverNodeType(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{nodeTypesFixture},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Unknown driver"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		driver_node_type_id = "i3.2xlage"
+		num_workers = 1
+		skip_version_validation = true`,
+	}.ExpectError(t, "driver_node_type_id i3.2xlage is not available in this workspace. Did you mean i3.2xlarge?")
+}
+
+func TestValidateGcpZoneID(t *testing.T) {
+	for _, zone := range []string{"auto", "HA", "us-central1-a", "europe-west4-c"} {
+		_, errs := validateGcpZoneID(zone, "zone_id")
+		assert.Len(t, errs, 0, zone)
+	}
+	_, errs := validateGcpZoneID("us-central1", "zone_id")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "zone_id must be auto, HA or a zone like us-central1-a, got: us-central1")
+}
+
+func TestResourceClusterCreate_GcpZoneAuto(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					ClusterName:            "GCP Cluster",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "n1-standard-4",
+					AutoterminationMinutes: 60,
+					GcpAttributes: &GcpAttributes{
+						ZoneID: "auto",
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "GCP Cluster",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "n1-standard-4",
+					AutoterminationMinutes: 60,
+					GcpAttributes: &GcpAttributes{
+						ZoneID: "us-central1-b",
+					},
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Gcp:      true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "GCP Cluster"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "n1-standard-4"
+		num_workers = 1
+		gcp_attributes {
+			zone_id = "auto"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "us-central1-b", d.Get("effective_zone_id"))
+}
+
+func TestResourceClusterCreate_GcpZoneOutsideOfRegion(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-zones",
+				Response: ZonesInfo{
+					Zones:       []string{"us-central1-a", "us-central1-b"},
+					DefaultZone: "us-central1-a",
+				},
+			},
+		},
+		Create:   true,
+		Gcp:      true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "GCP Cluster"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "n1-standard-4"
+		num_workers = 1
+		gcp_attributes {
+			zone_id = "europe-west4-a"
+		}`,
+	}.ExpectError(t, "zone europe-west4-a is not available in the workspace region. "+
+		"Available zones: us-central1-a, us-central1-b")
+}
+
+func TestResourceClusterUpdate_GcpZoneAutoNoDiff(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "GCP Cluster",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "n1-standard-4",
+					AutoterminationMinutes: 60,
+					GcpAttributes: &GcpAttributes{
+						ZoneID: "us-central1-b",
+					},
+					State: ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Gcp:      true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"cluster_name":             "GCP Cluster",
+			"spark_version":            "7.3.x-scala2.12",
+			"node_type_id":             "n1-standard-4",
+			"num_workers":              "1",
+			"autotermination_minutes":  "60",
+			"gcp_attributes.#":         "1",
+			"gcp_attributes.0.zone_id": "us-central1-b",
+			"effective_zone_id":        "us-central1-b",
+		},
+		HCL: `
+		cluster_name = "GCP Cluster"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "n1-standard-4"
+		num_workers = 1
+		gcp_attributes {
+			zone_id = "auto"
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceClusterUpdate_ResizeRunningCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "Resized",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/resize",
+				ExpectedRequest: ResizeRequest{
+					ClusterID:  "abc",
+					NumWorkers: 3,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"cluster_name":            "Resized",
+			"spark_version":           "7.3.x-scala2.12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "1",
+			"autotermination_minutes": "60",
+		},
+		HCL: `
+		cluster_name = "Resized"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 3`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceClusterCreate_InstancePoolAndNodeTypeConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Conflicting Cluster"
+		spark_version = "7.3.x-scala2.12"
+		instance_pool_id = "pool"
+		node_type_id = "i3.xlarge"
+		num_workers = 1`,
+	}.ExpectError(t, "invalid config supplied. [instance_pool_id] Conflicting configuration arguments. "+
+		"[node_type_id] Conflicting configuration arguments")
+}
+
+func TestResourceClusterCreate_DriverInstancePoolWithoutInstancePool(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Conflicting Cluster"
+		spark_version = "7.3.x-scala2.12"
+		driver_instance_pool_id = "pool"
+		num_workers = 1`,
+	}.ExpectError(t, "driver_instance_pool_id can only be specified together with instance_pool_id")
+}
+
+func TestResourceClusterUpdate_InstancePoolWithNodeTypeFromState(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "Pool Cluster",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					InstancePoolID:         "pool",
+					DriverInstancePoolID:   "pool",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"cluster_name":            "Pool Cluster",
+			"spark_version":           "7.3.x-scala2.12",
+			"node_type_id":            "i3.xlarge",
+			"driver_node_type_id":     "i3.xlarge",
+			"instance_pool_id":        "pool",
+			"driver_instance_pool_id": "pool",
+			"num_workers":             "1",
+			"autotermination_minutes": "60",
+		},
+		HCL: `
+		cluster_name = "Pool Cluster"
+		spark_version = "7.3.x-scala2.12"
+		instance_pool_id = "pool"
+		num_workers = 1`,
+	}.ApplyNoError(t)
+}
+
+func TestClusterSensitiveFields(t *testing.T) {
+	for name, s := range map[string]map[string]*schema.Schema{
+		"cluster":          ResourceCluster().Schema,
+		"job new_cluster":  common.MustSchemaPath(ResourceJob().Schema, "new_cluster").Elem.(*schema.Resource).Schema,
+		"task new_cluster": common.MustSchemaPath(ResourceJob().Schema, "task", "new_cluster").Elem.(*schema.Resource).Schema,
+		"pipeline cluster": common.MustSchemaPath(ResourcePipeline().Schema, "cluster").Elem.(*schema.Resource).Schema,
+	} {
+		for _, path := range [][]string{
+			{"spark_env_vars"},
+			{"cluster_log_conf", "s3", "kms_key"},
+			{"init_scripts", "s3", "kms_key"},
+		} {
+			assert.True(t, common.MustSchemaPath(s, path...).Sensitive, "%s: %v", name, path)
+		}
+	}
+	assert.True(t, common.MustSchemaPath(ResourceCluster().Schema,
+		"docker_image", "basic_auth", "password").Sensitive)
+	assert.False(t, common.MustSchemaPath(ResourceCluster().Schema,
+		"docker_image", "url").Sensitive)
+	pool := ResourceInstancePool().CoreConfigSchema()
+	assert.True(t, pool.BlockTypes["preloaded_docker_image"].BlockTypes["basic_auth"].Attributes["password"].Sensitive)
+}
+
+func TestResourceClusterDiff_SensitiveValues(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{nodeTypesFixture}, func(ctx context.Context, client *common.DatabricksClient) {
+		diff, err := ResourceCluster().Diff(ctx, nil, terraform.NewResourceConfigRaw(
+			map[string]interface{}{
+				"spark_version":           "7.1-scala12",
+				"skip_version_validation": true,
+				"node_type_id":            "i3.xlarge",
+				"num_workers":             1,
+				"spark_env_vars": map[string]interface{}{
+					"SECRET_TOKEN": "dapi123",
+				},
+				"docker_image": []interface{}{
+					map[string]interface{}{
+						"url": "repo/image@sha256:" + strings.Repeat("a", 64),
+						"basic_auth": []interface{}{
+							map[string]interface{}{
+								"username": "user",
+								"password": "secret",
+							},
+						},
+					},
+				},
+				"cluster_log_conf": []interface{}{
+					map[string]interface{}{
+						"s3": []interface{}{
+							map[string]interface{}{
+								"destination": "s3://logs",
+								"kms_key":     "arn:aws:kms:us-east-1:123:key/abc",
+							},
+						},
+					},
+				},
+			}), client)
+		require.NoError(t, err)
+		for _, k := range []string{
+			"docker_image.0.basic_auth.0.password",
+			"cluster_log_conf.0.s3.0.kms_key",
+		} {
+			require.Contains(t, diff.Attributes, k)
+			assert.True(t, diff.Attributes[k].Sensitive, k)
+		}
+		assert.False(t, diff.Attributes["docker_image.0.basic_auth.0.username"].Sensitive)
+	})
+}
+
+func TestResourceClusterDiff_SSHPublicKeysAndDataSecurityMode(t *testing.T) {
+	tests := []struct {
+		name             string
+		dataSecurityMode string
+		sshPublicKeys    []interface{}
+		err              string
+	}{
+		{"ssh with none", DataSecurityModeNone, []interface{}{"ssh-rsa AAAA"}, ""},
+		{"ssh with user isolation", DataSecurityModeUserIsolation, []interface{}{"ssh-rsa AAAA"},
+			"ssh_public_keys cannot be specified when data_security_mode is USER_ISOLATION"},
+		{"no ssh with user isolation", DataSecurityModeUserIsolation, nil, ""},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{nodeTypesFixture}, func(ctx context.Context, client *common.DatabricksClient) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				raw := map[string]interface{}{
+					"spark_version":           "7.1-scala12",
+					"skip_version_validation": true,
+					"node_type_id":            "i3.xlarge",
+					"num_workers":             1,
+					"data_security_mode":      tt.dataSecurityMode,
+				}
+				if tt.sshPublicKeys != nil {
+					raw["ssh_public_keys"] = tt.sshPublicKeys
+				}
+				_, err := ResourceCluster().Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), client)
+				if tt.err == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, tt.err)
+				}
+			})
+		}
+	})
+}
+
+func TestResourceClusterCreate_EnhancedSecurityMonitoring(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace-conf?keys=enableEnhancedSecurityMonitoring",
+				Response: map[string]string{
+					"enableEnhancedSecurityMonitoring": "true",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					ClusterName:            "Secure",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					EnhancedSecurityMonitoring: &EnhancedSecurityMonitoringConfig{
+						IsEnabled: true,
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					ClusterName:            "Secure",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					EnhancedSecurityMonitoring: &EnhancedSecurityMonitoringConfig{
+						IsEnabled: true,
+					},
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Secure"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		enhanced_security_monitoring {
+			is_enabled = true
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("enhanced_security_monitoring.0.is_enabled"))
+}
+
+func TestResourceClusterCreate_EnhancedSecurityMonitoringNotEnabledInWorkspace(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace-conf?keys=enableEnhancedSecurityMonitoring",
+				Response: map[string]string{
+					"enableEnhancedSecurityMonitoring": "false",
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Secure"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		enhanced_security_monitoring {
+			is_enabled = true
+		}`,
+	}.ExpectError(t, "enhanced_security_monitoring cannot be enabled, because enhanced "+
+		"security feature is not enabled for the workspace")
+}
+
+func TestResourceClusterCreate_EnhancedSecurityMonitoringWithSingleUser(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Secure"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		single_user_name = "me@example.com"
+		enhanced_security_monitoring {
+			is_enabled = true
+		}`,
+	}.ExpectError(t, "single_user_name cannot be specified when enhanced_security_monitoring is enabled")
+}
+
+func TestResourceCluster_SparkVersionDiffSuppress(t *testing.T) {
+	s := ResourceCluster().Schema
+	sv := s["spark_version"]
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	assert.True(t, sv.DiffSuppressFunc("spark_version", "7.3.x-snapshot-scala2.12", "7.3.x-scala2.12", d))
+	assert.False(t, sv.DiffSuppressFunc("spark_version", "7.3.x-scala2.12", "8.3.x-scala2.12", d))
+	assert.False(t, sv.DiffSuppressFunc("spark_version", "", "7.3.x-scala2.12", d))
+
+	strict := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"strict_spark_version": true,
+	})
+	assert.False(t, sv.DiffSuppressFunc("spark_version", "7.3.x-snapshot-scala2.12", "7.3.x-scala2.12", strict))
+}
+
+func TestValidatePysparkPython(t *testing.T) {
+	assert.Len(t, validatePysparkPython(map[string]interface{}{
+		"PYSPARK_PYTHON":        "/databricks/python3/bin/python3",
+		"PYSPARK_DRIVER_PYTHON": "/usr/bin/python3",
+		"OTHER":                 "/opt/anything",
+	}, cty.Path{}), 0)
+
+	diags := validatePysparkPython(map[string]interface{}{
+		"PYSPARK_PYTHON": "/opt/conda/bin/python",
+	}, cty.Path{})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "PYSPARK_PYTHON points to /opt/conda/bin/python, "+
+		"which is unlikely to exist on Databricks runtimes", diags[0].Summary)
+}
+
+func TestResourceClusterValidate_PysparkPython(t *testing.T) {
+	config := func(python string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"spark_version": "7.3.x-scala2.12",
+			"node_type_id":  "i3.xlarge",
+			"num_workers":   1,
+			"spark_env_vars": map[string]interface{}{
+				"PYSPARK_PYTHON": python,
+			},
+		})
+	}
+	diags := ResourceCluster().Validate(config("/databricks/python3/bin/python3"))
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 0)
+
+	diags = ResourceCluster().Validate(config("/usr/local/bin/python3.9"))
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+}
+
+func TestResourceClusterCreate_ExplicitlyDisabledDiskSettings(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: map[string]interface{}{
+					"num_workers":                  1,
+					"spark_version":                "7.1-scala12",
+					"node_type_id":                 "i3.xlarge",
+					"autotermination_minutes":      60,
+					"enable_elastic_disk":          false,
+					"enable_local_disk_encryption": false,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					// API does not honor the setting
+					EnableElasticDisk: true,
+					State:             ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		enable_elastic_disk = false
+		enable_local_disk_encryption = false
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("enable_elastic_disk"))
+	assert.Equal(t, false, d.Get("enable_local_disk_encryption"))
+}
+
+func TestClusterMarshalJSON_DisabledDiskSettings(t *testing.T) {
+	cluster := Cluster{
+		SparkVersion:   "7.1-scala12",
+		InstancePoolID: "pool",
+		disabledDiskSettings: []string{
+			"enable_elastic_disk",
+			"enable_local_disk_encryption",
+		},
+	}
+	modifyClusterRequest(&cluster)
+	raw, err := json.Marshal(cluster)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"spark_version": "7.1-scala12",
+		"num_workers": 0,
+		"instance_pool_id": "pool",
+		"enable_local_disk_encryption": false
+	}`, string(raw))
+}
+
+func awsClusterDiff(t *testing.T, awsAttributes map[string]interface{}) *terraform.InstanceDiff {
+	diff, err := ResourceCluster().Diff(context.Background(), &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":                                      "abc",
+			"cluster_id":                              "abc",
+			"spark_version":                           "7.1-scala12",
+			"node_type_id":                            "i3.xlarge",
+			"num_workers":                             "1",
+			"autotermination_minutes":                 "60",
+			"aws_attributes.#":                        "1",
+			"aws_attributes.0.availability":           "SPOT",
+			"aws_attributes.0.first_on_demand":        "1",
+			"aws_attributes.0.zone_id":                "us-east-1a",
+			"aws_attributes.0.ebs_volume_count":       "1",
+			"aws_attributes.0.ebs_volume_size":        "100",
+			"aws_attributes.0.ebs_volume_type":        "GENERAL_PURPOSE_SSD",
+			"aws_attributes.0.spot_bid_price_percent": "100",
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"spark_version":  "7.1-scala12",
+		"node_type_id":   "i3.xlarge",
+		"num_workers":    1,
+		"aws_attributes": []interface{}{awsAttributes},
+	}), nil)
+	require.NoError(t, err)
+	return diff
+}
+
+func TestResourceClusterDiff_AwsAvailabilityChange(t *testing.T) {
+	diff := awsClusterDiff(t, map[string]interface{}{
+		"availability": "ON_DEMAND",
+	})
+	require.NotNil(t, diff)
+	require.Contains(t, diff.Attributes, "aws_attributes.0.availability")
+	assert.Equal(t, "ON_DEMAND", diff.Attributes["aws_attributes.0.availability"].New)
+}
+
+func TestResourceClusterDiff_AwsFirstOnDemandChange(t *testing.T) {
+	diff := awsClusterDiff(t, map[string]interface{}{
+		"availability":    "SPOT",
+		"first_on_demand": 0,
+	})
+	require.NotNil(t, diff)
+	require.Contains(t, diff.Attributes, "aws_attributes.0.first_on_demand")
+	assert.Equal(t, "0", diff.Attributes["aws_attributes.0.first_on_demand"].New)
+}
+
+func TestResourceClusterDiff_AwsServerComputedFields(t *testing.T) {
+	diff := awsClusterDiff(t, map[string]interface{}{
+		"availability": "SPOT",
+	})
+	if diff == nil {
+		return
+	}
+	for k := range diff.Attributes {
+		assert.False(t, strings.HasPrefix(k, "aws_attributes."), k)
+	}
+}
+
+func elasticDiskClusterDiff(t *testing.T, config map[string]interface{}) *terraform.InstanceDiff {
+	config["spark_version"] = "7.1-scala12"
+	config["node_type_id"] = "i3.xlarge"
+	config["num_workers"] = 1
+	diff, err := ResourceCluster().Diff(context.Background(), &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":                      "abc",
+			"cluster_id":              "abc",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "1",
+			"autotermination_minutes": "60",
+			"enable_elastic_disk":     "true",
+		},
+	}, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	return diff
+}
+
+func TestResourceClusterDiff_ElasticDiskUnset(t *testing.T) {
+	diff := elasticDiskClusterDiff(t, map[string]interface{}{})
+	require.NotNil(t, diff)
+	assert.NotContains(t, diff.Attributes, "enable_elastic_disk")
+}
+
+func TestResourceClusterDiff_ElasticDiskExplicit(t *testing.T) {
+	diff := elasticDiskClusterDiff(t, map[string]interface{}{
+		"enable_elastic_disk": false,
+	})
+	require.NotNil(t, diff)
+	require.Contains(t, diff.Attributes, "enable_elastic_disk")
+	assert.Equal(t, "false", diff.Attributes["enable_elastic_disk"].New)
+}
+
+func TestClusterChangeImpact(t *testing.T) {
+	old := Cluster{
+		ClusterName:  "Shared",
+		SparkVersion: "7.1-scala12",
+		NodeTypeID:   "i3.xlarge",
+		NumWorkers:   1,
+		SparkConf: map[string]string{
+			"spark.speculation": "true",
+		},
+		IdempotencyToken: "abc",
+	}
+	assert.Equal(t, ChangeImpactNone, old.ChangeImpact(old))
+
+	changed := old
+	changed.SparkConf = map[string]string{
+		"spark.speculation": "false",
+	}
+	assert.Equal(t, ChangeImpactRestart, old.ChangeImpact(changed))
+
+	changed = old
+	changed.IdempotencyToken = "def"
+	assert.Equal(t, ChangeImpactRecreate, old.ChangeImpact(changed))
+
+	changed = old
+	changed.CustomTags = map[string]string{
+		"team": "data",
+	}
+	assert.Equal(t, ChangeImpactEdit, old.ChangeImpact(changed))
+
+	changed = old
+	changed.DockerImage = &DockerImage{
+		URL: "repo/image:latest",
+	}
+	assert.Equal(t, ChangeImpactRecreate, old.ChangeImpact(changed))
+}
+
+func TestClusterChangeImpactFromData(t *testing.T) {
+	config := func(extra map[string]interface{}) map[string]interface{} {
+		raw := map[string]interface{}{
+			"cluster_name":  "Shared",
+			"spark_version": "7.3.x-scala2.12",
+			"node_type_id":  "i3.xlarge",
+			"num_workers":   1,
+		}
+		for k, v := range extra {
+			raw[k] = v
+		}
+		return raw
+	}
+	previous := schema.TestResourceDataRaw(t, clusterSchema, config(nil))
+	previous.SetId("abc")
+	state := previous.State()
+	for expected, extra := range map[ChangeImpact]map[string]interface{}{
+		ChangeImpactNone: nil,
+		ChangeImpactEdit: {"num_workers": 3},
+		ChangeImpactRestart: {"spark_conf": map[string]interface{}{
+			"spark.speculation": "true",
+		}},
+	} {
+		sm := schema.InternalMap(clusterSchema)
+		diff, err := sm.Diff(context.Background(), state,
+			terraform.NewResourceConfigRaw(config(extra)), nil, nil, true)
+		require.NoError(t, err)
+		d, err := sm.Data(state, diff)
+		require.NoError(t, err)
+		impact, err := clusterChangeImpact(d)
+		require.NoError(t, err)
+		assert.Equal(t, expected, impact)
+	}
+}
+
+func TestClustersAPISparkVersionWarning(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{sparkVersionsFixture}, func(ctx context.Context, client *common.DatabricksClient) {
+		clustersAPI := NewClustersAPI(ctx, client)
+		assert.Equal(t, "", clustersAPI.sparkVersionWarning("7.1-scala12"))
+		assert.Equal(t, "spark_version 6.4.x-scala2.11 is deprecated or no longer available. "+
+			"Upgrade to a supported runtime, that could be looked up with databricks_spark_version "+
+			"data source, or set skip_version_validation, if the version is known to be valid",
+			clustersAPI.sparkVersionWarning("6.4.x-scala2.11"))
+	})
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/spark-versions",
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "nope",
+			},
+			Status: 403,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		assert.Equal(t, "cannot verify spark_version 7.1-scala12: nope",
+			NewClustersAPI(ctx, client).sparkVersionWarning("7.1-scala12"))
+	})
+}
+
+func TestAutoterminationWarning(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes int32
+		source  Availability
+		warning string
+	}{
+		{
+			name:    "reasonable value",
+			minutes: 60,
+			source:  ClusterSourceAPI,
+		},
+		{
+			name:    "never terminates",
+			minutes: 0,
+			source:  ClusterSourceUI,
+			warning: "autotermination_minutes = 0 disables automatic termination of all-purpose " +
+				"cluster, so it is billed until terminated manually. Consider the default of 60 minutes",
+		},
+		{
+			name:    "too long",
+			minutes: 10000,
+			source:  ClusterSourceAPI,
+			warning: "all-purpose cluster terminates only after 10000 minutes of inactivity. " +
+				"Consider autotermination_minutes of at most 1440 to control costs",
+		},
+		{
+			name:    "job cluster",
+			minutes: 0,
+			source:  ClusterSourceJob,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.warning, autoterminationWarning(tt.minutes, tt.source))
+		})
+	}
+}
+
+func TestResourceClusterValidate_Autotermination(t *testing.T) {
+	config := func(minutes int) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"spark_version":           "7.3.x-scala2.12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             1,
+			"autotermination_minutes": minutes,
+		})
+	}
+	diags := ResourceCluster().Validate(config(60))
+	assert.Len(t, diags, 0)
+
+	diags = ResourceCluster().Validate(config(0))
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Summary, "autotermination_minutes = 0 disables automatic termination")
+}
+
+func TestClusterApplyClusterMode(t *testing.T) {
+	cluster := Cluster{SparkConf: map[string]string{"spark.speculation": "true"}}
+	require.NoError(t, cluster.applyClusterMode(ClusterModeSingleNode))
+	assert.Equal(t, map[string]string{
+		"spark.speculation":                "true",
+		"spark.databricks.cluster.profile": "singleNode",
+		"spark.master":                     "local[*]",
+	}, cluster.SparkConf)
+	assert.Equal(t, map[string]string{"ResourceClass": "SingleNode"}, cluster.CustomTags)
+	assert.NoError(t, validateClusterDefinition(cluster))
+
+	cluster = Cluster{NumWorkers: 2}
+	require.NoError(t, cluster.applyClusterMode(ClusterModeHighConcurrency))
+	assert.True(t, cluster.IsServerless())
+	assert.Equal(t, "Serverless", cluster.CustomTags["ResourceClass"])
+
+	cluster = Cluster{NumWorkers: 2}
+	require.NoError(t, cluster.applyClusterMode(ClusterModeStandard))
+	assert.Nil(t, cluster.SparkConf)
+
+	cluster = Cluster{NumWorkers: 2}
+	assert.EqualError(t, cluster.applyClusterMode(ClusterModeSingleNode),
+		"cluster_mode SINGLE_NODE cannot have num_workers or autoscale")
+
+	cluster = Cluster{SparkConf: map[string]string{"spark.master": "spark://abc"}}
+	assert.EqualError(t, cluster.applyClusterMode(ClusterModeSingleNode),
+		"spark_conf.spark.master = spark://abc conflicts with cluster_mode SINGLE_NODE")
+
+	cluster = Cluster{SparkConf: map[string]string{"spark.databricks.cluster.profile": "serverless"}}
+	assert.EqualError(t, cluster.applyClusterMode(ClusterModeStandard),
+		"spark_conf.spark.databricks.cluster.profile = serverless conflicts with cluster_mode STANDARD")
+}
+
+func TestResourceClusterCreate_ClusterModeSingleNode(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					ClusterName:            "Single Node",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					SparkConf: map[string]string{
+						"spark.databricks.cluster.profile": "singleNode",
+						"spark.master":                     "local[*]",
+					},
+					CustomTags: map[string]string{
+						"ResourceClass": "SingleNode",
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Single Node",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					SparkConf: map[string]string{
+						"spark.databricks.cluster.profile": "singleNode",
+						"spark.master":                     "local[*]",
+					},
+					CustomTags: map[string]string{
+						"ResourceClass": "SingleNode",
+					},
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Single Node"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		cluster_mode = "SINGLE_NODE"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "SINGLE_NODE", d.Get("cluster_mode"))
+}
+
+func assertNoClusterModeDiff(t *testing.T, diff *terraform.InstanceDiff) {
+	if diff == nil {
+		return
+	}
+	for k := range diff.Attributes {
+		for _, prefix := range []string{"spark_conf", "custom_tags", "cluster_mode"} {
+			assert.False(t, strings.HasPrefix(k, prefix), "unexpected diff of %s", k)
+		}
+	}
+}
+
+func TestResourceClusterDiff_ClusterModeSuppressesImpliedSettings(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"cluster_id":              "abc",
+			"cluster_name":            "Single Node",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"autotermination_minutes": "60",
+			"num_workers":             "0",
+			"spark_conf.%":            "3",
+			"spark_conf.spark.databricks.cluster.profile": "singleNode",
+			"spark_conf.spark.master":                     "local[*]",
+			"spark_conf.spark.speculation":                "true",
+			"custom_tags.%":                               "1",
+			"custom_tags.ResourceClass":                   "SingleNode",
+		},
+	}
+	config := map[string]interface{}{
+		"cluster_name":  "Single Node",
+		"spark_version": "7.1-scala12",
+		"node_type_id":  "i3.xlarge",
+		"spark_conf": map[string]interface{}{
+			"spark.speculation": "true",
+		},
+	}
+	config["cluster_mode"] = "SINGLE_NODE"
+	diff, err := ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assertNoClusterModeDiff(t, diff)
+
+	// clusters with profile set through spark_conf are equivalent to the cluster_mode
+	delete(config, "spark_conf")
+	config["cluster_mode"] = "SINGLE_NODE"
+	state.Attributes["spark_conf.%"] = "2"
+	delete(state.Attributes, "spark_conf.spark.speculation")
+	diff, err = ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assertNoClusterModeDiff(t, diff)
+
+	// without cluster_mode, implied settings are removed
+	delete(config, "cluster_mode")
+	diff, err = ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "spark_conf.spark.master")
+}
+
+func TestResourceClusterCreate_CloneFromCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=source",
+				Response: ClusterInfo{
+					ClusterID:              "source",
+					ClusterName:            "Analytics",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					DriverNodeTypeID:       "i3.xlarge",
+					AutoScale:              &AutoScale{MinWorkers: 2, MaxWorkers: 8},
+					AutoterminationMinutes: 120,
+					PolicyID:               "old-policy",
+					SparkConf: map[string]string{
+						"spark.speculation": "true",
+					},
+					CustomTags: map[string]string{
+						"Team": "analytics",
+					},
+					DefaultTags: map[string]string{
+						"ClusterId": "source",
+					},
+					State:          ClusterStateRunning,
+					SparkContextID: 123,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					ClusterName:            "Analytics Clone",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					DriverNodeTypeID:       "i3.xlarge",
+					NumWorkers:             4,
+					AutoterminationMinutes: 120,
+					PolicyID:               "new-policy",
+					SparkConf: map[string]string{
+						"spark.speculation": "true",
+					},
+					CustomTags: map[string]string{
+						"Team": "analytics",
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Analytics Clone",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					DriverNodeTypeID:       "i3.xlarge",
+					NumWorkers:             4,
+					AutoterminationMinutes: 120,
+					PolicyID:               "new-policy",
+					SparkConf: map[string]string{
+						"spark.speculation": "true",
+					},
+					CustomTags: map[string]string{
+						"Team": "analytics",
+					},
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		clone_from_cluster_id = "source"
+		cluster_name = "Analytics Clone"
+		policy_id = "new-policy"
+		num_workers = 4`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "source", d.Get("clone_from_cluster_id"))
+	cloned := d.Get("cloned_attributes").(*schema.Set)
+	assert.ElementsMatch(t, []interface{}{"spark_version", "node_type_id", "driver_node_type_id",
+		"autotermination_minutes", "spark_conf", "custom_tags"}, cloned.List())
+}
+
+func TestResourceClusterCreate_NoSparkVersion(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{nodeTypesFixture},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		node_type_id = "i3.xlarge"
+		num_workers = 1`,
+	}.ExpectError(t, "invalid config supplied. [spark_version] Missing required argument")
+}
+
+func clonedClusterState(attrs map[string]string, cloned ...string) *terraform.InstanceState {
+	attrs["cloned_attributes.#"] = fmt.Sprint(len(cloned))
+	for _, k := range cloned {
+		attrs[fmt.Sprintf("cloned_attributes.%d", schema.HashString(k))] = k
+	}
+	return &terraform.InstanceState{
+		ID:         "abc",
+		Attributes: attrs,
+	}
+}
+
+func TestResourceClusterDiff_ClonedClusterKeepsSourceSnapshot(t *testing.T) {
+	state := clonedClusterState(map[string]string{
+		"cluster_id":                   "abc",
+		"clone_from_cluster_id":        "source",
+		"cluster_name":                 "Analytics Clone",
+		"spark_version":                "7.1-scala12",
+		"node_type_id":                 "i3.xlarge",
+		"autotermination_minutes":      "120",
+		"num_workers":                  "4",
+		"spark_conf.%":                 "1",
+		"spark_conf.spark.speculation": "true",
+		"custom_tags.%":                "1",
+		"custom_tags.Team":             "analytics",
+	}, "spark_version", "node_type_id", "autotermination_minutes", "spark_conf", "custom_tags")
+	config := map[string]interface{}{
+		"clone_from_cluster_id": "source",
+		"cluster_name":          "Analytics Clone",
+		"num_workers":           4,
+	}
+	diff, err := ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	if diff != nil {
+		for k := range diff.Attributes {
+			for _, prefix := range []string{"spark_version", "spark_conf", "custom_tags",
+				"num_workers", "autotermination_minutes"} {
+				assert.False(t, strings.HasPrefix(k, prefix), "unexpected diff of %s", k)
+			}
+		}
+	}
+
+	// explicitly configured attributes are still compared with the state
+	config["cluster_name"] = "Renamed"
+	diff, err = ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "Renamed", diff.Attributes["cluster_name"].New)
+}
+
+func TestResourceClusterDiff_ClonedClusterConfiguredAttributes(t *testing.T) {
+	// custom_tags and autotermination_minutes were configured, when the clone was created
+	state := clonedClusterState(map[string]string{
+		"cluster_id":              "abc",
+		"clone_from_cluster_id":   "source",
+		"cluster_name":            "Analytics Clone",
+		"spark_version":           "7.1-scala12",
+		"node_type_id":            "i3.xlarge",
+		"autotermination_minutes": "30",
+		"num_workers":             "4",
+		"custom_tags.%":           "2",
+		"custom_tags.Team":        "analytics",
+		"custom_tags.Owner":       "me",
+	}, "spark_version", "node_type_id")
+	diff, err := ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"clone_from_cluster_id":   "source",
+			"cluster_name":            "Analytics Clone",
+			"num_workers":             4,
+			"autotermination_minutes": 0,
+			"custom_tags": map[string]interface{}{
+				"Team": "analytics",
+			},
+		}), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "0", diff.Attributes["autotermination_minutes"].New)
+	assert.Equal(t, "1", diff.Attributes["custom_tags.%"].New)
+	assert.True(t, diff.Attributes["custom_tags.Owner"].NewRemoved)
+}
+
+func TestResourceClusterDiff_RemovedSparkVersion(t *testing.T) {
+	_, err := ResourceCluster().Diff(context.Background(), clonedClusterState(map[string]string{
+		"cluster_id":            "abc",
+		"clone_from_cluster_id": "source",
+		"spark_version":         "7.1-scala12",
+		"num_workers":           "4",
+	}), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"clone_from_cluster_id": "source",
+		"num_workers":           4,
+	}), nil)
+	assert.EqualError(t, err, "spark_version is required, unless clone_from_cluster_id is set")
+}
+
+func TestCloneCluster_InstancePool(t *testing.T) {
+	source := ClusterInfo{
+		ClusterID:            "source",
+		SparkVersion:         "7.1-scala12",
+		NodeTypeID:           "i3.xlarge",
+		DriverNodeTypeID:     "i3.xlarge",
+		InstancePoolID:       "pool",
+		DriverInstancePoolID: "driver-pool",
+		NumWorkers:           2,
+	}
+	cluster, cloned, err := cloneCluster(source, Cluster{ClusterName: "Clone"}, func(k string) bool {
+		return k == "cluster_name"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "", cluster.NodeTypeID)
+	assert.Equal(t, "", cluster.DriverNodeTypeID)
+	assert.Equal(t, "pool", cluster.InstancePoolID)
+	assert.Equal(t, "Clone", cluster.ClusterName)
+	assert.ElementsMatch(t, []string{"spark_version", "instance_pool_id",
+		"driver_instance_pool_id", "num_workers"}, cloned)
+
+	// explicit node type replaces pools of the source
+	cluster, _, err = cloneCluster(source, Cluster{NodeTypeID: "m5.xlarge"}, func(k string) bool {
+		return k == "node_type_id"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "m5.xlarge", cluster.NodeTypeID)
+	assert.Equal(t, "", cluster.InstancePoolID)
+	assert.Equal(t, "", cluster.DriverInstancePoolID)
+}
+
+func TestResourceClusterRead_NodeTypeFromPool(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             2,
+					ClusterName:            "Pooled",
+					SparkVersion:           "7.1-scala12",
+					InstancePoolID:         "pool",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=pool",
+				Response: InstancePool{
+					InstancePoolID:   "pool",
+					InstancePoolName: "Pool",
+					NodeTypeID:       "i3.xlarge",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Read:     true,
+		ID:       "abc",
+		New:      true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "pool", d.Get("instance_pool_id"))
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+}
+
+func TestResourceClusterDiff_NodeTypeFromPool(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"cluster_id":              "abc",
+			"cluster_name":            "Pooled",
+			"spark_version":           "7.1-scala12",
+			"instance_pool_id":        "pool",
+			"node_type_id":            "i3.xlarge",
+			"autotermination_minutes": "60",
+			"num_workers":             "2",
+		},
+	}
+	diff, err := ResourceCluster().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"cluster_name":     "Pooled",
+			"spark_version":    "7.1-scala12",
+			"instance_pool_id": "pool",
+			"num_workers":      2,
+		}), nil)
+	require.NoError(t, err)
+	if diff != nil {
+		assert.NotContains(t, diff.Attributes, "node_type_id")
+	}
+}
+
+func instancePoolFixture(instancePoolID, nodeTypeID, zoneID string) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/instance-pools/get?instance_pool_id=" + instancePoolID,
+		Response: InstancePool{
+			InstancePoolID: instancePoolID,
+			NodeTypeID:     nodeTypeID,
+			AwsAttributes: &InstancePoolAwsAttributes{
+				ZoneID: zoneID,
+			},
+		},
+	}
+}
+
+var photonNodeTypesFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/list-node-types",
+	Response: NodeTypeList{
+		NodeTypes: []NodeType{
+			{
+				NodeTypeID:          "i3.xlarge",
+				PhotonWorkerCapable: true,
+				PhotonDriverCapable: true,
+			},
+			{
+				NodeTypeID:          "r5d.large",
+				PhotonWorkerCapable: true,
+			},
+			{
+				NodeTypeID: "m4.large",
+			},
+		},
+	},
+}
+
+func TestValidateInstancePools_Compatible(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		instancePoolFixture("worker", "r5d.large", "us-west-2a"),
+		instancePoolFixture("driver", "i3.xlarge", "us-west-2a"),
+		photonNodeTypesFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).validateInstancePools(Cluster{
+			SparkVersion:         "10.4.x-photon-scala2.12",
+			InstancePoolID:       "worker",
+			DriverInstancePoolID: "driver",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidateInstancePools_Incompatible(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		instancePoolFixture("worker", "m4.large", "us-west-2a"),
+		instancePoolFixture("driver", "r5d.large", "us-west-2b"),
+		photonNodeTypesFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).validateInstancePools(Cluster{
+			SparkVersion:         "10.4.x-photon-scala2.12",
+			InstancePoolID:       "worker",
+			DriverInstancePoolID: "driver",
+		})
+		assert.EqualError(t, err, "cluster cannot be launched from instance pools: "+
+			"worker pool worker is in us-west-2a zone, but driver pool driver is in us-west-2b zone; "+
+			"node type m4.large of worker pool worker cannot run Photon worker; "+
+			"node type r5d.large of driver pool driver cannot run Photon driver")
+	})
+}
+
+func TestValidateInstancePools_AutoZoneAndUnknownNodeType(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		instancePoolFixture("worker", "p3.2xlarge", "auto"),
+		instancePoolFixture("driver", "i3.xlarge", "us-west-2b"),
+		photonNodeTypesFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).validateInstancePools(Cluster{
+			SparkVersion:         "10.4.x-gpu-ml-scala2.12",
+			InstancePoolID:       "worker",
+			DriverInstancePoolID: "driver",
+		})
+		assert.EqualError(t, err, "cluster cannot be launched from instance pools: "+
+			"node type i3.xlarge of driver pool driver has no GPUs, that are required by 10.4.x-gpu-ml-scala2.12")
+	})
+}
+
+func TestResourceClusterCreate_MissingDriverInstancePool(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			sparkVersionsFixture,
+			instancePoolFixture("worker", "i3.xlarge", "us-west-2a"),
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=driver",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Can't find an instance pool with id: driver",
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Pooled"
+		spark_version = "7.3.x-scala2.12"
+		instance_pool_id = "worker"
+		driver_instance_pool_id = "driver"
+		num_workers = 1`,
+	}.ExpectError(t, "driver_instance_pool_id: instance pool driver does not exist")
+}
+
+var secretsListFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/secrets/list?scope=init",
+	Response: map[string]interface{}{
+		"secrets": []map[string]string{
+			{"key": "token"},
+		},
+	},
+}
+
+func TestValidateSecretReferences_Existing(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		secretsListFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).validateSecretReferences(map[string]string{
+			"TOKEN":       "{{secrets/init/token}}",
+			"SAME_SECRET": "{{secrets/init/token}}",
+			"PLAIN":       "value",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidateSecretReferences_Missing(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		secretsListFixture,
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/secrets/list?scope=gone",
+			Status:   404,
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Scope gone does not exist!",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).validateSecretReferences(map[string]string{
+			"TOKEN":    "{{secrets/init/token}}",
+			"PASSWORD": "{{secrets/init/password}}",
+			"OTHER":    "{{secrets/gone/key}}",
+		})
+		assert.EqualError(t, err, "invalid secret references: secret scope gone does not exist; "+
+			"spark_env_vars.OTHER references missing secret key in gone scope; "+
+			"spark_env_vars.PASSWORD references missing secret password in init scope")
+	})
+}
+
+func TestValidateSecretReferences_CannotList(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/secrets/list?scope=init",
+			Status:   403,
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "User does not have READ permission on scope init",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewClustersAPI(ctx, client).validateSecretReferences(map[string]string{
+			"TOKEN": "{{secrets/init/token}}",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestResourceClusterCreate_MissingSecretReference(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			secretsListFixture,
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Init scripts"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		spark_env_vars = {
+			API_TOKEN = "{{secrets/init/api_token}}"
+		}`,
+	}.ExpectError(t, "invalid secret references: spark_env_vars.API_TOKEN "+
+		"references missing secret api_token in init scope")
+}
+
+func TestPreviousLibraryList(t *testing.T) {
+	raw := map[string]interface{}{
+		"spark_version": "7.3.x-scala2.12",
+		"node_type_id":  "i3.xlarge",
+		"library": []interface{}{
+			map[string]interface{}{
+				"whl":         "dbfs://audit.whl",
+				"verify_only": true,
+			},
+		},
+	}
+	previous := schema.TestResourceDataRaw(t, clusterSchema, raw)
+	previous.SetId("abc")
+	delete(raw, "library")
+	sm := schema.InternalMap(clusterSchema)
+	diff, err := sm.Diff(context.Background(), previous.State(),
+		terraform.NewResourceConfigRaw(raw), nil, nil, true)
+	require.NoError(t, err)
+	d, err := sm.Data(previous.State(), diff)
+	require.NoError(t, err)
+	libraries, err := previousLibraryList(d)
+	require.NoError(t, err)
+	assert.Equal(t, []Library{{Whl: "dbfs://audit.whl", VerifyOnly: true}}, libraries.Libraries)
+}
+
+func TestResourceClusterCreate_VerifyOnlyLibraries(t *testing.T) {
+	installed := qa.HTTPFixture{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+		Response: ClusterLibraryStatuses{
+			ClusterID: "abc",
+			LibraryStatuses: []LibraryStatus{
+				{
+					Library: &Library{
+						Jar: "dbfs://foo.jar",
+					},
+					Status: "INSTALLED",
+				},
+				{
+					Library: &Library{
+						Whl: "dbfs://audit.whl",
+					},
+					Status:                          "INSTALLED",
+					IsLibraryInstalledOnAllClusters: true,
+				},
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []Library{
+						{
+							Jar: "dbfs://foo.jar",
+						},
+					},
+				},
+			},
+			installed,
+			{
+				Method:       "POST",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+
+		library {
+			jar = "dbfs://foo.jar"
+		}
+
+		library {
+			whl = "dbfs://audit.whl"
+			verify_only = true
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 2, d.Get("library.#"))
+}
+
+func TestResourceClusterRead_VerifyOnlyLibraryNotInstalled(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			sparkVersionsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					ClusterID: "abc",
+					LibraryStatuses: []LibraryStatus{
+						{
+							Library: &Library{
+								Whl: "dbfs://audit.whl",
+							},
+							Status: "UNINSTALL_ON_RESTART",
+						},
+					},
+				},
+			},
+		},
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+
+		library {
+			whl = "dbfs://audit.whl"
+			verify_only = true
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "verify_only libraries are not installed on cluster abc: "+
+		"whl:dbfs://audit.whl is UNINSTALL_ON_RESTART")
+}
@@ -2,9 +2,14 @@ package compute
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -46,6 +51,39 @@ func (a InstancePoolsAPI) List() (ipl InstancePoolList, err error) {
 	return
 }
 
+// WaitForIdleInstances waits until pool has at least minIdle idle instances and fails early,
+// if every pending instance cannot be launched, e.g. because of cloud quota or bad image
+func (a InstancePoolsAPI) WaitForIdleInstances(instancePoolID string, minIdle int32,
+	timeout time.Duration) error {
+	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		var ip InstancePoolAndStats
+		err := a.client.Get(a.context, "/instance-pools/get", map[string]string{
+			"instance_pool_id": instancePoolID,
+		}, &ip)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		stats := InstancePoolStats{}
+		if ip.Stats != nil {
+			stats = *ip.Stats
+		}
+		if stats.IdleCount >= minIdle {
+			return nil
+		}
+		if ip.Status != nil && len(ip.Status.PendingInstanceErrors) > 0 &&
+			int32(len(ip.Status.PendingInstanceErrors)) >= stats.PendingIdleCount+stats.PendingUsedCount {
+			messages := []string{}
+			for _, pie := range ip.Status.PendingInstanceErrors {
+				messages = append(messages, fmt.Sprintf("%s: %s", pie.InstanceID, pie.Message))
+			}
+			return resource.NonRetryableError(fmt.Errorf("instance pool %s cannot launch instances: %s",
+				instancePoolID, strings.Join(messages, "; ")))
+		}
+		return resource.RetryableError(fmt.Errorf("instance pool %s has %d of %d idle instances",
+			instancePoolID, stats.IdleCount, minIdle))
+	})
+}
+
 // Delete terminates a instance pool given its ID
 func (a InstancePoolsAPI) Delete(instancePoolID string) error {
 	return a.client.Post(a.context, "/instance-pools/delete", map[string]string{
@@ -53,12 +91,96 @@ func (a InstancePoolsAPI) Delete(instancePoolID string) error {
 	}, nil)
 }
 
+func validateAzureSpotBidMaxPrice(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(float64)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be float", k)}
+	}
+	if v != AzureSpotBidMaxPriceOnDemand && v <= 0 {
+		errors = append(errors, fmt.Errorf("%s must be either %d or greater than 0, got: %v",
+			k, AzureSpotBidMaxPriceOnDemand, v))
+	}
+	return
+}
+
+// maxSpotBidPricePercent is the highest percentage of on-demand price, that the API accepts
+const maxSpotBidPricePercent = 10000
+
+func validateSpotBidPricePercent(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(int)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be int", k)}
+	}
+	if v < 0 || v > maxSpotBidPricePercent {
+		return nil, []error{fmt.Errorf("%s must be between 0 and %d, got: %d",
+			k, maxSpotBidPricePercent, v)}
+	}
+	if v == 0 {
+		warnings = append(warnings, fmt.Sprintf("%s of 0 may be interpreted as no bid, "+
+			"so spot instances may never be acquired. Use 100 to bid at the on-demand price", k))
+	}
+	return
+}
+
+// API normalizes omitted spot_bid_max_price to -1, which should not trigger pool re-creation
+func azureSpotBidMaxPriceSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return old == fmt.Sprint(AzureSpotBidMaxPriceOnDemand) && (new == "0" || new == "")
+}
+
+func validateInstancePoolAzureAttributes(attrs *InstancePoolAzureAttributes) error {
+	if attrs == nil || attrs.SpotBidMaxPrice == 0 {
+		return nil
+	}
+	if attrs.Availability != AzureAvailabilitySpot {
+		return fmt.Errorf("azure_attributes.spot_bid_max_price can only be set "+
+			"when availability is %s", AzureAvailabilitySpot)
+	}
+	return nil
+}
+
+// maxWarmIdleInstances is the number of idle instances, above which pool is likely
+// to cost more while unused, than the time it saves for cluster starts
+const maxWarmIdleInstances = 10
+
+// validateMinIdleInstances warns about pools, that keep many instances warm
+func validateMinIdleInstances(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(int)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be int", k)}
+	}
+	if v > maxWarmIdleInstances {
+		warnings = append(warnings, fmt.Sprintf("%s keeps %d idle instances, which are billed by the "+
+			"cloud provider even when no clusters use them. Consider lowering it and relying on "+
+			"idle_instance_autotermination_minutes to keep recently used instances warm", k, v))
+	}
+	return
+}
+
 // ResourceInstancePool ...
 func ResourceInstancePool() *schema.Resource {
 	s := common.StructToSchema(InstancePool{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["enable_elastic_disk"].Default = true
-		s["aws_attributes"].ConflictsWith = []string{"azure_attributes"}
-		s["azure_attributes"].ConflictsWith = []string{"aws_attributes"}
+		s["aws_attributes"].ConflictsWith = []string{"azure_attributes", "gcp_attributes"}
+		s["azure_attributes"].ConflictsWith = []string{"aws_attributes", "gcp_attributes"}
+		s["gcp_attributes"].ConflictsWith = []string{"aws_attributes", "azure_attributes"}
+		customizeGcpZoneIDSchema(s)
+		markClusterSensitiveFields(s)
+		if v, ok := s["preloaded_spark_versions"].Elem.(*schema.Schema); ok {
+			// workspace returns expanded keys for runtime aliases
+			v.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+				if old != "" && sameRuntimeKeys(old, new) {
+					log.Printf("[DEBUG] Suppressing diff for k=%#v old=%#v new=%#v", k, old, new)
+					return true
+				}
+				return false
+			}
+		}
+		s["min_idle_instances"].ValidateFunc = validateMinIdleInstances
+		s["wait_for_idle_instances"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
 		if v, err := common.SchemaPath(s, "aws_attributes", "availability"); err == nil {
 			v.Default = AwsAvailabilitySpot
 			v.ValidateFunc = validation.StringInSlice([]string{
@@ -68,6 +190,10 @@ func ResourceInstancePool() *schema.Resource {
 		}
 		if v, err := common.SchemaPath(s, "aws_attributes", "spot_bid_price_percent"); err == nil {
 			v.Default = 100
+			v.ValidateFunc = validateSpotBidPricePercent
+			v.Description = "The max price for spot instances, as a percentage of the on-demand " +
+				"price. 100 means bidding at the on-demand price, and values over 100 allow " +
+				"overbidding relative to on-demand."
 		}
 		if v, err := common.SchemaPath(s, "azure_attributes", "availability"); err == nil {
 			v.Default = AzureAvailabilityOnDemand
@@ -76,6 +202,10 @@ func ResourceInstancePool() *schema.Resource {
 				AzureAvailabilityOnDemand,
 			}, false)
 		}
+		if v, err := common.SchemaPath(s, "azure_attributes", "spot_bid_max_price"); err == nil {
+			v.ValidateFunc = validateAzureSpotBidMaxPrice
+			v.DiffSuppressFunc = azureSpotBidMaxPriceSuppressFunc
+		}
 		if v, err := common.SchemaPath(s, "disk_spec", "disk_type", "azure_disk_volume_type"); err == nil {
 			// nolint
 			v.ValidateFunc = validation.StringInSlice([]string{
@@ -94,16 +224,45 @@ func ResourceInstancePool() *schema.Resource {
 	})
 	return common.Resource{
 		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			var ip InstancePool
+			if err := common.DiffToStructPointer(d, s, &ip); err != nil {
+				return err
+			}
+			if len(ip.PreloadedSparkVersions) > 1 {
+				return fmt.Errorf("only one of preloaded_spark_versions could be specified, but got %d: %s",
+					len(ip.PreloadedSparkVersions), strings.Join(ip.PreloadedSparkVersions, ", "))
+			}
+			if err := validateNodeTypesDiff(ctx, d, c, "node_type_id"); err != nil {
+				return err
+			}
+			for _, image := range ip.PreloadedDockerImages {
+				if err := image.BasicAuth.validate(); err != nil {
+					return err
+				}
+			}
+			return validateInstancePoolAzureAttributes(ip.AzureAttributes)
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var ip InstancePool
 			if err := common.DataToStructPointer(d, s, &ip); err != nil {
 				return err
 			}
+			if c.IsGcp() && ip.GcpAttributes != nil {
+				err := NewClustersAPI(ctx, c).validateGcpZone(ip.GcpAttributes.ZoneID)
+				if err != nil {
+					return err
+				}
+			}
 			instancePoolInfo, err := NewInstancePoolsAPI(ctx, c).Create(ip)
 			if err != nil {
 				return err
 			}
 			d.SetId(instancePoolInfo.InstancePoolID)
+			if d.Get("wait_for_idle_instances").(bool) && ip.MinIdleInstances > 0 {
+				return NewInstancePoolsAPI(ctx, c).WaitForIdleInstances(d.Id(),
+					ip.MinIdleInstances, d.Timeout(schema.TimeoutCreate))
+			}
 			return nil
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -111,6 +270,7 @@ func ResourceInstancePool() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			d.Set("effective_zone_id", ip.effectiveZoneID())
 			return common.StructToData(ip, s, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -119,10 +279,15 @@ func ResourceInstancePool() *schema.Resource {
 				return err
 			}
 			ip.InstancePoolID = d.Id()
+			// status is reported by the API and cannot be changed
+			ip.Status = nil
 			return NewInstancePoolsAPI(ctx, c).Update(ip)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewInstancePoolsAPI(ctx, c).Delete(d.Id())
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultProvisionTimeout),
+		},
 	}.ToResource()
 }
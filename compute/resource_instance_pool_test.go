@@ -2,13 +2,18 @@ package compute
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccInstancePools(t *testing.T) {
@@ -72,6 +77,7 @@ func TestAccInstancePools(t *testing.T) {
 func TestResourceInstancePoolCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/instance-pools/create",
@@ -118,6 +124,7 @@ func TestResourceInstancePoolCreate(t *testing.T) {
 func TestResourceInstancePoolCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/instance-pools/create",
@@ -265,6 +272,7 @@ func TestResourceInstancePoolUpdate(t *testing.T) {
 func TestResourceInstancePoolUpdate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
 			{ // read log output for better stub url...
 				Method:   "POST",
 				Resource: "/api/2.0/instance-pools/edit",
@@ -330,3 +338,443 @@ func TestResourceInstancePoolDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceInstancePoolCreate_AzureSpotBidMaxPriceOnDemand(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: -1,
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: -1,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 15
+		azure_attributes {
+			availability = "SPOT_AZURE"
+			spot_bid_max_price = -1
+		}`,
+		Create: true,
+	}.ApplyNoError(t)
+}
+
+func TestResourceInstancePoolCreate_AzureSpotBidMaxPriceInvalid(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 15
+		azure_attributes {
+			availability = "SPOT_AZURE"
+			spot_bid_max_price = -5
+		}`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [azure_attributes.#.spot_bid_max_price] "+
+		"azure_attributes.0.spot_bid_max_price must be either -1 or greater than 0, got: -5")
+}
+
+func TestResourceInstancePoolCreate_AzureSpotBidMaxPriceOnDemandAvailability(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{nodeTypesFixture},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 15
+		azure_attributes {
+			availability = "ON_DEMAND_AZURE"
+			spot_bid_max_price = 0.5
+		}`,
+		Create: true,
+	}.ExpectError(t, "azure_attributes.spot_bid_max_price can only be set when availability is SPOT_AZURE")
+}
+
+func TestResourceInstancePoolUpdate_AzureSpotBidMaxPriceNormalized(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/edit",
+				ExpectedRequest: InstancePool{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 20,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: -1,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 20,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: -1,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		InstanceState: map[string]string{
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "Standard_DS3_v2",
+			"idle_instance_autotermination_minutes": "15",
+			"enable_elastic_disk":                   "true",
+			"azure_attributes.#":                    "1",
+			"azure_attributes.0.availability":       "SPOT_AZURE",
+			"azure_attributes.0.spot_bid_max_price": "-1",
+		},
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 20
+		azure_attributes {
+			availability = "SPOT_AZURE"
+		}`,
+		Update: true,
+		ID:     "abc",
+	}.ApplyNoError(t)
+}
+
+func TestValidateMinIdleInstances(t *testing.T) {
+	warnings, errs := validateMinIdleInstances(2, "min_idle_instances")
+	assert.Len(t, warnings, 0)
+	assert.Len(t, errs, 0)
+
+	warnings, errs = validateMinIdleInstances(50, "min_idle_instances")
+	assert.Len(t, errs, 0)
+	assert.Equal(t, []string{"min_idle_instances keeps 50 idle instances, which are billed by the " +
+		"cloud provider even when no clusters use them. Consider lowering it and relying on " +
+		"idle_instance_autotermination_minutes to keep recently used instances warm"}, warnings)
+}
+
+func TestResourceInstancePoolValidate_IdleCostWarning(t *testing.T) {
+	diags := ResourceInstancePool().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"instance_pool_name":                    "warm",
+		"node_type_id":                          "i3.xlarge",
+		"idle_instance_autotermination_minutes": 10,
+		"min_idle_instances":                    50,
+	}))
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Summary, "keeps 50 idle instances")
+}
+
+func TestResourceInstancePoolCreate_WaitForIdleInstances(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			nodeTypesFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+					Stats: &InstancePoolStats{
+						PendingIdleCount: 2,
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Warm Pool",
+					MinIdleInstances:                   2,
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					Stats: &InstancePoolStats{
+						IdleCount: 2,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Warm Pool"
+		min_idle_instances = 2
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		wait_for_idle_instances = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestWaitForIdleInstances_PendingErrors(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+			Response: InstancePoolAndStats{
+				InstancePoolID: "abc",
+				Stats: &InstancePoolStats{
+					PendingIdleCount: 2,
+				},
+				Status: &InstancePoolStatus{
+					PendingInstanceErrors: []PendingInstanceError{
+						{
+							InstanceID: "i-1",
+							Message:    "InstanceLimitExceeded",
+						},
+						{
+							InstanceID: "i-2",
+							Message:    "InstanceLimitExceeded",
+						},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewInstancePoolsAPI(ctx, client).WaitForIdleInstances("abc", 2, time.Minute)
+		assert.EqualError(t, err, "instance pool abc cannot launch instances: "+
+			"i-1: InstanceLimitExceeded; i-2: InstanceLimitExceeded")
+	})
+}
+
+func TestResourceInstancePoolRead_PendingInstanceErrors(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					Status: &InstancePoolStatus{
+						PendingInstanceErrors: []PendingInstanceError{
+							{
+								InstanceID: "i-1",
+								Message:    "AMI is not available",
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "i-1", d.Get("status.0.pending_instance_errors.0.instance_id"))
+	assert.Equal(t, "AMI is not available", d.Get("status.0.pending_instance_errors.0.message"))
+}
+
+func TestResourceInstancePoolDiff_PreloadedSparkVersionAlias(t *testing.T) {
+	diff, err := ResourceInstancePool().Diff(context.Background(), &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":                                    "abc",
+			"instance_pool_id":                      "abc",
+			"instance_pool_name":                    "Pool",
+			"node_type_id":                          "i3.xlarge",
+			"idle_instance_autotermination_minutes": "15",
+			"enable_elastic_disk":                   "true",
+			"preloaded_spark_versions.#":            "1",
+			"preloaded_spark_versions.0":            "7.3.15-scala2.12",
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"instance_pool_name":                    "Pool",
+		"node_type_id":                          "i3.xlarge",
+		"idle_instance_autotermination_minutes": 15,
+		"preloaded_spark_versions":              []interface{}{"7.3.x-scala2.12"},
+	}), nil)
+	require.NoError(t, err)
+	if diff != nil {
+		assert.NotContains(t, diff.Attributes, "preloaded_spark_versions.0")
+		assert.False(t, diff.RequiresNew())
+	}
+}
+
+func TestResourceInstancePoolCreate_SeveralPreloadedSparkVersions(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		preloaded_spark_versions = ["7.3.x-scala2.12", "9.1.x-scala2.12"]`,
+	}.ExpectError(t, "only one of preloaded_spark_versions could be specified, "+
+		"but got 2: 7.3.x-scala2.12, 9.1.x-scala2.12")
+}
+
+func TestResourceInstancePoolDiff_EditOrRecreate(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":                                    "abc",
+			"instance_pool_id":                      "abc",
+			"instance_pool_name":                    "Pool",
+			"node_type_id":                          "i3.xlarge",
+			"min_idle_instances":                    "1",
+			"max_capacity":                          "10",
+			"idle_instance_autotermination_minutes": "15",
+			"enable_elastic_disk":                   "true",
+			"disk_spec.#":                           "1",
+			"disk_spec.0.disk_count":                "1",
+			"disk_spec.0.disk_size":                 "32",
+		},
+	}
+	config := func(overrides map[string]interface{}) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"instance_pool_name":                    "Pool",
+			"node_type_id":                          "i3.xlarge",
+			"min_idle_instances":                    1,
+			"max_capacity":                          10,
+			"idle_instance_autotermination_minutes": 15,
+			"disk_spec": []interface{}{
+				map[string]interface{}{
+					"disk_count": 1,
+					"disk_size":  32,
+				},
+			},
+		}
+		for k, v := range overrides {
+			raw[k] = v
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+	tests := []struct {
+		name        string
+		overrides   map[string]interface{}
+		requiresNew bool
+	}{
+		{"idle termination", map[string]interface{}{"idle_instance_autotermination_minutes": 60}, false},
+		{"min idle", map[string]interface{}{"min_idle_instances": 0}, false},
+		{"max capacity", map[string]interface{}{"max_capacity": 20}, false},
+		{"node type", map[string]interface{}{"node_type_id": "i3.2xlarge"}, true},
+		{"disk size", map[string]interface{}{"disk_spec": []interface{}{
+			map[string]interface{}{
+				"disk_count": 1,
+				"disk_size":  64,
+			},
+		}}, true},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{nodeTypesFixture}, func(ctx context.Context, client *common.DatabricksClient) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				diff, err := ResourceInstancePool().Diff(ctx, state, config(tt.overrides), client)
+				require.NoError(t, err)
+				require.NotNil(t, diff)
+				assert.Equal(t, tt.requiresNew, diff.RequiresNew())
+			})
+		}
+	})
+}
+
+func TestResourceInstancePoolCreate_UnknownNodeType(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/list-node-types",
+				Response: NodeTypeList{
+					NodeTypes: []NodeType{
+						{NodeTypeID: "i3.xlarge"},
+						{NodeTypeID: "m5d.large"},
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		max_capacity = 10
+		idle_instance_autotermination_minutes = 15
+		node_type_id = "i3.xlarg"`,
+		Create: true,
+	}.ExpectError(t, "node_type_id i3.xlarg is not available in this workspace. Did you mean i3.xlarge?")
+}
+
+func TestValidateSpotBidPricePercent(t *testing.T) {
+	for _, v := range []int{1, 100, 200, 10000} {
+		warnings, errs := validateSpotBidPricePercent(v, "spot_bid_price_percent")
+		assert.Len(t, warnings, 0, v)
+		assert.Len(t, errs, 0, v)
+	}
+	warnings, errs := validateSpotBidPricePercent(0, "spot_bid_price_percent")
+	assert.Len(t, errs, 0)
+	assert.Equal(t, []string{"spot_bid_price_percent of 0 may be interpreted as no bid, " +
+		"so spot instances may never be acquired. Use 100 to bid at the on-demand price"}, warnings)
+	for _, v := range []int{-1, 10001} {
+		_, errs = validateSpotBidPricePercent(v, "spot_bid_price_percent")
+		require.Len(t, errs, 1, v)
+		assert.EqualError(t, errs[0], fmt.Sprintf(
+			"spot_bid_price_percent must be between 0 and 10000, got: %d", v))
+	}
+	_, errs = validateSpotBidPricePercent("100", "spot_bid_price_percent")
+	assert.Len(t, errs, 1)
+}
+
+func TestResourceInstancePoolCreate_SpotBidPricePercentTooHigh(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		aws_attributes {
+			spot_bid_price_percent = 20000
+		}`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [aws_attributes.#.spot_bid_price_percent] "+
+		"aws_attributes.0.spot_bid_price_percent must be between 0 and 10000, got: 20000")
+}
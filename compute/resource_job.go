@@ -2,12 +2,18 @@ package compute
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/mail"
+	"path"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -33,12 +39,59 @@ func (a JobsAPI) List() (l JobList, err error) {
 	return
 }
 
+// ListAll returns all jobs with their tasks, following every page of the list
+func (a JobsAPI) ListAll() (result []Job, err error) {
+	ctx := context.WithValue(a.context, common.Api, common.API_2_1)
+	req := JobListRequest{Limit: 25, ExpandTasks: true}
+	for {
+		var page JobList
+		err = a.client.Get(ctx, "/jobs/list", req, &page)
+		if err != nil {
+			return
+		}
+		result = append(result, page.Jobs...)
+		if !page.HasMore {
+			return
+		}
+		req.Offset += len(page.Jobs)
+	}
+}
+
 // RunsList ...
 func (a JobsAPI) RunsList(r JobRunsListRequest) (jrl JobRunsList, err error) {
 	err = a.client.Get(a.context, "/jobs/runs/list", r, &jrl)
 	return
 }
 
+// LastCompletedRun returns the latest completed run of the job with its tasks
+// or nil, if the job has never completed a run
+func (a JobsAPI) LastCompletedRun(jobID int64) (*JobRun, error) {
+	ctx := context.WithValue(a.context, common.Api, common.API_2_1)
+	var runs JobRunsList
+	err := a.client.Get(ctx, "/jobs/runs/list", JobRunsListRequest{
+		JobID:         jobID,
+		CompletedOnly: true,
+		Limit:         1,
+		ExpandTasks:   true,
+	}, &runs)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs.Runs) == 0 {
+		return nil, nil
+	}
+	return &runs.Runs[0], nil
+}
+
+// RunOutput returns the output of the task run
+func (a JobsAPI) RunOutput(runID int64) (output RunOutput, err error) {
+	ctx := context.WithValue(a.context, common.Api, common.API_2_1)
+	err = a.client.Get(ctx, "/jobs/runs/get-output", map[string]int64{
+		"run_id": runID,
+	}, &output)
+	return
+}
+
 // RunsCancel ...
 func (a JobsAPI) RunsCancel(runID int64, timeout time.Duration) error {
 	var response interface{}
@@ -48,10 +101,10 @@ func (a JobsAPI) RunsCancel(runID int64, timeout time.Duration) error {
 	if err != nil {
 		return err
 	}
-	return a.waitForRunState(runID, "TERMINATED", timeout)
+	return a.waitForRunState(runID, RunLifeCycleStateTerminated, timeout)
 }
 
-func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.Duration) error {
+func (a JobsAPI) waitForRunState(runID int64, desiredState RunLifeCycleState, timeout time.Duration) error {
 	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
 		jobRun, err := a.RunsGet(runID)
 		if err != nil {
@@ -62,7 +115,7 @@ func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.
 		if state.LifeCycleState == desiredState {
 			return nil
 		}
-		if state.LifeCycleState == "INTERNAL_ERROR" {
+		if state.IsTerminal() {
 			return resource.NonRetryableError(
 				fmt.Errorf("cannot get job %s: %s",
 					desiredState, state.StateMessage))
@@ -97,7 +150,7 @@ func (a JobsAPI) Start(jobID int64, timeout time.Duration) error {
 	if err != nil {
 		return fmt.Errorf("cannot start job run: %v", err)
 	}
-	return a.waitForRunState(runID, "RUNNING", timeout)
+	return a.waitForRunState(runID, RunLifeCycleStateRunning, timeout)
 }
 
 func (a JobsAPI) Restart(id string, timeout time.Duration) error {
@@ -158,6 +211,7 @@ func (a JobsAPI) Read(id string) (job Job, err error) {
 	}, &job), id)
 	if job.Settings != nil {
 		job.Settings.sortTasksByKey()
+		job.Settings.normalizeFormat()
 	}
 	return
 }
@@ -173,6 +227,95 @@ func (a JobsAPI) Delete(id string) error {
 	}, nil), id)
 }
 
+// ValidateClusterReference returns warnings, if existing cluster used by the job does not exist
+// or is in error state. Terminated clusters are started by the job, so those are fine. The check
+// only reads the cluster, so that job owners don't need permissions to manage it.
+func (a JobsAPI) ValidateClusterReference(clusterID string) diag.Diagnostics {
+	warning := func(format string, args ...interface{}) diag.Diagnostics {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf(format, args...),
+			},
+		}
+	}
+	info, err := NewClustersAPI(a.context, a.client).GetCached(clusterID)
+	if common.IsMissing(err) {
+		return warning("cluster %s does not exist", clusterID)
+	}
+	if err != nil {
+		return warning("cannot verify cluster %s: %s", clusterID, err)
+	}
+	switch info.State {
+	case ClusterStateError, ClusterStateUnknown:
+		return warning("cluster %s is in %s state: %s", clusterID, info.State, info.StateMessage)
+	}
+	return nil
+}
+
+// ValidateWebhookNotifications returns errors for webhook IDs, that are not among notification
+// destinations of the workspace. Destinations are fetched only if there are webhooks to check.
+func (a JobsAPI) ValidateWebhookNotifications(wn *WebhookNotifications) (diags diag.Diagnostics) {
+	if wn == nil {
+		return nil
+	}
+	ids := []string{}
+	for _, webhooks := range [][]Webhook{wn.OnStart, wn.OnSuccess, wn.OnFailure} {
+		for _, webhook := range webhooks {
+			// IDs, that are not known until apply, are skipped
+			if webhook.ID != "" {
+				ids = append(ids, webhook.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	destinations, err := a.listNotificationDestinations()
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("cannot verify webhook notifications: %s", err),
+			},
+		}
+	}
+	known := map[string]bool{}
+	for _, destination := range destinations {
+		known[destination.ID] = true
+	}
+	reported := map[string]bool{}
+	for _, id := range ids {
+		if known[id] || reported[id] {
+			continue
+		}
+		reported[id] = true
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("notification destination %s does not exist", id),
+		})
+	}
+	return diags
+}
+
+// listNotificationDestinations returns notification destinations from all pages
+func (a JobsAPI) listNotificationDestinations() (result []NotificationDestination, err error) {
+	// the first page is requested without query parameters
+	var req interface{}
+	for {
+		var page NotificationDestinationList
+		err = a.client.Get(a.context, "/notification-destinations", req, &page)
+		if err != nil {
+			return
+		}
+		result = append(result, page.Results...)
+		if page.NextPageToken == "" {
+			return
+		}
+		req = NotificationDestinationListRequest{PageToken: page.NextPageToken}
+	}
+}
+
 func wrapMissingJobError(err error, id string) error {
 	if err == nil {
 		return nil
@@ -194,12 +337,16 @@ func wrapMissingJobError(err error, id string) error {
 }
 
 func jobSettingsSchema(s *map[string]*schema.Schema, prefix string) {
-	if p, err := common.SchemaPath(*s, "new_cluster", "num_workers"); err == nil {
-		p.Optional = true
-		p.Default = 0
-		p.Type = schema.TypeInt
-		p.ValidateDiagFunc = validation.ToDiagFunc(validation.IntAtLeast(0))
-		p.Required = false
+	customizeLibrarySchema(*s)
+	if p, ok := (*s)["library"]; ok {
+		// job runs install their libraries, so there is nothing to verify
+		delete(p.Elem.(*schema.Resource).Schema, "verify_only")
+	}
+	if nc, ok := (*s)["new_cluster"].Elem.(*schema.Resource); ok {
+		// job clusters terminate with the run and libraries are set on the task
+		computeSpecSchema(nc.Schema, computeSpecOptions{
+			allowAutoscaleMode: true,
+		})
 	}
 	if v, err := common.SchemaPath(*s, "new_cluster", "spark_conf"); err == nil {
 		reSize := common.MustCompileKeyRE(prefix + "new_cluster.0.spark_conf.%")
@@ -216,12 +363,959 @@ func jobSettingsSchema(s *map[string]*schema.Schema, prefix string) {
 	}
 }
 
+var applicationIDRegex = regexp.MustCompile(
+	`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// effectiveServicePrincipalID extracts application ID of service principal, as the
+// server may echo it back decorated with a display name, like "Name (<application-id>)"
+func effectiveServicePrincipalID(name string) string {
+	if applicationID := applicationIDRegex.FindString(name); applicationID != "" {
+		return strings.ToLower(applicationID)
+	}
+	return strings.TrimSpace(name)
+}
+
+func runAsServicePrincipalSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	if effectiveServicePrincipalID(old) == effectiveServicePrincipalID(new) {
+		log.Printf("[DEBUG] Suppressing diff for k=%#v old=%#v new=%#v", k, old, new)
+		return true
+	}
+	return false
+}
+
+// validateNotebookPath checks that notebook path is absolute workspace path, unless
+// notebooks are taken from git_source, where paths are relative to repository root
+func validateNotebookPath(notebookPath string, hasGitSource bool) error {
+	if notebookPath == "" {
+		// interpolated values are not known until apply
+		return nil
+	}
+	if hasGitSource {
+		if strings.HasPrefix(notebookPath, "/") {
+			return fmt.Errorf("notebook_path must be relative to the repository root "+
+				"when git_source is specified, got: %s", notebookPath)
+		}
+		if path.Clean(notebookPath) != notebookPath ||
+			strings.HasPrefix(notebookPath, "../") || notebookPath == ".." {
+			return fmt.Errorf("notebook_path must be a clean relative path, got: %s", notebookPath)
+		}
+		return nil
+	}
+	if !strings.HasPrefix(notebookPath, "/") {
+		return fmt.Errorf("notebook_path must be an absolute workspace path, like "+
+			"/Users/..., /Repos/... or /Shared/..., unless git_source is specified, got: %s", notebookPath)
+	}
+	return nil
+}
+
+// maxTaskKeyLength is the longest task_key accepted by Jobs API
+const maxTaskKeyLength = 100
+
+// maxTaskRetries is the largest max_retries of a task accepted by Jobs API
+const maxTaskRetries = 10
+
+// taskKeyRegex matches task keys accepted by Jobs API
+var taskKeyRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+// validateTaskKey checks length and characters of task_key, as well as of task_key
+// references in depends_on blocks, so that typos are reported during plan
+func validateTaskKey(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if len(v) > maxTaskKeyLength {
+		errors = append(errors, fmt.Errorf("%s must be at most %d characters long, got %d: %s",
+			k, maxTaskKeyLength, len(v), v))
+	}
+	if !taskKeyRegex.MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must contain only letters, digits, "+
+			"hyphens and underscores, got: %s", k, v))
+	}
+	return
+}
+
+// pythonFileSchemes are prefixes of python_file locations, that are supported by Jobs API
+var pythonFileSchemes = []string{"/Workspace/", "dbfs:/", "s3://", "abfss://", "gs://"}
+
+// validatePythonFile checks that python file is either in workspace, DBFS or cloud storage,
+// unless files are taken from git_source, where paths are relative to repository root
+func validatePythonFile(pythonFile string, hasGitSource bool) error {
+	if pythonFile == "" {
+		// interpolated values are not known until apply
+		return nil
+	}
+	for _, scheme := range pythonFileSchemes {
+		if strings.HasPrefix(pythonFile, scheme) {
+			return nil
+		}
+	}
+	if hasGitSource && !strings.HasPrefix(pythonFile, "/") &&
+		!strings.Contains(pythonFile, "://") && path.Clean(pythonFile) == pythonFile &&
+		!strings.HasPrefix(pythonFile, "../") && pythonFile != ".." {
+		return nil
+	}
+	return fmt.Errorf("python_file must start with %s, or be relative to the repository "+
+		"root when git_source is specified, got: %s",
+		strings.Join(pythonFileSchemes, ", "), pythonFile)
+}
+
+// validateNotebookTask checks that notebooks with GIT source are taken from git_source
+// and that the notebook path matches its source
+func validateNotebookTask(nt *NotebookTask, hasGitSource bool) error {
+	if nt.Source == NotebookSourceGit && !hasGitSource {
+		return fmt.Errorf("notebook_task with source = GIT requires git_source block, " +
+			"use source = WORKSPACE for workspace notebooks")
+	}
+	return validateNotebookPath(nt.NotebookPath, nt.isFromGit(hasGitSource))
+}
+
+func (js *JobSettings) validateTaskPaths() error {
+	hasGitSource := js.GitSource != nil
+	if js.NotebookTask != nil {
+		err := validateNotebookTask(js.NotebookTask, hasGitSource)
+		if err != nil {
+			return err
+		}
+	}
+	if js.SparkPythonTask != nil {
+		err := validatePythonFile(js.SparkPythonTask.PythonFile, hasGitSource)
+		if err != nil {
+			return err
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.NotebookTask != nil {
+			err := validateNotebookTask(task.NotebookTask, hasGitSource)
+			if err != nil {
+				return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+			}
+		}
+		if task.SparkPythonTask != nil {
+			err := validatePythonFile(task.SparkPythonTask.PythonFile, hasGitSource)
+			if err != nil {
+				return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+var pythonPackageSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizePackageName makes python package names comparable in the same way as pip does
+func normalizePackageName(name string) string {
+	return strings.ToLower(pythonPackageSeparators.ReplaceAllString(name, "_"))
+}
+
+// wheelDistribution returns distribution name from wheel file name, that follows
+// {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl convention
+func wheelDistribution(whl string) string {
+	return strings.SplitN(path.Base(whl), "-", 2)[0]
+}
+
+// pypiDistribution strips version specifiers and extras from PyPI requirement
+func pypiDistribution(requirement string) string {
+	parts := strings.FieldsFunc(requirement, func(r rune) bool {
+		return strings.ContainsRune("<>=!~[;@ ", r)
+	})
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+// wheelLibrary returns library for package_name, that is a path to wheel file,
+// when auto_add_wheel_library is set. Absolute paths refer to workspace files.
+func (wheel *PythonWheelTask) wheelLibrary() (Library, error) {
+	whl := wheel.PackageName
+	if !strings.HasSuffix(whl, ".whl") {
+		return Library{}, fmt.Errorf("package_name must be a path to .whl file, "+
+			"when auto_add_wheel_library is set, got: %s", whl)
+	}
+	if strings.HasPrefix(whl, "dbfs:/") || strings.HasPrefix(whl, "/Workspace/") {
+		return Library{Whl: whl}, nil
+	}
+	if strings.HasPrefix(whl, "/") {
+		return Library{Whl: "/Workspace" + whl}, nil
+	}
+	return Library{}, fmt.Errorf("package_name must start with dbfs:/ or /, "+
+		"when auto_add_wheel_library is set, got: %s", whl)
+}
+
+// validateWheelLibrary checks that package of python_wheel_task is installed with libraries
+func validateWheelLibrary(wheel *PythonWheelTask, libraries []Library) error {
+	if wheel == nil || wheel.PackageName == "" {
+		return nil
+	}
+	if wheel.AutoAddWheelLibrary {
+		_, err := wheel.wheelLibrary()
+		return err
+	}
+	packageName := normalizePackageName(wheel.PackageName)
+	for _, library := range libraries {
+		switch {
+		case library.Whl != "":
+			if normalizePackageName(wheelDistribution(library.Whl)) == packageName {
+				return nil
+			}
+		case library.Pypi != nil && library.Pypi.Package != "":
+			if normalizePackageName(pypiDistribution(library.Pypi.Package)) == packageName {
+				return nil
+			}
+		case library.String() == ":":
+			// interpolated libraries are not known until apply
+			return nil
+		}
+	}
+	return fmt.Errorf("package_name %s is not installed by any whl or pypi library. "+
+		"Add it to libraries or set auto_add_wheel_library", wheel.PackageName)
+}
+
+func (js *JobSettings) validateWheelLibraries() error {
+	if js.ExistingClusterID == "" {
+		err := validateWheelLibrary(js.PythonWheelTask, js.Libraries)
+		if err != nil {
+			return err
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.ExistingClusterID != "" {
+			// libraries might be already installed on the cluster
+			continue
+		}
+		err := validateWheelLibrary(task.PythonWheelTask, task.Libraries)
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// validateJarLibrary checks that main class of spark_jar_task could be loaded from jar or maven
+// library, as otherwise the run fails with ClassNotFoundException
+func validateJarLibrary(jarTask *SparkJarTask, libraries []Library) error {
+	if jarTask == nil {
+		return nil
+	}
+	for _, library := range libraries {
+		if library.Jar != "" || library.Maven != nil || library.String() == ":" {
+			// interpolated libraries are not known until apply
+			return nil
+		}
+	}
+	return fmt.Errorf("spark_jar_task requires at least one jar or maven library " +
+		"with the main class. Add it to libraries")
+}
+
+func (js *JobSettings) validateJarLibraries() error {
+	if js.ExistingClusterID == "" {
+		err := validateJarLibrary(js.SparkJarTask, js.Libraries)
+		if err != nil {
+			return err
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.ExistingClusterID != "" {
+			// libraries might be already installed on the cluster
+			continue
+		}
+		libraries := append([]Library{}, task.Libraries...)
+		err := validateJarLibrary(task.SparkJarTask, append(libraries, js.Libraries...))
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// dbtLibrary is the adapter, that runs dbt commands on Databricks clusters
+const dbtLibrary = "dbt-databricks"
+
+// validateDbtTask checks that dbt_task either runs against SQL warehouse or on a new cluster
+// with dbt adapter installed, as otherwise the API accepts the task, but the run fails
+func validateDbtTask(task JobTaskSettings) error {
+	if task.DbtTask == nil || task.DbtTask.WarehouseID != "" {
+		return nil
+	}
+	if task.NewCluster != nil || task.JobClusterKey != "" {
+		for _, library := range task.Libraries {
+			switch {
+			case library.Pypi != nil && library.Pypi.Package != "":
+				if normalizePackageName(pypiDistribution(library.Pypi.Package)) == normalizePackageName(dbtLibrary) {
+					return nil
+				}
+			case library.String() == ":":
+				// interpolated libraries are not known until apply
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("dbt_task must either have warehouse_id or run on new_cluster "+
+		"or job_cluster_key with %s pypi library", dbtLibrary)
+}
+
+// validateDbtTasks skips tasks with warehouse_id, that is known only after apply,
+// like the one of databricks_sql_endpoint created within the same apply
+func (js *JobSettings) validateDbtTasks(d *schema.ResourceDiff) error {
+	for i, task := range js.Tasks {
+		if !d.NewValueKnown(fmt.Sprintf("task.%d.dbt_task.0.warehouse_id", i)) {
+			continue
+		}
+		if err := validateDbtTask(task); err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+func hasLibrary(libraries []Library, library Library) bool {
+	for _, l := range libraries {
+		if l.String() == library.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// addWheelLibrary appends wheel file to libraries and replaces package_name with the
+// distribution name of the wheel, so that the API receives the expected request
+func addWheelLibrary(wheel *PythonWheelTask, libraries *[]Library) error {
+	if wheel == nil || !wheel.AutoAddWheelLibrary {
+		return nil
+	}
+	library, err := wheel.wheelLibrary()
+	if err != nil {
+		return err
+	}
+	if !hasLibrary(*libraries, library) {
+		*libraries = append(*libraries, library)
+	}
+	wheel.PackageName = wheelDistribution(library.Whl)
+	wheel.AutoAddWheelLibrary = false
+	return nil
+}
+
+func (js *JobSettings) addWheelLibraries() error {
+	err := addWheelLibrary(js.PythonWheelTask, &js.Libraries)
+	if err != nil {
+		return err
+	}
+	for i := range js.Tasks {
+		err = addWheelLibrary(js.Tasks[i].PythonWheelTask, &js.Tasks[i].Libraries)
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", js.Tasks[i].TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// removeWheelLibrary reverts addWheelLibrary for the settings read from the API,
+// so that automatically added library doesn't produce a diff
+func removeWheelLibrary(wheel *PythonWheelTask, libraries *[]Library, configured *PythonWheelTask) {
+	if wheel == nil || configured == nil || !configured.AutoAddWheelLibrary {
+		return
+	}
+	library, err := configured.wheelLibrary()
+	if err != nil || wheel.PackageName != wheelDistribution(library.Whl) {
+		return
+	}
+	remaining := []Library{}
+	for _, l := range *libraries {
+		if l.Whl != library.Whl {
+			remaining = append(remaining, l)
+		}
+	}
+	*libraries = remaining
+	wheel.PackageName = configured.PackageName
+	wheel.AutoAddWheelLibrary = true
+}
+
+func (js *JobSettings) removeWheelLibraries(configured JobSettings) {
+	removeWheelLibrary(js.PythonWheelTask, &js.Libraries, configured.PythonWheelTask)
+	configuredTasks := map[string]JobTaskSettings{}
+	for _, task := range configured.Tasks {
+		configuredTasks[task.TaskKey] = task
+	}
+	for i := range js.Tasks {
+		task := &js.Tasks[i]
+		removeWheelLibrary(task.PythonWheelTask, &task.Libraries,
+			configuredTasks[task.TaskKey].PythonWheelTask)
+	}
+}
+
+func (js *JobSettings) validateClusterPolicies(d *schema.ResourceDiff, policies ClusterPoliciesAPI) error {
+	if js.NewCluster != nil {
+		err := validateClusterPolicy(d, "new_cluster.0.policy_id", *js.NewCluster, policies)
+		if err != nil {
+			return fmt.Errorf("invalid job cluster: %w", err)
+		}
+	}
+	for i, jc := range js.JobClusters {
+		if jc.NewCluster == nil {
+			continue
+		}
+		err := validateClusterPolicy(d, fmt.Sprintf("job_cluster.%d.new_cluster.0.policy_id", i),
+			*jc.NewCluster, policies)
+		if err != nil {
+			return fmt.Errorf("job cluster %s invalid: %w", jc.JobClusterKey, err)
+		}
+	}
+	for i, task := range js.Tasks {
+		if task.NewCluster == nil {
+			continue
+		}
+		err := validateClusterPolicy(d, fmt.Sprintf("task.%d.new_cluster.0.policy_id", i),
+			*task.NewCluster, policies)
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// warnAboutClusterReferences logs warnings about existing clusters, that jobs cannot run on
+func (js *JobSettings) warnAboutClusterReferences(d *schema.ResourceDiff, jobs JobsAPI) {
+	references := map[string]string{}
+	if js.ExistingClusterID != "" && d.NewValueKnown("existing_cluster_id") {
+		references["existing_cluster_id"] = js.ExistingClusterID
+	}
+	for i, task := range js.Tasks {
+		key := fmt.Sprintf("task.%d.existing_cluster_id", i)
+		if task.ExistingClusterID != "" && d.NewValueKnown(key) {
+			references[key] = task.ExistingClusterID
+		}
+	}
+	checked := map[string]bool{}
+	for _, clusterID := range references {
+		if checked[clusterID] {
+			continue
+		}
+		checked[clusterID] = true
+		for _, warning := range jobs.ValidateClusterReference(clusterID) {
+			logPlanWarning(warning.Summary)
+		}
+	}
+}
+
+// validateEmailAddress checks that notification recipient is a plain email address,
+// as misspelled recipients silently never receive notifications
+func validateEmailAddress(address string) error {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address {
+		return fmt.Errorf("%s is not a valid email address", address)
+	}
+	domain := address[strings.LastIndex(address, "@")+1:]
+	if !strings.Contains(domain, ".") {
+		return fmt.Errorf("%s is not a valid email address: domain has no top-level domain", address)
+	}
+	return nil
+}
+
+// validateWebhookID checks that notification destination is referenced by its identifier
+func validateWebhookID(id string) error {
+	if applicationIDRegex.FindString(id) != id {
+		return fmt.Errorf("%s is not a valid notification destination id, which must be UUID", id)
+	}
+	return nil
+}
+
+func validateRecipients(field string, recipients []string, validate func(string) error) error {
+	seen := map[string]bool{}
+	for _, recipient := range recipients {
+		if recipient == "" {
+			// interpolated values are not known until apply
+			continue
+		}
+		if seen[recipient] {
+			return fmt.Errorf("%s lists %s more than once", field, recipient)
+		}
+		seen[recipient] = true
+		err := validate(recipient)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+	}
+	return nil
+}
+
+func (en *EmailNotifications) validate() error {
+	if en == nil {
+		return nil
+	}
+	for field, recipients := range map[string][]string{
+		"email_notifications.on_start":   en.OnStart,
+		"email_notifications.on_success": en.OnSuccess,
+		"email_notifications.on_failure": en.OnFailure,
+	} {
+		err := validateRecipients(field, recipients, validateEmailAddress)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (wn *WebhookNotifications) validate() error {
+	if wn == nil {
+		return nil
+	}
+	ids := func(webhooks []Webhook) (ids []string) {
+		for _, webhook := range webhooks {
+			ids = append(ids, webhook.ID)
+		}
+		return
+	}
+	for field, recipients := range map[string][]string{
+		"webhook_notifications.on_start":   ids(wn.OnStart),
+		"webhook_notifications.on_success": ids(wn.OnSuccess),
+		"webhook_notifications.on_failure": ids(wn.OnFailure),
+	} {
+		err := validateRecipients(field, recipients, validateWebhookID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNotificationRecipients checks email addresses and webhook identifiers of job and tasks
+func (js *JobSettings) validateNotificationRecipients() error {
+	err := js.EmailNotifications.validate()
+	if err != nil {
+		return err
+	}
+	err = js.WebhookNotifications.validate()
+	if err != nil {
+		return err
+	}
+	for _, task := range js.Tasks {
+		err = task.EmailNotifications.validate()
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+func (js *JobSettings) validateWebhookNotifications(jobs JobsAPI) error {
+	for _, d := range jobs.ValidateWebhookNotifications(js.WebhookNotifications) {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("invalid webhook_notifications: %s", d.Summary)
+		}
+		logPlanWarning(d.Summary)
+	}
+	return nil
+}
+
+func notebookExists(ctx context.Context, client *common.DatabricksClient,
+	d *schema.ResourceDiff, key, notebookPath string) error {
+	if !d.NewValueKnown(key) {
+		log.Printf("[WARN] Cannot verify that %s exists, because it is not known until apply", key)
+		return nil
+	}
+	var status map[string]interface{}
+	err := client.Get(ctx, "/workspace/get-status", map[string]string{
+		"path": notebookPath,
+	}, &status)
+	if common.IsMissing(err) {
+		return fmt.Errorf("notebook_path %s does not exist", notebookPath)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot get status of %s: %w", notebookPath, err)
+	}
+	return nil
+}
+
+// validateNotebooksExist checks that workspace notebooks of the job exist, so that misspelled
+// paths are reported during plan and not when the job runs for the first time
+func (js *JobSettings) validateNotebooksExist(ctx context.Context, d *schema.ResourceDiff,
+	client *common.DatabricksClient) error {
+	if !client.ValidateNotebookPaths {
+		return nil
+	}
+	hasGitSource := js.GitSource != nil
+	if js.NotebookTask != nil && !js.NotebookTask.isFromGit(hasGitSource) {
+		err := notebookExists(ctx, client, d, "notebook_task.0.notebook_path",
+			js.NotebookTask.NotebookPath)
+		if err != nil {
+			return err
+		}
+	}
+	for i, task := range js.Tasks {
+		if task.NotebookTask == nil || task.NotebookTask.isFromGit(hasGitSource) {
+			continue
+		}
+		err := notebookExists(ctx, client, d, fmt.Sprintf("task.%d.notebook_task.0.notebook_path", i),
+			task.NotebookTask.NotebookPath)
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// validateTaskRetries checks that retry_on_timeout is set only for tasks with timeout. As the
+// attribute is computed, tasks are checked only when either of attributes is changed.
+func (js *JobSettings) validateTaskRetries(d *schema.ResourceDiff) error {
+	for i, task := range js.Tasks {
+		if !task.RetryOnTimeout || task.TimeoutSeconds > 0 {
+			continue
+		}
+		if !d.HasChange(fmt.Sprintf("task.%d.retry_on_timeout", i)) &&
+			!d.HasChange(fmt.Sprintf("task.%d.timeout_seconds", i)) {
+			continue
+		}
+		return fmt.Errorf("task %s invalid: retry_on_timeout requires timeout_seconds "+
+			"to be greater than 0", task.TaskKey)
+	}
+	return nil
+}
+
+// validateRunMode checks how runs of the job are started and how many of them could be active
+// at the same time. Every violated rule is reported at once, as they are often changed together.
+func (js *JobSettings) validateRunMode(alwaysRunning bool) error {
+	violations := []string{}
+	modes := []string{}
+	if js.Schedule != nil {
+		modes = append(modes, "schedule")
+	}
+	if js.Trigger != nil {
+		modes = append(modes, "trigger")
+	}
+	if js.Continuous != nil {
+		modes = append(modes, "continuous")
+	}
+	if len(modes) > 1 {
+		violations = append(violations, fmt.Sprintf("only one of schedule, trigger or continuous "+
+			"blocks could be specified, but got: %s", strings.Join(modes, ", ")))
+	}
+	if js.Continuous != nil && js.MaxConcurrentRuns > 1 {
+		violations = append(violations, fmt.Sprintf("continuous block must be specified only "+
+			"with `max_concurrent_runs = 1`, but got: %d", js.MaxConcurrentRuns))
+	}
+	if alwaysRunning && js.MaxConcurrentRuns > 1 {
+		violations = append(violations, "`always_running` must be specified only with `max_concurrent_runs = 1`")
+	}
+	if alwaysRunning && js.Continuous != nil {
+		violations = append(violations, "`always_running` cannot be used with continuous block, "+
+			"as continuous job always has an active run")
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(violations, "; "))
+}
+
+// validateJobClusters checks that tasks refer only to job clusters defined in the job
+// and that such tasks do not define any other cluster
+func (js *JobSettings) validateJobClusters() error {
+	jobClusterKeys := map[string]bool{}
+	for _, jc := range js.JobClusters {
+		if jobClusterKeys[jc.JobClusterKey] {
+			return fmt.Errorf("job_cluster_key %s is defined more than once", jc.JobClusterKey)
+		}
+		jobClusterKeys[jc.JobClusterKey] = true
+		if jc.NewCluster == nil {
+			continue
+		}
+		err := validateClusterDefinition(*jc.NewCluster)
+		if err != nil {
+			return fmt.Errorf("job cluster %s invalid: %w", jc.JobClusterKey, err)
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.JobClusterKey == "" {
+			continue
+		}
+		if task.ExistingClusterID != "" || task.NewCluster != nil {
+			return fmt.Errorf("task %s invalid: job_cluster_key cannot be used together "+
+				"with existing_cluster_id or new_cluster", task.TaskKey)
+		}
+		if !jobClusterKeys[task.JobClusterKey] {
+			return fmt.Errorf("task %s invalid: job_cluster_key %s is not defined "+
+				"in any job_cluster block", task.TaskKey, task.JobClusterKey)
+		}
+	}
+	return nil
+}
+
+// UnusedJobClusters returns keys of job clusters, that are not referenced by any task
+func (js JobSettings) UnusedJobClusters() []string {
+	used := map[string]bool{}
+	for _, task := range js.Tasks {
+		used[task.JobClusterKey] = true
+	}
+	unused := []string{}
+	for _, jc := range js.JobClusters {
+		if !used[jc.JobClusterKey] {
+			unused = append(unused, jc.JobClusterKey)
+		}
+	}
+	return unused
+}
+
+func (js *JobSettings) unusedJobClustersWarning() string {
+	unused := js.UnusedJobClusters()
+	if len(unused) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("job_cluster %s is not used by any task and could be removed",
+		strings.Join(unused, ", "))
+}
+
+// sparkSubmitManagedParameters are set by Databricks and cannot be overridden
+var sparkSubmitManagedParameters = []string{"--master", "--deploy-mode"}
+
+// validate checks constraints of spark_submit_task, that are otherwise reported
+// by the API only when the job runs
+func (sst *SparkSubmitTask) validate(existingClusterID, jobClusterKey string, libraries []Library) error {
+	if existingClusterID != "" || jobClusterKey != "" {
+		return fmt.Errorf("spark_submit_task can only run on new_cluster, " +
+			"not on existing_cluster_id or job_cluster_key")
+	}
+	if len(libraries) > 0 {
+		return fmt.Errorf("spark_submit_task cannot be combined with libraries, " +
+			"use --jars or --py-files parameters instead")
+	}
+	for _, parameter := range sst.Parameters {
+		for _, managed := range sparkSubmitManagedParameters {
+			if parameter == managed || strings.HasPrefix(parameter, managed+"=") {
+				return fmt.Errorf("spark_submit_task parameters cannot contain %s, "+
+					"as it's set by Databricks", managed)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSparkSubmitTasks checks spark_submit_task of the job and of its tasks
+func (js *JobSettings) validateSparkSubmitTasks() error {
+	if js.SparkSubmitTask != nil {
+		err := js.SparkSubmitTask.validate(js.ExistingClusterID, "", js.Libraries)
+		if err != nil {
+			return err
+		}
+	}
+	for _, task := range js.Tasks {
+		if task.SparkSubmitTask == nil {
+			continue
+		}
+		err := task.SparkSubmitTask.validate(task.ExistingClusterID, task.JobClusterKey, task.Libraries)
+		if err != nil {
+			return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+		}
+	}
+	return nil
+}
+
+// sparkSubmitWarning returns advice for spark_submit_task on autoscaling cluster,
+// as spark-submit doesn't support autoscaling
+func (js *JobSettings) sparkSubmitWarning() string {
+	if js.SparkSubmitTask != nil && js.NewCluster != nil && js.NewCluster.Autoscale != nil {
+		return "spark_submit_task doesn't support autoscaling, use num_workers for new_cluster instead"
+	}
+	for _, task := range js.Tasks {
+		if task.SparkSubmitTask != nil && task.NewCluster != nil && task.NewCluster.Autoscale != nil {
+			return fmt.Sprintf("task %s: spark_submit_task doesn't support autoscaling, "+
+				"use num_workers for new_cluster instead", task.TaskKey)
+		}
+	}
+	return ""
+}
+
+// typicalClusterSpinUp is the usual time for a new cluster without instance pool to start
+const typicalClusterSpinUp = 5 * time.Minute
+
+// quartzFieldStep returns the smallest step between values of quartz cron field
+// and whether the field matches more than one value
+func quartzFieldStep(field string) (int, bool) {
+	switch {
+	case field == "*":
+		return 1, true
+	case strings.Contains(field, "/"):
+		step, err := strconv.Atoi(strings.SplitN(field, "/", 2)[1])
+		if err != nil || step < 1 {
+			return 1, true
+		}
+		return step, true
+	case strings.Contains(field, "-"):
+		return 1, true
+	case strings.Contains(field, ","):
+		values := []int{}
+		for _, v := range strings.Split(field, ",") {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return 1, true
+			}
+			values = append(values, i)
+		}
+		sort.Ints(values)
+		step := 0
+		for i := 1; i < len(values); i++ {
+			if diff := values[i] - values[i-1]; diff > 0 && (step == 0 || diff < step) {
+				step = diff
+			}
+		}
+		return step, step > 0
+	}
+	return 0, false
+}
+
+// minScheduleInterval estimates the shortest interval between runs of quartz cron expression,
+// like "0 */5 * * * ?". Schedules, that fire only once per hour or less often, are reported
+// with the precision of hours.
+func minScheduleInterval(quartzCronExpression string) (time.Duration, error) {
+	fields := strings.Fields(quartzCronExpression)
+	if len(fields) < 6 {
+		return 0, fmt.Errorf("invalid quartz cron expression: %s", quartzCronExpression)
+	}
+	for i, unit := range []time.Duration{time.Second, time.Minute, time.Hour} {
+		if step, ok := quartzFieldStep(fields[i]); ok {
+			return time.Duration(step) * unit, nil
+		}
+	}
+	return 24 * time.Hour, nil
+}
+
+// hasNewClusterWithoutPool returns true if job starts clusters, that are not backed by pools
+func (js *JobSettings) hasNewClusterWithoutPool() bool {
+	clusters := []*Cluster{js.NewCluster}
+	for _, task := range js.Tasks {
+		clusters = append(clusters, task.NewCluster)
+	}
+	for _, jc := range js.JobClusters {
+		clusters = append(clusters, jc.NewCluster)
+	}
+	for _, cluster := range clusters {
+		if cluster != nil && cluster.InstancePoolID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleWarning returns advice for jobs, that are scheduled so often, that new clusters
+// for the runs spend most of the time starting up
+func (js *JobSettings) scheduleWarning() string {
+	if js.Schedule == nil || !js.hasNewClusterWithoutPool() {
+		return ""
+	}
+	interval, err := minScheduleInterval(js.Schedule.QuartzCronExpression)
+	if err != nil || interval >= 2*typicalClusterSpinUp {
+		return ""
+	}
+	return fmt.Sprintf("job is scheduled to run every %s, but new clusters usually take "+
+		"about %s to start. Consider using instance_pool_id for new_cluster, an existing "+
+		"cluster or always_running job instead", interval, typicalClusterSpinUp)
+}
+
+// longestTaskPath returns the task keys and the sum of task timeouts of the dependency
+// path, that may take the longest. Tasks without timeout don't add to the sum.
+func (js *JobSettings) longestTaskPath() (path []string, seconds int32) {
+	tasks := map[string]JobTaskSettings{}
+	for _, task := range js.Tasks {
+		tasks[task.TaskKey] = task
+	}
+	type longest struct {
+		path    []string
+		seconds int32
+	}
+	memo := map[string]longest{}
+	visiting := map[string]bool{}
+	var visit func(key string) longest
+	visit = func(key string) longest {
+		if l, ok := memo[key]; ok {
+			return l
+		}
+		task, ok := tasks[key]
+		if !ok || visiting[key] {
+			// unknown dependencies and cycles are reported by the API
+			return longest{}
+		}
+		visiting[key] = true
+		var upstream longest
+		for _, dep := range task.DependsOn {
+			if l := visit(dep.TaskKey); l.seconds > upstream.seconds {
+				upstream = l
+			}
+		}
+		visiting[key] = false
+		l := longest{
+			path:    append(append([]string{}, upstream.path...), key),
+			seconds: upstream.seconds + task.TimeoutSeconds,
+		}
+		memo[key] = l
+		return l
+	}
+	for _, task := range js.Tasks {
+		if l := visit(task.TaskKey); l.seconds > seconds {
+			path, seconds = l.path, l.seconds
+		}
+	}
+	return
+}
+
+// timeoutWarning returns advice for jobs, that time out before tasks on the longest
+// dependency path could use their own timeouts. It's only advisory, as tasks usually
+// complete faster than their timeouts.
+func (js *JobSettings) timeoutWarning() string {
+	if js.TimeoutSeconds == 0 {
+		return ""
+	}
+	path, seconds := js.longestTaskPath()
+	if seconds <= js.TimeoutSeconds {
+		return ""
+	}
+	return fmt.Sprintf("job timeout_seconds is %d, but tasks %s may run for up to %d seconds "+
+		"according to their timeout_seconds, so the job may time out before they complete",
+		js.TimeoutSeconds, strings.Join(path, " -> "), seconds)
+}
+
+// normalizeRunAs sends only application ID of service principal to the API
+func (js *JobSettings) normalizeRunAs() {
+	if js.RunAs == nil || js.RunAs.ServicePrincipalName == "" {
+		return
+	}
+	js.RunAs.ServicePrincipalName = effectiveServicePrincipalID(js.RunAs.ServicePrincipalName)
+}
+
 var jobSchema = common.StructToSchema(JobSettings{},
 	func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		jobSettingsSchema(&s, "")
 		jobSettingsSchema(&s["task"].Elem.(*schema.Resource).Schema, "task.0.")
-		if p, err := common.SchemaPath(s, "schedule", "pause_status"); err == nil {
-			p.ValidateFunc = validation.StringInSlice([]string{"PAUSED", "UNPAUSED"}, false)
+		jobSettingsSchema(&s["job_cluster"].Elem.(*schema.Resource).Schema, "job_cluster.0.")
+		if p, err := common.SchemaPath(s, "task", "task_key"); err == nil {
+			p.ValidateFunc = validateTaskKey
+		}
+		if p, err := common.SchemaPath(s, "task", "depends_on", "task_key"); err == nil {
+			p.ValidateFunc = validateTaskKey
+		}
+		if p, err := common.SchemaPath(s, "task", "max_retries"); err == nil {
+			// -1 retries indefinitely
+			p.ValidateFunc = validation.Any(validation.IntInSlice([]int{-1}),
+				validation.IntBetween(0, maxTaskRetries))
+		}
+		for _, attr := range [][]string{{"notebook_task", "source"}, {"task", "notebook_task", "source"}} {
+			if p, err := common.SchemaPath(s, attr...); err == nil {
+				p.ValidateFunc = validation.StringInSlice([]string{
+					NotebookSourceGit, NotebookSourceWorkspace}, false)
+			}
+		}
+		for _, block := range []string{"schedule", "trigger", "continuous"} {
+			if p, err := common.SchemaPath(s, block, "pause_status"); err == nil {
+				p.ValidateFunc = validation.StringInSlice([]string{"PAUSED", "UNPAUSED"}, false)
+			}
+		}
+		if p, err := common.SchemaPath(s, "run_as", "service_principal_name"); err == nil {
+			p.DiffSuppressFunc = runAsServicePrincipalSuppressFunc
+			p.ConflictsWith = []string{"run_as.0.user_name"}
+		}
+		if p, err := common.SchemaPath(s, "run_as", "user_name"); err == nil {
+			p.ConflictsWith = []string{"run_as.0.service_principal_name"}
 		}
 		s["max_concurrent_runs"].ValidateDiagFunc = validation.ToDiagFunc(validation.IntAtLeast(1))
 		s["max_concurrent_runs"].Default = 1
@@ -264,10 +1358,46 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			alwaysRunning := d.Get("always_running").(bool)
-			if alwaysRunning && js.MaxConcurrentRuns > 1 {
-				return fmt.Errorf("`always_running` must be specified only with `max_concurrent_runs = 1`")
+			err = js.validateRunMode(d.Get("always_running").(bool))
+			if err != nil {
+				return err
+			}
+			err = js.validateTaskPaths()
+			if err != nil {
+				return err
+			}
+			err = js.validateJobClusters()
+			if err != nil {
+				return err
+			}
+			err = js.validateWheelLibraries()
+			if err != nil {
+				return err
+			}
+			err = js.validateJarLibraries()
+			if err != nil {
+				return err
+			}
+			err = js.validateDbtTasks(d)
+			if err != nil {
+				return err
+			}
+			err = js.validateNotificationRecipients()
+			if err != nil {
+				return err
+			}
+			err = js.validateTaskRetries(d)
+			if err != nil {
+				return err
 			}
+			err = js.validateSparkSubmitTasks()
+			if err != nil {
+				return err
+			}
+			logPlanWarning(js.scheduleWarning())
+			logPlanWarning(js.sparkSubmitWarning())
+			logPlanWarning(js.timeoutWarning())
+			logPlanWarning(js.unusedJobClustersWarning())
 			for _, task := range js.Tasks {
 				if task.NewCluster == nil {
 					continue
@@ -283,7 +1413,17 @@ func ResourceJob() *schema.Resource {
 					return fmt.Errorf("invalid job cluster: %w", err)
 				}
 			}
-			return nil
+			err = js.validateClusterPolicies(d, NewClusterPoliciesAPI(ctx, m))
+			if err != nil {
+				return err
+			}
+			jobsAPI := NewJobsAPI(ctx, m)
+			js.warnAboutClusterReferences(d, jobsAPI)
+			err = js.validateWebhookNotifications(jobsAPI)
+			if err != nil {
+				return err
+			}
+			return js.validateNotebooksExist(ctx, d, m.(*common.DatabricksClient))
 		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var js JobSettings
@@ -291,9 +1431,15 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			js.normalizeRunAs()
+			err = js.addWheelLibraries()
+			if err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
+			js.stripFormat()
 			jobsAPI := NewJobsAPI(ctx, c)
 			job, err := jobsAPI.Create(js)
 			if err != nil {
@@ -311,6 +1457,10 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			var configured JobSettings
+			if common.DataToStructPointer(d, jobSchema, &configured) == nil {
+				job.Settings.removeWheelLibraries(configured)
+			}
 			d.Set("url", c.FormatURL("#job/", d.Id()))
 			return common.StructToData(*job.Settings, jobSchema, d)
 		},
@@ -320,9 +1470,15 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			js.normalizeRunAs()
+			err = js.addWheelLibraries()
+			if err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
+			js.stripFormat()
 			jobsAPI := NewJobsAPI(ctx, c)
 			err = jobsAPI.Update(d.Id(), js)
 			if err != nil {
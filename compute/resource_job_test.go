@@ -8,13 +8,28 @@ import (
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// existingClusterFixture is used to verify existing_cluster_id of jobs during plan
+var existingClusterFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+	Response: ClusterInfo{
+		ClusterID: "abc",
+		State:     ClusterStateRunning,
+	},
+}
+
 func TestResourceJobCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/jobs/create",
@@ -108,6 +123,7 @@ func TestResourceJobCreate(t *testing.T) {
 func TestResourceJobCreate_MultiTask(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.1/jobs/create",
@@ -205,9 +221,117 @@ func TestResourceJobCreate_MultiTask(t *testing.T) {
 	assert.Equal(t, "789", d.Id())
 }
 
+func TestResourceJobCreate_TaskNotificationSettings(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					EmailNotifications: &EmailNotifications{
+						OnFailure: []string{"team@example.com"},
+					},
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							SparkJarTask: &SparkJarTask{
+								MainClassName: "com.labs.BarMain",
+							},
+							MaxRetries: 3,
+							EmailNotifications: &EmailNotifications{
+								OnFailure: []string{"oncall@example.com"},
+							},
+							NotificationSettings: &TaskNotificationSettings{
+								NoAlertForCanceledRuns: true,
+								AlertOnLastAttempt:     true,
+							},
+						},
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						EmailNotifications: &EmailNotifications{
+							OnFailure: []string{"team@example.com"},
+						},
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								SparkJarTask: &SparkJarTask{
+									MainClassName: "com.labs.BarMain",
+								},
+								MaxRetries: 3,
+								EmailNotifications: &EmailNotifications{
+									OnFailure: []string{"oncall@example.com"},
+								},
+								NotificationSettings: &TaskNotificationSettings{
+									NoAlertForCanceledRuns: true,
+									AlertOnLastAttempt:     true,
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+
+		email_notifications {
+			on_failure = ["team@example.com"]
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			max_retries = 3
+
+			spark_jar_task {
+				main_class_name = "com.labs.BarMain"
+			}
+
+			email_notifications {
+				on_failure = ["oncall@example.com"]
+			}
+
+			notification_settings {
+				no_alert_for_canceled_runs = true
+				alert_on_last_attempt = true
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "team@example.com", d.Get("email_notifications.0.on_failure.0"))
+	assert.Equal(t, "oncall@example.com", d.Get("task.0.email_notifications.0.on_failure.0"))
+	assert.Equal(t, true, d.Get("task.0.notification_settings.0.alert_on_last_attempt"))
+	assert.Equal(t, true, d.Get("task.0.notification_settings.0.no_alert_for_canceled_runs"))
+	assert.Equal(t, false, d.Get("task.0.notification_settings.0.no_alert_for_skipped_runs"))
+
+	// removal of task notification settings must not be hidden like removal of email_notifications
+	taskSchema := ResourceJob().Schema["task"].Elem.(*schema.Resource).Schema
+	assert.Nil(t, taskSchema["notification_settings"].DiffSuppressFunc)
+}
+
 func TestResourceJobCreate_AlwaysRunning(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/jobs/create",
@@ -306,6 +430,9 @@ func TestResourceJobCreateSingleNode(t *testing.T) {
 					SparkJarTask: &SparkJarTask{
 						MainClassName: "com.labs.BarMain",
 					},
+					Libraries: []Library{
+						{Jar: "dbfs://aa/bb/cc.jar"},
+					},
 					Name:                   "Featurizer",
 					MaxRetries:             3,
 					MinRetryIntervalMillis: 5000,
@@ -357,6 +484,9 @@ func TestResourceJobCreateSingleNode(t *testing.T) {
 
 		spark_jar_task {
 			main_class_name = "com.labs.BarMain"
+		}
+		library {
+			jar = "dbfs://aa/bb/cc.jar"
 		}`,
 	}.Apply(t)
 	assert.NoError(t, err, err)
@@ -377,6 +507,9 @@ func TestResourceJobCreateNWorkers(t *testing.T) {
 					SparkJarTask: &SparkJarTask{
 						MainClassName: "com.labs.BarMain",
 					},
+					Libraries: []Library{
+						{Jar: "dbfs://aa/bb/cc.jar"},
+					},
 					Name:                   "Featurizer",
 					MaxRetries:             3,
 					MinRetryIntervalMillis: 5000,
@@ -421,6 +554,9 @@ func TestResourceJobCreateNWorkers(t *testing.T) {
 
 		spark_jar_task {
 			main_class_name = "com.labs.BarMain"
+		}
+		library {
+			jar = "dbfs://aa/bb/cc.jar"
 		}`,
 	}.Apply(t)
 	assert.NoError(t, err, err)
@@ -512,6 +648,77 @@ func TestResourceJobRead(t *testing.T) {
 	assert.Equal(t, "abc", d.Get("existing_cluster_id"))
 }
 
+func TestResourceJobImport_SingleTask(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Featurizer",
+						},
+						Name:              "Featurizer",
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		New:      true,
+		ID:       "789",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "SINGLE_TASK", d.Get("format"))
+	assert.Equal(t, "/Featurizer", d.Get("notebook_task.0.notebook_path"))
+}
+
+func TestResourceJobImport_MultiTask(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "b",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Featurizer",
+								},
+							},
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Ingest",
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		New:      true,
+		ID:       "789",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "MULTI_TASK", d.Get("format"))
+	assert.Equal(t, 2, d.Get("task.#"))
+	assert.Equal(t, "a", d.Get("task.0.task_key"))
+}
+
 func TestResourceJobRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -558,6 +765,7 @@ func TestResourceJobRead_Error(t *testing.T) {
 func TestResourceJobUpdate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/jobs/reset",
@@ -642,6 +850,7 @@ func TestResourceJobUpdate(t *testing.T) {
 func TestResourceJobUpdate_Tasks(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
 			{
 				Method:   "POST",
 				Resource: "/api/2.1/jobs/reset",
@@ -695,6 +904,76 @@ func TestResourceJobUpdate_Tasks(t *testing.T) {
 	}.ApplyNoError(t)
 }
 
+func TestResourceJobUpdate_ImportedSingleTaskToTasks(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Featurizer",
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Featurizer",
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+						Format:            "MULTI_TASK",
+					},
+				},
+			},
+		},
+		ID:     "789",
+		Update: true,
+		// format was filled by normalizeFormat on import
+		InstanceState: map[string]string{
+			"name":                          "Featurizer",
+			"format":                        "SINGLE_TASK",
+			"existing_cluster_id":           "abc",
+			"max_concurrent_runs":           "1",
+			"notebook_task.#":               "1",
+			"notebook_task.0.notebook_path": "/Featurizer",
+		},
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+		max_concurrent_runs = 1
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Featurizer"
+			}
+		}`,
+	}.ApplyNoError(t)
+}
+
 func TestResourceJobUpdate_Restart(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -962,6 +1241,9 @@ func TestResourceJobUpdate_FailNumWorkersZero(t *testing.T) {
 		spark_jar_task {
 			main_class_name = "com.labs.BarMain"
 			parameters = ["--cleanup", "full"]
+		}
+		library {
+			jar = "dbfs://aa/bb/cc.jar"
 		}`,
 	}.Apply(t)
 	assert.Error(t, err, err)
@@ -1035,3 +1317,1704 @@ func TestJobResource_SparkConfDiffSuppress(t *testing.T) {
 	assert.True(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "0", nil))
 	assert.False(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "1", nil))
 }
+
+func TestJobResource_RunAsServicePrincipalDiffSuppress(t *testing.T) {
+	jr := ResourceJob()
+	rsp := common.MustSchemaPath(jr.Schema, "run_as", "service_principal_name")
+	k := "run_as.0.service_principal_name"
+	appID := "9f0621ee-b52b-11ea-b3de-0242ac130004"
+	assert.True(t, rsp.DiffSuppressFunc(k, "Data Pipelines ("+appID+")", appID, nil))
+	assert.True(t, rsp.DiffSuppressFunc(k, strings.ToUpper(appID), appID, nil))
+	assert.True(t, rsp.DiffSuppressFunc(k, " "+appID+" ", appID, nil))
+	assert.False(t, rsp.DiffSuppressFunc(k, "Data Pipelines ("+appID+")",
+		"0b9a3a1c-b52c-11ea-b3de-0242ac130004", nil))
+	assert.False(t, rsp.DiffSuppressFunc(k, "", appID, nil))
+}
+
+func TestResourceJobUpdate_RunAsServicePrincipalEnriched(t *testing.T) {
+	appID := "9f0621ee-b52b-11ea-b3de-0242ac130004"
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Shared/Featurizer",
+						},
+						Name:              "Featurizer New",
+						MaxConcurrentRuns: 1,
+						RunAs: &JobRunAs{
+							ServicePrincipalName: appID,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Shared/Featurizer",
+						},
+						Name:              "Featurizer New",
+						MaxConcurrentRuns: 1,
+						RunAs: &JobRunAs{
+							ServicePrincipalName: "Data Pipelines (" + strings.ToUpper(appID) + ")",
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"run_as.#":                        "1",
+			"run_as.0.service_principal_name": "Data Pipelines (" + strings.ToUpper(appID) + ")",
+		},
+		HCL: `existing_cluster_id = "abc"
+		name = "Featurizer New"
+
+		notebook_task {
+			notebook_path = "/Shared/Featurizer"
+		}
+
+		run_as {
+			service_principal_name = "` + appID + `"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id(), "Id should be the same as in reading")
+}
+
+func TestResourceJobCreate_Description(t *testing.T) {
+	settings := JobSettings{
+		ExistingClusterID: "abc",
+		NotebookTask: &NotebookTask{
+			NotebookPath: "/Shared/Featurizer",
+		},
+		Name:              "Featurizer",
+		Description:       "Computes features for the model",
+		MaxConcurrentRuns: 1,
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/jobs/create",
+				ExpectedRequest: settings,
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		name = "Featurizer"
+		description = "Computes features for the model"
+
+		notebook_task {
+			notebook_path = "/Shared/Featurizer"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "Computes features for the model", d.Get("description"))
+}
+
+func TestResourceJobUpdate_RemoveDescription(t *testing.T) {
+	// reset replaces all settings, so absent description clears it in the workspace
+	settings := JobSettings{
+		ExistingClusterID: "abc",
+		NotebookTask: &NotebookTask{
+			NotebookPath: "/Shared/Featurizer",
+		},
+		Name:              "Featurizer",
+		MaxConcurrentRuns: 1,
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: map[string]interface{}{
+					"job_id": 789,
+					"new_settings": map[string]interface{}{
+						"existing_cluster_id": "abc",
+						"notebook_task": map[string]interface{}{
+							"notebook_path": "/Shared/Featurizer",
+						},
+						"name":                "Featurizer",
+						"max_concurrent_runs": 1,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"description": "Computes features for the model",
+		},
+		HCL: `existing_cluster_id = "abc"
+		name = "Featurizer"
+
+		notebook_task {
+			notebook_path = "/Shared/Featurizer"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "", d.Get("description"))
+}
+
+func TestValidateNotebookPath(t *testing.T) {
+	assert.NoError(t, validateNotebookPath("/Users/foo@example.com/Featurizer", false))
+	assert.NoError(t, validateNotebookPath("/Repos/foo@example.com/project/Featurizer", false))
+	assert.NoError(t, validateNotebookPath("notebooks/Featurizer", true))
+	assert.EqualError(t, validateNotebookPath("notebooks/Featurizer", false),
+		"notebook_path must be an absolute workspace path, like /Users/..., /Repos/... "+
+			"or /Shared/..., unless git_source is specified, got: notebooks/Featurizer")
+	assert.EqualError(t, validateNotebookPath("/Shared/Featurizer", true),
+		"notebook_path must be relative to the repository root when git_source "+
+			"is specified, got: /Shared/Featurizer")
+	assert.EqualError(t, validateNotebookPath("notebooks/../Featurizer", true),
+		"notebook_path must be a clean relative path, got: notebooks/../Featurizer")
+	assert.EqualError(t, validateNotebookPath("../Featurizer", true),
+		"notebook_path must be a clean relative path, got: ../Featurizer")
+}
+
+func TestValidateNotebookTaskSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		task         NotebookTask
+		hasGitSource bool
+		err          string
+	}{
+		{
+			name:         "GIT with git_source",
+			task:         NotebookTask{NotebookPath: "notebooks/Featurizer", Source: NotebookSourceGit},
+			hasGitSource: true,
+		},
+		{
+			name: "WORKSPACE with absolute path",
+			task: NotebookTask{NotebookPath: "/Shared/Featurizer", Source: NotebookSourceWorkspace},
+		},
+		{
+			name:         "WORKSPACE with absolute path and git_source",
+			task:         NotebookTask{NotebookPath: "/Shared/Featurizer", Source: NotebookSourceWorkspace},
+			hasGitSource: true,
+		},
+		{
+			name: "GIT without git_source",
+			task: NotebookTask{NotebookPath: "notebooks/Featurizer", Source: NotebookSourceGit},
+			err: "notebook_task with source = GIT requires git_source block, " +
+				"use source = WORKSPACE for workspace notebooks",
+		},
+		{
+			name:         "WORKSPACE with relative path",
+			task:         NotebookTask{NotebookPath: "notebooks/Featurizer", Source: NotebookSourceWorkspace},
+			hasGitSource: true,
+			err: "notebook_path must be an absolute workspace path, like /Users/..., /Repos/... " +
+				"or /Shared/..., unless git_source is specified, got: notebooks/Featurizer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNotebookTask(&tt.task, tt.hasGitSource)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestResourceJobCreate_GitNotebookSourceWithoutGitSource(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "notebooks/Featurizer"
+				source = "GIT"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: notebook_task with source = GIT requires git_source block, "+
+		"use source = WORKSPACE for workspace notebooks")
+}
+
+func TestValidatePythonFile(t *testing.T) {
+	for _, pythonFile := range []string{
+		"/Workspace/Users/foo@example.com/main.py",
+		"dbfs:/FileStore/main.py",
+		"s3://bucket/main.py",
+		"abfss://container@account.dfs.core.windows.net/main.py",
+		"gs://bucket/main.py",
+	} {
+		assert.NoError(t, validatePythonFile(pythonFile, false), pythonFile)
+		assert.NoError(t, validatePythonFile(pythonFile, true), pythonFile)
+	}
+	assert.NoError(t, validatePythonFile("jobs/main.py", true))
+	assert.EqualError(t, validatePythonFile("jobs/main.py", false),
+		"python_file must start with /Workspace/, dbfs:/, s3://, abfss://, gs://, or be "+
+			"relative to the repository root when git_source is specified, got: jobs/main.py")
+	assert.EqualError(t, validatePythonFile("http://example.com/main.py", true),
+		"python_file must start with /Workspace/, dbfs:/, s3://, abfss://, gs://, or be "+
+			"relative to the repository root when git_source is specified, got: http://example.com/main.py")
+	assert.EqualError(t, validatePythonFile("../main.py", true),
+		"python_file must start with /Workspace/, dbfs:/, s3://, abfss://, gs://, or be "+
+			"relative to the repository root when git_source is specified, got: ../main.py")
+}
+
+func TestValidateTaskKey(t *testing.T) {
+	_, errs := validateTaskKey("ingest_raw-data_01", "task_key")
+	assert.Len(t, errs, 0)
+
+	_, errs = validateTaskKey("ingest raw data", "task_key")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "task_key must contain only letters, digits, "+
+		"hyphens and underscores, got: ingest raw data")
+
+	long := strings.Repeat("a", maxTaskKeyLength+1)
+	_, errs = validateTaskKey(long, "task_key")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "task_key must be at most 100 characters long, got 101: "+long)
+}
+
+func TestResourceJobCreate_InvalidTaskKey(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}
+		task {
+			task_key = "load data"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+			depends_on {
+				task_key = "a"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. [task.#.task_key] task.1.task_key must "+
+		"contain only letters, digits, hyphens and underscores, got: load data")
+}
+
+func TestResourceJobCreate_InvalidPythonFileInTask(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			spark_python_task {
+				python_file = "/Users/foo@example.com/main.py"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: python_file must start with /Workspace/, dbfs:/, s3://, "+
+		"abfss://, gs://, or be relative to the repository root when git_source is specified, "+
+		"got: /Users/foo@example.com/main.py")
+}
+
+func TestResourceJobCreate_RelativeNotebookPathWithoutGitSource(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "notebooks/Featurizer"
+		}`,
+	}.ExpectError(t, "notebook_path must be an absolute workspace path, like /Users/..., "+
+		"/Repos/... or /Shared/..., unless git_source is specified, got: notebooks/Featurizer")
+}
+
+func TestResourceJobCreate_RelativeNotebookPathInTaskWithoutGitSource(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "Featurizer"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: notebook_path must be an absolute workspace path, "+
+		"like /Users/..., /Repos/... or /Shared/..., unless git_source is specified, got: Featurizer")
+}
+
+func TestResourceJobCreate_RelativeNotebookPathWithGitSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "notebooks/Featurizer",
+							},
+						},
+					},
+					GitSource: &GitSource{
+						URL:      "https://github.com/example/project",
+						Provider: "gitHub",
+						Branch:   "main",
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "notebooks/Featurizer",
+								},
+							},
+						},
+						GitSource: &GitSource{
+							URL:      "https://github.com/example/project",
+							Provider: "gitHub",
+							Branch:   "main",
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+
+		git_source {
+			url = "https://github.com/example/project"
+			provider = "gitHub"
+			branch = "main"
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "notebooks/Featurizer"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "main", d.Get("git_source.0.branch"))
+}
+
+func TestResourceJobCreate_TaskClusterPolicyViolation(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Definition: `{"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]}}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				policy_id = "abc"
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.2xlarge"
+				num_workers = 1
+			}
+			notebook_task {
+				notebook_path = "/Featurizer"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: cluster does not conform to policy abc: "+
+		"node_type_id must be one of [i3.xlarge]")
+}
+
+func TestResourceJobCreate_ClusterPolicyNotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Policy abc does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		new_cluster {
+			policy_id = "abc"
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "i3.xlarge"
+			num_workers = 1
+		}
+		notebook_task {
+			notebook_path = "/Featurizer"
+		}`,
+	}.ExpectError(t, "invalid job cluster: cannot get cluster policy abc: Policy abc does not exist")
+}
+
+func TestResourceJobDiff_UnknownClusterPolicy(t *testing.T) {
+	_, err := ResourceJob().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(
+		map[string]interface{}{
+			"task": []interface{}{
+				map[string]interface{}{
+					"task_key": "a",
+					"new_cluster": []interface{}{
+						map[string]interface{}{
+							// policy is created within the same apply
+							"policy_id":     "74D93920-ED26-11E3-AC10-0800200C9A66",
+							"spark_version": "7.3.x-scala2.12",
+							"node_type_id":  "i3.xlarge",
+							"num_workers":   1,
+						},
+					},
+					"notebook_task": []interface{}{
+						map[string]interface{}{
+							"notebook_path": "/Featurizer",
+						},
+					},
+				},
+			},
+		}), &common.DatabricksClient{})
+	assert.NoError(t, err)
+}
+
+func TestResourceJobCreate_JobClusters(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:       "a",
+							JobClusterKey: "shared",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Ingest",
+							},
+						},
+						{
+							TaskKey:       "b",
+							JobClusterKey: "shared",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Featurizer",
+							},
+						},
+					},
+					JobClusters: []JobCluster{
+						{
+							JobClusterKey: "shared",
+							NewCluster: &Cluster{
+								SparkVersion: "7.3.x-scala2.12",
+								NodeTypeID:   "i3.xlarge",
+								NumWorkers:   2,
+							},
+						},
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:       "a",
+								JobClusterKey: "shared",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Ingest",
+								},
+							},
+							{
+								TaskKey:       "b",
+								JobClusterKey: "shared",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Featurizer",
+								},
+							},
+						},
+						JobClusters: []JobCluster{
+							{
+								JobClusterKey: "shared",
+								NewCluster: &Cluster{
+									SparkVersion: "7.3.x-scala2.12",
+									NodeTypeID:   "i3.xlarge",
+									NumWorkers:   2,
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 2
+			}
+		}
+
+		task {
+			task_key = "a"
+			job_cluster_key = "shared"
+			notebook_task {
+				notebook_path = "/Ingest"
+			}
+		}
+
+		task {
+			task_key = "b"
+			job_cluster_key = "shared"
+			notebook_task {
+				notebook_path = "/Featurizer"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "shared", d.Get("job_cluster.0.job_cluster_key"))
+	assert.Equal(t, "shared", d.Get("task.1.job_cluster_key"))
+}
+
+func TestResourceJobCreate_UndefinedJobClusterKey(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 2
+			}
+		}
+
+		task {
+			task_key = "a"
+			job_cluster_key = "sharde"
+			notebook_task {
+				notebook_path = "/Ingest"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: job_cluster_key sharde is not defined in any job_cluster block")
+}
+
+func TestResourceJobCreate_JobClusterKeyWithExistingCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 2
+			}
+		}
+
+		task {
+			task_key = "a"
+			job_cluster_key = "shared"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Ingest"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: job_cluster_key cannot be used together "+
+		"with existing_cluster_id or new_cluster")
+}
+
+func TestResourceJobCreate_DuplicateJobClusterKey(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 2
+			}
+		}
+
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 4
+			}
+		}
+
+		task {
+			task_key = "a"
+			job_cluster_key = "shared"
+			notebook_task {
+				notebook_path = "/Ingest"
+			}
+		}`,
+	}.ExpectError(t, "job_cluster_key shared is defined more than once")
+}
+
+func TestMinScheduleInterval(t *testing.T) {
+	for expression, expected := range map[string]time.Duration{
+		"0 0 12 * * ?":         24 * time.Hour,
+		"0 0 */2 * * ?":        2 * time.Hour,
+		"0 * * * * ?":          time.Minute,
+		"0 */15 * * * ?":       15 * time.Minute,
+		"0 0,10,30 * * * ?":    10 * time.Minute,
+		"0 0 9-17 ? * MON-FRI": time.Hour,
+		"*/30 * * * * ?":       30 * time.Second,
+	} {
+		interval, err := minScheduleInterval(expression)
+		assert.NoError(t, err, expression)
+		assert.Equal(t, expected, interval, expression)
+	}
+	_, err := minScheduleInterval("* * *")
+	assert.EqualError(t, err, "invalid quartz cron expression: * * *")
+}
+
+func TestJobScheduleWarning(t *testing.T) {
+	js := JobSettings{
+		NewCluster: &Cluster{
+			SparkVersion: "7.3.x-scala2.12",
+			NodeTypeID:   "i3.xlarge",
+			NumWorkers:   2,
+		},
+		Schedule: &CronSchedule{
+			QuartzCronExpression: "0 0 12 * * ?",
+			TimezoneID:           "UTC",
+		},
+	}
+	assert.Equal(t, "", js.scheduleWarning())
+
+	js.Schedule.QuartzCronExpression = "0 * * * * ?"
+	assert.Equal(t, "job is scheduled to run every 1m0s, but new clusters usually take "+
+		"about 5m0s to start. Consider using instance_pool_id for new_cluster, an existing "+
+		"cluster or always_running job instead", js.scheduleWarning())
+
+	js.NewCluster.InstancePoolID = "pool"
+	assert.Equal(t, "", js.scheduleWarning())
+}
+
+func TestRunStateHelpers(t *testing.T) {
+	for _, tc := range []struct {
+		state      RunState
+		terminal   bool
+		successful bool
+	}{
+		{RunState{LifeCycleState: RunLifeCycleStateQueued}, false, false},
+		{RunState{LifeCycleState: RunLifeCycleStatePending}, false, false},
+		{RunState{LifeCycleState: RunLifeCycleStateRunning}, false, false},
+		{RunState{LifeCycleState: RunLifeCycleStateBlocked}, false, false},
+		{RunState{LifeCycleState: RunLifeCycleStateWaitingForRetry}, false, false},
+		{RunState{LifeCycleState: RunLifeCycleStateTerminating}, false, false},
+		{RunState{LifeCycleState: RunLifeCycleStateSkipped}, true, false},
+		{RunState{LifeCycleState: RunLifeCycleStateInternalError}, true, false},
+		{RunState{
+			LifeCycleState: RunLifeCycleStateTerminated,
+			ResultState:    RunResultStateFailed,
+		}, true, false},
+		{RunState{
+			LifeCycleState: RunLifeCycleStateTerminated,
+			ResultState:    RunResultStateSuccess,
+		}, true, true},
+	} {
+		assert.Equal(t, tc.terminal, tc.state.IsTerminal(), tc.state.LifeCycleState)
+		assert.Equal(t, tc.successful, tc.state.IsSuccessful(), tc.state.LifeCycleState)
+	}
+}
+
+func TestWaitForRunState_IntermediateStates(t *testing.T) {
+	runsGet := func(state RunLifeCycleState) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get?run_id=234",
+			Response: JobRun{
+				State: RunState{
+					LifeCycleState: state,
+				},
+			},
+		}
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		runsGet(RunLifeCycleStateQueued),
+		runsGet(RunLifeCycleStateWaitingForRetry),
+		runsGet(RunLifeCycleStatePending),
+		runsGet(RunLifeCycleStateRunning),
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewJobsAPI(ctx, client).waitForRunState(234,
+			RunLifeCycleStateRunning, 30*time.Second)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWaitForRunState_Skipped(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get?run_id=234",
+			Response: JobRun{
+				State: RunState{
+					LifeCycleState: RunLifeCycleStateSkipped,
+					StateMessage:   "Run is already active",
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewJobsAPI(ctx, client).waitForRunState(234,
+			RunLifeCycleStateRunning, 30*time.Second)
+		assert.EqualError(t, err, "cannot get job RUNNING: Run is already active")
+	})
+}
+
+func notebookJobDiff(t *testing.T, fixtures []qa.HTTPFixture,
+	validate bool, config map[string]interface{}) error {
+	client, server, err := qa.HttpFixtureClient(t, append(fixtures, existingClusterFixture))
+	require.NoError(t, err)
+	defer server.Close()
+	client.ValidateNotebookPaths = validate
+	_, err = ResourceJob().Diff(context.Background(), nil,
+		terraform.NewResourceConfigRaw(config), client)
+	return err
+}
+
+func notebookTaskConfig(notebookPath string) map[string]interface{} {
+	return map[string]interface{}{
+		"task": []interface{}{
+			map[string]interface{}{
+				"task_key":            "ingest",
+				"existing_cluster_id": "abc",
+				"notebook_task": []interface{}{
+					map[string]interface{}{
+						"notebook_path": notebookPath,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResourceJobDiff_NotebookExists(t *testing.T) {
+	err := notebookJobDiff(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FShared%2FIngest",
+			Response: map[string]interface{}{
+				"path":        "/Shared/Ingest",
+				"object_type": "NOTEBOOK",
+			},
+		},
+	}, true, notebookTaskConfig("/Shared/Ingest"))
+	assert.NoError(t, err)
+}
+
+func TestResourceJobDiff_NotebookMissing(t *testing.T) {
+	err := notebookJobDiff(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/get-status?path=%2FShared%2FIngets",
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "Path (/Shared/Ingets) doesn't exist.",
+			},
+			Status: 404,
+		},
+	}, true, notebookTaskConfig("/Shared/Ingets"))
+	assert.EqualError(t, err, "task ingest invalid: notebook_path /Shared/Ingets does not exist")
+}
+
+func TestResourceJobDiff_NotebookNotValidatedByDefault(t *testing.T) {
+	err := notebookJobDiff(t, []qa.HTTPFixture{}, false, notebookTaskConfig("/Shared/Ingets"))
+	assert.NoError(t, err)
+}
+
+func TestResourceJobDiff_NotebookFromGitNotValidated(t *testing.T) {
+	config := notebookTaskConfig("notebooks/ingest")
+	config["git_source"] = []interface{}{
+		map[string]interface{}{
+			"url":      "https://github.com/databrickslabs/terraform-provider-databricks",
+			"provider": "gitHub",
+			"branch":   "master",
+		},
+	}
+	err := notebookJobDiff(t, []qa.HTTPFixture{}, true, config)
+	assert.NoError(t, err)
+}
+
+func TestResourceJobDiff_NotebookUnknown(t *testing.T) {
+	err := notebookJobDiff(t, []qa.HTTPFixture{}, true,
+		notebookTaskConfig("74D93920-ED26-11E3-AC10-0800200C9A66"))
+	assert.NoError(t, err)
+}
+
+func TestJobsAPIValidateClusterReference(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		existingClusterFixture,
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=broken",
+			Response: ClusterInfo{
+				ClusterID:    "broken",
+				State:        ClusterStateError,
+				StateMessage: "Instance profile is invalid",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=gone",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Cluster gone does not exist",
+			},
+			Status: 400,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		jobsAPI := NewJobsAPI(ctx, client)
+		assert.Len(t, jobsAPI.ValidateClusterReference("abc"), 0)
+
+		diags := jobsAPI.ValidateClusterReference("broken")
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "cluster broken is in ERROR state: Instance profile is invalid", diags[0].Summary)
+
+		diags = jobsAPI.ValidateClusterReference("gone")
+		require.Len(t, diags, 1)
+		assert.Equal(t, "cluster gone does not exist", diags[0].Summary)
+	})
+}
+
+func TestJobsAPIValidateWebhookNotifications(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/notification-destinations",
+			Response: NotificationDestinationList{
+				Results: []NotificationDestination{
+					{
+						ID:          "email",
+						DisplayName: "Team",
+					},
+				},
+				NextPageToken: "next",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/notification-destinations?page_token=next",
+			Response: NotificationDestinationList{
+				Results: []NotificationDestination{
+					{
+						ID:          "pagerduty",
+						DisplayName: "On-call",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		jobsAPI := NewJobsAPI(ctx, client)
+		assert.Len(t, jobsAPI.ValidateWebhookNotifications(nil), 0)
+		assert.Len(t, jobsAPI.ValidateWebhookNotifications(&WebhookNotifications{}), 0)
+		assert.Len(t, jobsAPI.ValidateWebhookNotifications(&WebhookNotifications{
+			OnFailure: []Webhook{{ID: "pagerduty"}},
+		}), 0)
+		assert.Len(t, jobsAPI.ValidateWebhookNotifications(&WebhookNotifications{
+			OnStart: []Webhook{{ID: "email"}},
+		}), 0)
+
+		diags := jobsAPI.ValidateWebhookNotifications(&WebhookNotifications{
+			OnStart:   []Webhook{{ID: "slack"}},
+			OnFailure: []Webhook{{ID: "pagerduty"}, {ID: "slack"}},
+		})
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Error, diags[0].Severity)
+		assert.Equal(t, "notification destination slack does not exist", diags[0].Summary)
+	})
+}
+
+func TestResourceJobCreate_UnknownWebhook(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			existingClusterFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations",
+				Response: NotificationDestinationList{},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		webhook_notifications {
+			on_failure {
+				id = "fb99f3dc-a0a0-11ec-b909-0242ac120002"
+			}
+		}`,
+	}.ExpectError(t, "invalid webhook_notifications: notification destination "+
+		"fb99f3dc-a0a0-11ec-b909-0242ac120002 does not exist")
+}
+
+func TestValidateWheelLibrary(t *testing.T) {
+	wheel := &PythonWheelTask{PackageName: "my-pkg"}
+	assert.NoError(t, validateWheelLibrary(nil, nil))
+	assert.NoError(t, validateWheelLibrary(&PythonWheelTask{}, nil))
+	assert.NoError(t, validateWheelLibrary(wheel, []Library{
+		{Whl: "dbfs:/wheels/my_pkg-0.1-py3-none-any.whl"},
+	}))
+	assert.NoError(t, validateWheelLibrary(wheel, []Library{
+		{Pypi: &PyPi{Package: "My.Pkg==0.1"}},
+	}))
+	assert.NoError(t, validateWheelLibrary(wheel, []Library{{}}))
+	assert.EqualError(t, validateWheelLibrary(wheel, []Library{
+		{Whl: "dbfs:/wheels/other-0.1-py3-none-any.whl"},
+	}), "package_name my-pkg is not installed by any whl or pypi library. "+
+		"Add it to libraries or set auto_add_wheel_library")
+	assert.EqualError(t, validateWheelLibrary(&PythonWheelTask{
+		PackageName:         "my_pkg",
+		AutoAddWheelLibrary: true,
+	}, nil), "package_name must be a path to .whl file, when auto_add_wheel_library is set, got: my_pkg")
+}
+
+func TestValidateJarLibrary(t *testing.T) {
+	jarTask := &SparkJarTask{MainClassName: "com.labs.BarMain"}
+	assert.NoError(t, validateJarLibrary(nil, nil))
+	assert.NoError(t, validateJarLibrary(jarTask, []Library{
+		{Jar: "dbfs:/FileStore/jars/main.jar"},
+	}))
+	assert.NoError(t, validateJarLibrary(jarTask, []Library{
+		{Maven: &Maven{Coordinates: "com.labs:bar:0.1"}},
+	}))
+	assert.NoError(t, validateJarLibrary(jarTask, []Library{{}}))
+	assert.EqualError(t, validateJarLibrary(jarTask, []Library{
+		{Whl: "dbfs:/wheels/my_pkg-0.1-py3-none-any.whl"},
+	}), "spark_jar_task requires at least one jar or maven library with the main class. "+
+		"Add it to libraries")
+}
+
+func TestResourceJobCreate_JarTaskWithoutJarLibrary(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				num_workers   = 1
+				spark_version = "7.3.x-scala2.12"
+				node_type_id  = "Standard_DS3_v2"
+			}
+			spark_jar_task {
+				main_class_name = "com.labs.BarMain"
+			}
+			library {
+				whl = "dbfs:/wheels/my_pkg-0.1-py3-none-any.whl"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: spark_jar_task requires at least one jar or maven "+
+		"library with the main class. Add it to libraries")
+}
+
+func TestJobSettingsValidateJarLibraries(t *testing.T) {
+	jarTask := &SparkJarTask{MainClassName: "com.labs.BarMain"}
+	js := JobSettings{
+		Libraries: []Library{{Jar: "dbfs:/FileStore/jars/main.jar"}},
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:      "with_job_library",
+				NewCluster:   &Cluster{NumWorkers: 1},
+				SparkJarTask: jarTask,
+			},
+			{
+				TaskKey:           "on_existing_cluster",
+				ExistingClusterID: "abc",
+				SparkJarTask:      jarTask,
+			},
+		},
+	}
+	assert.NoError(t, js.validateJarLibraries())
+	js.Libraries = nil
+	assert.EqualError(t, js.validateJarLibraries(), "task with_job_library invalid: "+
+		"spark_jar_task requires at least one jar or maven library with the main class. "+
+		"Add it to libraries")
+}
+
+func TestResourceJobCreate_AutoAddWheelLibrary(t *testing.T) {
+	wheelTask := JobTaskSettings{
+		TaskKey: "a",
+		NewCluster: &Cluster{
+			SparkVersion: "a",
+			NodeTypeID:   "b",
+			NumWorkers:   1,
+		},
+		Libraries: []Library{
+			{Whl: "/Workspace/Shared/my_pkg-0.1-py3-none-any.whl"},
+		},
+		PythonWheelTask: &PythonWheelTask{
+			PackageName: "my_pkg",
+			EntryPoint:  "main",
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Wheel",
+					Tasks:             []JobTaskSettings{wheelTask},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Wheel",
+						Tasks:             []JobTaskSettings{wheelTask},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Wheel"
+		task {
+			task_key = "a"
+			new_cluster {
+				spark_version = "a"
+				node_type_id = "b"
+				num_workers = 1
+			}
+			python_wheel_task {
+				package_name = "/Shared/my_pkg-0.1-py3-none-any.whl"
+				entry_point = "main"
+				auto_add_wheel_library = true
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "/Shared/my_pkg-0.1-py3-none-any.whl",
+		d.Get("task.0.python_wheel_task.0.package_name"))
+	assert.Equal(t, true, d.Get("task.0.python_wheel_task.0.auto_add_wheel_library"))
+	assert.Equal(t, 0, d.Get("task.0.library.#"))
+}
+
+func TestResourceJobCreate_WheelLibraryMissing(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				spark_version = "a"
+				node_type_id = "b"
+				num_workers = 1
+			}
+			python_wheel_task {
+				package_name = "my_pkg"
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: package_name my_pkg is not installed by any whl or pypi library. "+
+		"Add it to libraries or set auto_add_wheel_library")
+}
+
+func TestValidateDbtTask(t *testing.T) {
+	dbt := &DbtTask{Commands: []string{"dbt run"}}
+	dbtLibraries := []Library{{Pypi: &PyPi{Package: "dbt-databricks>=1.0.0"}}}
+	assert.NoError(t, validateDbtTask(JobTaskSettings{}))
+	assert.NoError(t, validateDbtTask(JobTaskSettings{
+		ExistingClusterID: "abc",
+		DbtTask: &DbtTask{
+			Commands:    []string{"dbt run"},
+			WarehouseID: "xyz",
+		},
+	}))
+	assert.NoError(t, validateDbtTask(JobTaskSettings{
+		NewCluster: &Cluster{SparkVersion: "a"},
+		Libraries:  dbtLibraries,
+		DbtTask:    dbt,
+	}))
+	assert.NoError(t, validateDbtTask(JobTaskSettings{
+		JobClusterKey: "shared",
+		Libraries:     []Library{{Pypi: &PyPi{Package: "DBT_Databricks"}}},
+		DbtTask:       dbt,
+	}))
+	assert.EqualError(t, validateDbtTask(JobTaskSettings{
+		ExistingClusterID: "abc",
+		Libraries:         dbtLibraries,
+		DbtTask:           dbt,
+	}), "dbt_task must either have warehouse_id or run on new_cluster or job_cluster_key "+
+		"with dbt-databricks pypi library")
+	assert.EqualError(t, validateDbtTask(JobTaskSettings{
+		NewCluster: &Cluster{SparkVersion: "a"},
+		Libraries:  []Library{{Pypi: &PyPi{Package: "dbt-core"}}},
+		DbtTask:    dbt,
+	}), "dbt_task must either have warehouse_id or run on new_cluster or job_cluster_key "+
+		"with dbt-databricks pypi library")
+}
+
+func TestResourceJobDiff_DbtTaskUnknownWarehouse(t *testing.T) {
+	_, err := ResourceJob().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(
+		map[string]interface{}{
+			"task": []interface{}{
+				map[string]interface{}{
+					"task_key":            "a",
+					"existing_cluster_id": "abc",
+					"dbt_task": []interface{}{
+						map[string]interface{}{
+							"commands": []interface{}{"dbt run"},
+							// warehouse is created within the same apply
+							"warehouse_id": "74D93920-ED26-11E3-AC10-0800200C9A66",
+						},
+					},
+				},
+			},
+		}), &common.DatabricksClient{})
+	assert.NoError(t, err)
+}
+
+func TestResourceJobCreate_DbtTaskWithoutAdapter(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				spark_version = "a"
+				node_type_id = "b"
+				num_workers = 1
+			}
+			dbt_task {
+				commands = ["dbt deps", "dbt run"]
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: dbt_task must either have warehouse_id or run on new_cluster "+
+		"or job_cluster_key with dbt-databricks pypi library")
+}
+
+func TestValidateNotificationRecipients(t *testing.T) {
+	valid := JobSettings{
+		EmailNotifications: &EmailNotifications{
+			OnFailure: []string{"alerts@example.com", "", "oncall@example.com"},
+		},
+		WebhookNotifications: &WebhookNotifications{
+			OnStart: []Webhook{{ID: "fb99f3dc-a0a0-11ec-b909-0242ac120002"}},
+		},
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey: "a",
+				EmailNotifications: &EmailNotifications{
+					OnSuccess: []string{"alerts@example.com"},
+				},
+			},
+		},
+	}
+	assert.NoError(t, valid.validateNotificationRecipients())
+	assert.NoError(t, (&JobSettings{}).validateNotificationRecipients())
+
+	for expected, js := range map[string]JobSettings{
+		"email_notifications.on_failure: user@company is not a valid email address: " +
+			"domain has no top-level domain": {
+			EmailNotifications: &EmailNotifications{
+				OnFailure: []string{"user@company"},
+			},
+		},
+		"email_notifications.on_start: https://hooks.slack.com/services/abc is not a valid email address": {
+			EmailNotifications: &EmailNotifications{
+				OnStart: []string{"https://hooks.slack.com/services/abc"},
+			},
+		},
+		"email_notifications.on_success: Alerts <alerts@example.com> is not a valid email address": {
+			EmailNotifications: &EmailNotifications{
+				OnSuccess: []string{"Alerts <alerts@example.com>"},
+			},
+		},
+		"email_notifications.on_failure lists alerts@example.com more than once": {
+			EmailNotifications: &EmailNotifications{
+				OnFailure: []string{"alerts@example.com", "alerts@example.com"},
+			},
+		},
+		"webhook_notifications.on_failure: https://hooks.slack.com/services/abc is not " +
+			"a valid notification destination id, which must be UUID": {
+			WebhookNotifications: &WebhookNotifications{
+				OnFailure: []Webhook{{ID: "https://hooks.slack.com/services/abc"}},
+			},
+		},
+		"webhook_notifications.on_start lists fb99f3dc-a0a0-11ec-b909-0242ac120002 more than once": {
+			WebhookNotifications: &WebhookNotifications{
+				OnStart: []Webhook{
+					{ID: "fb99f3dc-a0a0-11ec-b909-0242ac120002"},
+					{ID: "fb99f3dc-a0a0-11ec-b909-0242ac120002"},
+				},
+			},
+		},
+		"task a invalid: email_notifications.on_failure: nobody is not a valid email address": {
+			Tasks: []JobTaskSettings{
+				{
+					TaskKey: "a",
+					EmailNotifications: &EmailNotifications{
+						OnFailure: []string{"nobody"},
+					},
+				},
+			},
+		},
+	} {
+		assert.EqualError(t, js.validateNotificationRecipients(), expected)
+	}
+}
+
+func TestTaskMaxRetriesValidation(t *testing.T) {
+	p, err := common.SchemaPath(jobSchema, "task", "max_retries")
+	require.NoError(t, err)
+	for value, valid := range map[int]bool{-2: false, -1: true, 0: true, 10: true, 11: false} {
+		_, errs := p.ValidateFunc(value, "task.0.max_retries")
+		assert.Equal(t, valid, len(errs) == 0, "max_retries = %d", value)
+	}
+}
+
+func TestResourceJobDiff_RetryOnTimeout(t *testing.T) {
+	retryConfig := func(retryOnTimeout bool, timeoutSeconds int) map[string]interface{} {
+		config := notebookTaskConfig("/Shared/Ingest")
+		task := config["task"].([]interface{})[0].(map[string]interface{})
+		task["retry_on_timeout"] = retryOnTimeout
+		task["timeout_seconds"] = timeoutSeconds
+		return config
+	}
+	assert.NoError(t, notebookJobDiff(t, nil, false, retryConfig(false, 0)))
+	assert.NoError(t, notebookJobDiff(t, nil, false, retryConfig(true, 1)))
+	assert.EqualError(t, notebookJobDiff(t, nil, false, retryConfig(true, 0)),
+		"task ingest invalid: retry_on_timeout requires timeout_seconds to be greater than 0")
+}
+
+func TestJobSettingsValidateRunMode(t *testing.T) {
+	schedule := &CronSchedule{
+		QuartzCronExpression: "0 0 * * * ?",
+		TimezoneID:           "UTC",
+	}
+	trigger := &JobTrigger{
+		FileArrival: &FileArrivalTriggerConf{
+			URL: "s3://landing/",
+		},
+	}
+	continuous := &ContinuousConf{}
+	assert.NoError(t, (&JobSettings{}).validateRunMode(false))
+	assert.NoError(t, (&JobSettings{Schedule: schedule}).validateRunMode(false))
+	assert.NoError(t, (&JobSettings{Trigger: trigger}).validateRunMode(false))
+	assert.NoError(t, (&JobSettings{Continuous: continuous}).validateRunMode(false))
+	assert.EqualError(t, (&JobSettings{
+		Schedule: schedule,
+		Trigger:  trigger,
+	}).validateRunMode(false), "only one of schedule, trigger or continuous blocks "+
+		"could be specified, but got: schedule, trigger")
+	assert.EqualError(t, (&JobSettings{
+		Schedule:   schedule,
+		Trigger:    trigger,
+		Continuous: continuous,
+	}).validateRunMode(false), "only one of schedule, trigger or continuous blocks "+
+		"could be specified, but got: schedule, trigger, continuous")
+	assert.NoError(t, (&JobSettings{Continuous: continuous, MaxConcurrentRuns: 1}).validateRunMode(false))
+	assert.NoError(t, (&JobSettings{Trigger: trigger, MaxConcurrentRuns: 5}).validateRunMode(false))
+	assert.EqualError(t, (&JobSettings{
+		Continuous:        continuous,
+		MaxConcurrentRuns: 2,
+	}).validateRunMode(false), "continuous block must be specified only with "+
+		"`max_concurrent_runs = 1`, but got: 2")
+	assert.EqualError(t, (&JobSettings{
+		Schedule:          schedule,
+		Continuous:        continuous,
+		MaxConcurrentRuns: 3,
+	}).validateRunMode(true), "only one of schedule, trigger or continuous blocks could be specified, "+
+		"but got: schedule, continuous; continuous block must be specified only with "+
+		"`max_concurrent_runs = 1`, but got: 3; `always_running` must be specified only with "+
+		"`max_concurrent_runs = 1`; `always_running` cannot be used with continuous block, "+
+		"as continuous job always has an active run")
+}
+
+func TestResourceJobDiff_ScheduleAndContinuous(t *testing.T) {
+	config := notebookTaskConfig("/Shared/Ingest")
+	config["schedule"] = []interface{}{
+		map[string]interface{}{
+			"quartz_cron_expression": "0 0 * * * ?",
+			"timezone_id":            "UTC",
+		},
+	}
+	config["continuous"] = []interface{}{
+		map[string]interface{}{
+			"pause_status": "UNPAUSED",
+		},
+	}
+	assert.EqualError(t, notebookJobDiff(t, nil, false, config),
+		"only one of schedule, trigger or continuous blocks could be specified, "+
+			"but got: schedule, continuous")
+}
+
+func TestResourceJobCreate_SparkSubmitOnExistingCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			spark_submit_task {
+				parameters = ["--class", "com.example.Main", "dbfs:/app.jar"]
+			}
+		}`,
+	}.ExpectError(t, "task a invalid: spark_submit_task can only run on new_cluster, "+
+		"not on existing_cluster_id or job_cluster_key")
+}
+
+func TestJobSparkSubmitValidation(t *testing.T) {
+	js := JobSettings{
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey: "a",
+				NewCluster: &Cluster{
+					SparkVersion: "7.3.x-scala2.12",
+					NodeTypeID:   "i3.xlarge",
+					NumWorkers:   2,
+				},
+				SparkSubmitTask: &SparkSubmitTask{
+					Parameters: []string{"--class", "com.example.Main", "dbfs:/app.jar"},
+				},
+			},
+		},
+	}
+	assert.NoError(t, js.validateSparkSubmitTasks())
+	assert.Equal(t, "", js.sparkSubmitWarning())
+
+	js.Tasks[0].JobClusterKey = "shared"
+	assert.EqualError(t, js.validateSparkSubmitTasks(), "task a invalid: spark_submit_task can "+
+		"only run on new_cluster, not on existing_cluster_id or job_cluster_key")
+	js.Tasks[0].JobClusterKey = ""
+
+	js.Tasks[0].Libraries = []Library{{Jar: "dbfs:/lib.jar"}}
+	assert.EqualError(t, js.validateSparkSubmitTasks(), "task a invalid: spark_submit_task "+
+		"cannot be combined with libraries, use --jars or --py-files parameters instead")
+	js.Tasks[0].Libraries = nil
+
+	js.Tasks[0].SparkSubmitTask.Parameters = []string{"--master=yarn", "dbfs:/app.jar"}
+	assert.EqualError(t, js.validateSparkSubmitTasks(), "task a invalid: spark_submit_task "+
+		"parameters cannot contain --master, as it's set by Databricks")
+
+	js.Tasks[0].SparkSubmitTask.Parameters = []string{"--deploy-mode", "cluster", "dbfs:/app.jar"}
+	assert.EqualError(t, js.validateSparkSubmitTasks(), "task a invalid: spark_submit_task "+
+		"parameters cannot contain --deploy-mode, as it's set by Databricks")
+
+	js.Tasks[0].NewCluster.Autoscale = &AutoScale{MinWorkers: 1, MaxWorkers: 4}
+	assert.Equal(t, "task a: spark_submit_task doesn't support autoscaling, "+
+		"use num_workers for new_cluster instead", js.sparkSubmitWarning())
+
+	legacy := JobSettings{
+		ExistingClusterID: "abc",
+		SparkSubmitTask:   &SparkSubmitTask{},
+	}
+	assert.EqualError(t, legacy.validateSparkSubmitTasks(), "spark_submit_task can only run "+
+		"on new_cluster, not on existing_cluster_id or job_cluster_key")
+}
+
+func TestJobTimeoutWarning(t *testing.T) {
+	js := JobSettings{
+		TimeoutSeconds: 3600,
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:        "extract",
+				TimeoutSeconds: 1200,
+			},
+			{
+				TaskKey:        "validate",
+				TimeoutSeconds: 300,
+			},
+			{
+				TaskKey:        "transform",
+				TimeoutSeconds: 1800,
+				DependsOn:      []TaskDependency{{TaskKey: "extract"}},
+			},
+			{
+				TaskKey:        "load",
+				TimeoutSeconds: 600,
+				DependsOn: []TaskDependency{
+					{TaskKey: "transform"},
+					{TaskKey: "validate"},
+				},
+			},
+		},
+	}
+	// parallel tasks don't add up, so the longest path takes exactly an hour
+	assert.Equal(t, "", js.timeoutWarning())
+
+	js.TimeoutSeconds = 3000
+	assert.Equal(t, "job timeout_seconds is 3000, but tasks extract -> transform -> load "+
+		"may run for up to 3600 seconds according to their timeout_seconds, so the job "+
+		"may time out before they complete", js.timeoutWarning())
+
+	js.TimeoutSeconds = 0
+	assert.Equal(t, "", js.timeoutWarning())
+}
+
+func TestJobSettingsUnusedJobClusters(t *testing.T) {
+	js := JobSettings{
+		JobClusters: []JobCluster{
+			{JobClusterKey: "shared"},
+			{JobClusterKey: "ml"},
+		},
+		Tasks: []JobTaskSettings{
+			{TaskKey: "a", JobClusterKey: "shared"},
+			{TaskKey: "b", JobClusterKey: "ml"},
+			{TaskKey: "c", ExistingClusterID: "abc"},
+		},
+	}
+	assert.Equal(t, []string{}, js.UnusedJobClusters())
+	assert.Equal(t, "", js.unusedJobClustersWarning())
+
+	js.Tasks[1].JobClusterKey = "shared"
+	assert.Equal(t, []string{"ml"}, js.UnusedJobClusters())
+	assert.Equal(t, "job_cluster ml is not used by any task and could be removed",
+		js.unusedJobClustersWarning())
+}
+
+func TestJobSettingsToRunSubmit(t *testing.T) {
+	shared := &Cluster{
+		SparkVersion: "7.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		NumWorkers:   2,
+	}
+	js := JobSettings{
+		Name:           "Nightly",
+		TimeoutSeconds: 3600,
+		Schedule: &CronSchedule{
+			QuartzCronExpression: "0 0 12 * * ?",
+			TimezoneID:           "UTC",
+		},
+		Continuous:        &ContinuousConf{},
+		MaxConcurrentRuns: 2,
+		EmailNotifications: &EmailNotifications{
+			OnFailure: []string{"ops@example.com"},
+		},
+		GitSource: &GitSource{
+			URL:    "https://github.com/example/etl",
+			Branch: "main",
+		},
+		JobClusters: []JobCluster{
+			{
+				JobClusterKey: "shared",
+				NewCluster:    shared,
+			},
+		},
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:       "extract",
+				JobClusterKey: "shared",
+				NotebookTask: &NotebookTask{
+					NotebookPath: "notebooks/extract",
+				},
+			},
+			{
+				TaskKey:           "load",
+				ExistingClusterID: "abc",
+				DependsOn:         []TaskDependency{{TaskKey: "extract"}},
+				NotebookTask: &NotebookTask{
+					NotebookPath: "notebooks/load",
+				},
+			},
+		},
+	}
+	rs := js.ToRunSubmit("test run")
+	assert.Equal(t, RunSubmit{
+		RunName:        "test run",
+		TimeoutSeconds: 3600,
+		GitSource:      js.GitSource,
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:    "extract",
+				NewCluster: shared,
+				NotebookTask: &NotebookTask{
+					NotebookPath: "notebooks/extract",
+				},
+			},
+			{
+				TaskKey:           "load",
+				ExistingClusterID: "abc",
+				DependsOn:         []TaskDependency{{TaskKey: "extract"}},
+				NotebookTask: &NotebookTask{
+					NotebookPath: "notebooks/load",
+				},
+			},
+		},
+	}, rs)
+	// job settings are not modified
+	assert.Equal(t, "shared", js.Tasks[0].JobClusterKey)
+	assert.Nil(t, js.Tasks[0].NewCluster)
+}
+
+func TestJobSettingsToRunSubmit_SingleTask(t *testing.T) {
+	js := JobSettings{
+		Name:              "Legacy",
+		ExistingClusterID: "abc",
+		SparkJarTask: &SparkJarTask{
+			MainClassName: "com.example.Main",
+		},
+		Libraries: []Library{{Jar: "dbfs:/app.jar"}},
+		Schedule: &CronSchedule{
+			QuartzCronExpression: "0 0 12 * * ?",
+			TimezoneID:           "UTC",
+		},
+		MaxRetries: 3,
+	}
+	assert.Equal(t, RunSubmit{
+		RunName: "one-off",
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:           "main",
+				ExistingClusterID: "abc",
+				SparkJarTask: &SparkJarTask{
+					MainClassName: "com.example.Main",
+				},
+				Libraries:  []Library{{Jar: "dbfs:/app.jar"}},
+				MaxRetries: 3,
+			},
+		},
+	}, js.ToRunSubmit("one-off"))
+}
+
+func TestJobSettingsTasksOnRuntime(t *testing.T) {
+	js := JobSettings{
+		JobClusters: []JobCluster{
+			{
+				JobClusterKey: "shared",
+				NewCluster:    &Cluster{SparkVersion: "7.3.x-scala2.12"},
+			},
+		},
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:    "current",
+				NewCluster: &Cluster{SparkVersion: "10.4.x-scala2.12"},
+			},
+			{
+				TaskKey:    "old",
+				NewCluster: &Cluster{SparkVersion: "7.3.x-scala2.12"},
+			},
+			{
+				TaskKey:       "shared_old",
+				JobClusterKey: "shared",
+			},
+			{
+				TaskKey:           "existing",
+				ExistingClusterID: "abc",
+			},
+		},
+	}
+	isDeprecated := func(version string) bool {
+		return strings.HasPrefix(version, "7.")
+	}
+	assert.Equal(t, []string{"old", "shared_old"}, js.TasksOnRuntime(isDeprecated))
+	assert.Nil(t, js.TasksOnRuntime(func(string) bool { return false }))
+
+	legacy := JobSettings{
+		NewCluster: &Cluster{SparkVersion: "7.3.x-scala2.12"},
+	}
+	assert.Equal(t, []string{"main"}, legacy.TasksOnRuntime(isDeprecated))
+}
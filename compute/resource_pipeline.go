@@ -198,6 +198,8 @@ func adjustPipelineResourceSchema(m map[string]*schema.Schema) map[string]*schem
 	clusters, _ := m["cluster"].Elem.(*schema.Resource)
 	clustersSchema := clusters.Schema
 	clustersSchema["spark_conf"].DiffSuppressFunc = sparkConfDiffSuppressFunc
+	markClusterSensitiveFields(clustersSchema)
+	customizeAutoscaleModeSchema(clustersSchema, true)
 
 	awsAttributes, _ := clustersSchema["aws_attributes"].Elem.(*schema.Resource)
 	awsAttributesSchema := awsAttributes.Schema
@@ -209,6 +211,7 @@ func adjustPipelineResourceSchema(m map[string]*schema.Schema) map[string]*schem
 	delete(awsAttributesSchema, "ebs_volume_size")
 
 	m["library"].MinItems = 1
+	customizeLibrarySchema(m)
 	m["url"] = &schema.Schema{
 		Type:     schema.TypeString,
 		Computed: true,
@@ -316,7 +316,7 @@ func TestResourcePipelineUpdate(t *testing.T) {
 		Libraries: []pipelineLibrary{
 			{
 				Maven: &Maven{
-					Coordinates: "coordinates",
+					Coordinates: "com.example:library:1.0.0",
 				},
 			},
 		},
@@ -355,7 +355,7 @@ func TestResourcePipelineUpdate(t *testing.T) {
 		storage = "/test/storage"
 		library {
 			maven {
-				coordinates = "coordinates"
+				coordinates = "com.example:library:1.0.0"
 			}
 		}
 		filters {
@@ -386,7 +386,7 @@ func TestResourcePipelineUpdate_Error(t *testing.T) {
 		storage = "/test/storage"
 		library {
 			maven {
-				coordinates = "coordinates"
+				coordinates = "com.example:library:1.0.0"
 			}
 		}
 		filters {
@@ -408,7 +408,7 @@ func TestResourcePipelineUpdate_FailsAfterUpdate(t *testing.T) {
 		Libraries: []pipelineLibrary{
 			{
 				Maven: &Maven{
-					Coordinates: "coordinates",
+					Coordinates: "com.example:library:1.0.0",
 				},
 			},
 		},
@@ -438,7 +438,7 @@ func TestResourcePipelineUpdate_FailsAfterUpdate(t *testing.T) {
 		storage = "/test/storage"
 		library {
 			maven {
-				coordinates = "coordinates"
+				coordinates = "com.example:library:1.0.0"
 			}
 		}
 		filters {
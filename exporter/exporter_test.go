@@ -43,7 +43,7 @@ func TestImportingMounts(t *testing.T) {
 			{
 				Method:       "GET",
 				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/list",
+				Resource:     "/api/2.1/clusters/list?page_size=100",
 				Response: compute.ClusterList{
 					Clusters: []compute.ClusterInfo{
 						{
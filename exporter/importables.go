@@ -136,7 +136,7 @@ var resourcesMap map[string]importable = map[string]importable{
 			}
 			lastActiveMs := ic.lastActiveDays * 24 * 60 * 60 * 1000
 			for offset, c := range clusters {
-				if c.ClusterSource == "JOB" {
+				if c.ClusterSource == compute.ClusterSourceJob {
 					log.Printf("[INFO] Skipping job cluster %s", c.ClusterID)
 					continue
 				}
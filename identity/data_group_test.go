@@ -90,3 +90,52 @@ func TestDataSourceGroup(t *testing.T) {
 	assert.Equal(t, true, d.Get("allow_instance_pool_create"))
 	assert.Equal(t, true, d.Get("allow_cluster_create"))
 }
+
+func TestDataSourceGroup_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%27ds%27",
+				Response: GroupList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"display_name": "ds",
+		},
+	}.ExpectError(t, "cannot find group: ds")
+}
+
+func TestDataSourceGroup_MultipleMatches(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%27ds%27",
+				Response: GroupList{
+					Resources: []ScimGroup{
+						{
+							DisplayName: "ds",
+							ID:          "eerste",
+						},
+						{
+							DisplayName: "ds",
+							ID:          "tweede",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"display_name": "ds",
+		},
+	}.ExpectError(t, "there are 2 groups with display name ds")
+}
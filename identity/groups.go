@@ -58,6 +58,11 @@ func (a GroupsAPI) ReadByDisplayName(displayName string) (group ScimGroup, err e
 		err = fmt.Errorf("cannot find group: %s", displayName)
 		return
 	}
+	if len(groupList.Resources) > 1 {
+		err = fmt.Errorf("there are %d groups with display name %s",
+			len(groupList.Resources), displayName)
+		return
+	}
 	group = groupList.Resources[0]
 	return
 }
@@ -0,0 +1,184 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/compute"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DefaultAutoMLTimeout is the default amount of time to wait for AutoML run to complete
+const DefaultAutoMLTimeout = 2 * time.Hour
+
+// AutoMLExperiment is the AutoML run, that trains and evaluates models for the dataset
+type AutoMLExperiment struct {
+	DatasetPath       string           `json:"dataset_path"`
+	TargetCol         string           `json:"target_col"`
+	ProblemType       string           `json:"problem_type"`
+	PrimaryMetric     string           `json:"primary_metric,omitempty" tf:"computed"`
+	TimeoutMinutes    int32            `json:"timeout_minutes,omitempty"`
+	MaxTrials         int32            `json:"max_trials,omitempty"`
+	ClusterSpec       *compute.Cluster `json:"cluster_spec,omitempty"`
+	ExcludeFrameworks []string         `json:"exclude_frameworks,omitempty"`
+
+	ExperimentID          string `json:"experiment_id,omitempty" tf:"computed"`
+	State                 string `json:"state,omitempty" tf:"computed"`
+	BestTrialNotebookPath string `json:"best_trial_notebook_path,omitempty" tf:"computed"`
+	BestTrialRunID        string `json:"best_trial_run_id,omitempty" tf:"computed"`
+	BestModelURI          string `json:"best_model_uri,omitempty" tf:"computed"`
+	BestMetricValue       string `json:"best_metric_value,omitempty" tf:"computed"`
+	ErrorMessage          string `json:"error_message,omitempty" tf:"computed"`
+}
+
+const (
+	// AutoMLStatePending is the state of AutoML run, that waits for the cluster
+	AutoMLStatePending = "PENDING"
+	// AutoMLStateRunning is the state of AutoML run, that evaluates trials
+	AutoMLStateRunning = "RUNNING"
+	// AutoMLStateSuccess is the state of AutoML run, that has found the best model
+	AutoMLStateSuccess = "SUCCESS"
+	// AutoMLStateFailed is the state of AutoML run, that could not complete
+	AutoMLStateFailed = "FAILED"
+	// AutoMLStateCanceled is the state of AutoML run, that was canceled by the user
+	AutoMLStateCanceled = "CANCELED"
+)
+
+// autoMLMetrics lists primary metrics, that are supported for each problem type
+var autoMLMetrics = map[string][]string{
+	"CLASSIFICATION": {"f1", "log_loss", "precision", "accuracy", "roc_auc"},
+	"REGRESSION":     {"r2", "mae", "rmse", "mse"},
+	"FORECASTING":    {"smape", "mse", "rmse", "mae", "mdape"},
+}
+
+var autoMLFrameworks = []string{"sklearn", "lightgbm", "xgboost", "prophet", "arima"}
+
+type autoMLExperimentID struct {
+	ExperimentID string `json:"experiment_id" url:"experiment_id"`
+}
+
+// NewAutoMLAPI creates AutoMLAPI instance from provider meta
+func NewAutoMLAPI(ctx context.Context, m interface{}) AutoMLAPI {
+	return AutoMLAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// AutoMLAPI exposes the AutoML API
+type AutoMLAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Create starts AutoML run and returns ID of its experiment
+func (a AutoMLAPI) Create(ae AutoMLExperiment) (string, error) {
+	var id autoMLExperimentID
+	err := a.client.Post(a.context, "/automl/create-experiment", ae, &id)
+	return id.ExperimentID, err
+}
+
+// Get returns the state of AutoML run and the best trial, once it's found
+func (a AutoMLAPI) Get(experimentID string) (ae AutoMLExperiment, err error) {
+	err = a.client.Get(a.context, "/automl/get-experiment", autoMLExperimentID{
+		ExperimentID: experimentID,
+	}, &ae)
+	return
+}
+
+// Delete removes the MLflow experiment of AutoML run
+func (a AutoMLAPI) Delete(experimentID string) error {
+	return a.client.Post(a.context, "/mlflow/experiments/delete", autoMLExperimentID{
+		ExperimentID: experimentID,
+	}, nil)
+}
+
+// WaitForCompletion waits for AutoML run to find the best model
+func (a AutoMLAPI) WaitForCompletion(experimentID string, timeout time.Duration) (ae AutoMLExperiment, err error) {
+	err = resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		ae, err = a.Get(experimentID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch ae.State {
+		case AutoMLStateSuccess:
+			return nil
+		case AutoMLStateFailed, AutoMLStateCanceled:
+			return resource.NonRetryableError(fmt.Errorf("AutoML experiment %s is %s: %s",
+				experimentID, ae.State, ae.ErrorMessage))
+		}
+		return resource.RetryableError(fmt.Errorf("AutoML experiment %s is %s",
+			experimentID, ae.State))
+	})
+	return
+}
+
+func validateAutoMLExperiment(problemType, primaryMetric string) error {
+	metrics := autoMLMetrics[problemType]
+	if primaryMetric == "" {
+		return nil
+	}
+	for _, metric := range metrics {
+		if metric == primaryMetric {
+			return nil
+		}
+	}
+	return fmt.Errorf("primary_metric for %s must be one of: %s",
+		problemType, strings.Join(metrics, ", "))
+}
+
+// ResourceAutoMLExperiment starts AutoML run and waits for it to find the best model
+func ResourceAutoMLExperiment() *schema.Resource {
+	problemTypes := []string{}
+	for k := range autoMLMetrics {
+		problemTypes = append(problemTypes, k)
+	}
+	sort.Strings(problemTypes)
+	s := common.StructToSchema(AutoMLExperiment{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["problem_type"].ValidateFunc = validation.StringInSlice(problemTypes, false)
+			m["timeout_minutes"].ValidateFunc = validation.IntAtLeast(5)
+			m["max_trials"].ValidateFunc = validation.IntAtLeast(1)
+			m["exclude_frameworks"].Elem.(*schema.Schema).ValidateFunc =
+				validation.StringInSlice(autoMLFrameworks, false)
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			return validateAutoMLExperiment(d.Get("problem_type").(string),
+				d.Get("primary_metric").(string))
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var ae AutoMLExperiment
+			if err := common.DataToStructPointer(d, s, &ae); err != nil {
+				return err
+			}
+			automlAPI := NewAutoMLAPI(ctx, c)
+			experimentID, err := automlAPI.Create(ae)
+			if err != nil {
+				return err
+			}
+			d.SetId(experimentID)
+			_, err = automlAPI.WaitForCompletion(experimentID, d.Timeout(schema.TimeoutCreate))
+			return err
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			ae, err := NewAutoMLAPI(ctx, c).Get(d.Id())
+			if err != nil {
+				return err
+			}
+			return common.StructToData(ae, s, d)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewAutoMLAPI(ctx, c).Delete(d.Id())
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultAutoMLTimeout),
+		},
+	}.ToResource()
+}
@@ -0,0 +1,188 @@
+package mlflow
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/compute"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoMLExperimentCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceAutoMLExperiment())
+}
+
+func TestAutoMLExperimentCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/automl/create-experiment",
+				ExpectedRequest: AutoMLExperiment{
+					DatasetPath:       "default.diabetes",
+					TargetCol:         "progression",
+					ProblemType:       "REGRESSION",
+					PrimaryMetric:     "rmse",
+					TimeoutMinutes:    30,
+					ExcludeFrameworks: []string{"xgboost"},
+					ClusterSpec: &compute.Cluster{
+						SparkVersion: "10.4.x-cpu-ml-scala2.12",
+						NodeTypeID:   "i3.xlarge",
+						NumWorkers:   2,
+					},
+				},
+				Response: autoMLExperimentID{
+					ExperimentID: "123",
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				Resource:     "/api/2.0/automl/get-experiment?experiment_id=123",
+				ReuseRequest: true,
+				Response: AutoMLExperiment{
+					DatasetPath:           "default.diabetes",
+					TargetCol:             "progression",
+					ProblemType:           "REGRESSION",
+					PrimaryMetric:         "rmse",
+					TimeoutMinutes:        30,
+					ExcludeFrameworks:     []string{"xgboost"},
+					ExperimentID:          "123",
+					State:                 AutoMLStateSuccess,
+					BestTrialNotebookPath: "/Users/a@b.c/databricks_automl/progression/best-trial",
+					BestTrialRunID:        "abc",
+				},
+			},
+		},
+		Resource: ResourceAutoMLExperiment(),
+		HCL: `
+		dataset_path = "default.diabetes"
+		target_col = "progression"
+		problem_type = "REGRESSION"
+		primary_metric = "rmse"
+		timeout_minutes = 30
+		exclude_frameworks = ["xgboost"]
+		cluster_spec {
+			spark_version = "10.4.x-cpu-ml-scala2.12"
+			node_type_id = "i3.xlarge"
+			num_workers = 2
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, "123", d.Get("experiment_id"))
+	assert.Equal(t, "/Users/a@b.c/databricks_automl/progression/best-trial",
+		d.Get("best_trial_notebook_path"))
+}
+
+func TestAutoMLExperimentCreate_Failed(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/automl/create-experiment",
+				Response: autoMLExperimentID{
+					ExperimentID: "123",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/automl/get-experiment?experiment_id=123",
+				Response: AutoMLExperiment{
+					ExperimentID: "123",
+					State:        AutoMLStateFailed,
+					ErrorMessage: "target column has a single value",
+				},
+			},
+		},
+		Resource: ResourceAutoMLExperiment(),
+		HCL: `
+		dataset_path = "default.diabetes"
+		target_col = "progression"
+		problem_type = "CLASSIFICATION"`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "AutoML experiment 123 is FAILED: target column has a single value")
+	assert.Equal(t, "123", d.Id(), "experiment should be tainted")
+}
+
+func TestAutoMLExperimentCreate_WrongMetric(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceAutoMLExperiment(),
+		HCL: `
+		dataset_path = "default.diabetes"
+		target_col = "progression"
+		problem_type = "FORECASTING"
+		primary_metric = "roc_auc"`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "primary_metric for FORECASTING must be one of: smape, mse, rmse, mae, mdape")
+}
+
+func TestAutoMLExperimentRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/automl/get-experiment?experiment_id=123",
+				Response: AutoMLExperiment{
+					DatasetPath:  "default.diabetes",
+					TargetCol:    "progression",
+					ProblemType:  "REGRESSION",
+					ExperimentID: "123",
+					State:        AutoMLStateSuccess,
+					BestModelURI: "runs:/abc/model",
+				},
+			},
+		},
+		Resource: ResourceAutoMLExperiment(),
+		Read:     true,
+		New:      true,
+		ID:       "123",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "runs:/abc/model", d.Get("best_model_uri"))
+	assert.Equal(t, "REGRESSION", d.Get("problem_type"))
+}
+
+func TestAutoMLExperimentRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/automl/get-experiment?experiment_id=123",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Experiment 123 does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceAutoMLExperiment(),
+		Read:     true,
+		Removed:  true,
+		ID:       "123",
+	}.ApplyNoError(t)
+}
+
+func TestAutoMLExperimentDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/mlflow/experiments/delete",
+				ExpectedRequest: autoMLExperimentID{
+					ExperimentID: "123",
+				},
+			},
+		},
+		Resource: ResourceAutoMLExperiment(),
+		Delete:   true,
+		ID:       "123",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+}
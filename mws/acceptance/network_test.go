@@ -34,3 +34,27 @@ func TestMwsAccNetworks(t *testing.T) {
 		},
 	})
 }
+
+func TestGcpAccNetworks(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "gcp-accounts" {
+		t.Skip("Acceptance tests skipped unless CLOUD_ENV=gcp-accounts is set")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `
+			resource "databricks_mws_networks" "this" {
+				account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+				network_name = "network-test-{var.RANDOM}"
+				gcp_network_info {
+					network_project_id = "{env.GOOGLE_PROJECT}"
+					vpc_id             = "{env.TEST_VPC_ID}"
+					subnet_id          = "{env.TEST_SUBNET_ID}"
+					subnet_region      = "{env.GOOGLE_REGION}"
+					pod_subnet_id      = "{env.TEST_POD_SUBNET_ID}"
+					service_subnet_id  = "{env.TEST_SERVICE_SUBNET_ID}"
+				}
+			}`,
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package acceptance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/internal/acceptance"
+)
+
+func TestMwsAccPermissionAssignment(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Cannot run test on non-MWS environment")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_permission_assignment" "this" {
+				account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+				workspace_id = {env.TEST_WORKSPACE_ID}
+				principal_id = {env.TEST_PRINCIPAL_ID}
+				permissions  = ["USER"]
+			}`,
+		},
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_permission_assignment" "this" {
+				account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+				workspace_id = {env.TEST_WORKSPACE_ID}
+				principal_id = {env.TEST_PRINCIPAL_ID}
+				permissions  = ["ADMIN"]
+			}`,
+		},
+	})
+}
@@ -55,6 +55,7 @@ func TestMwsAccWorkspaces(t *testing.T) {
 				storage_configuration_id = databricks_mws_storage_configurations.this.storage_configuration_id
 				managed_services_customer_managed_key_id = databricks_mws_customer_managed_keys.this.customer_managed_key_id
 				network_id = databricks_mws_networks.this.network_id
+				is_no_public_ip_enabled = true
 			}`,
 		},
 	})
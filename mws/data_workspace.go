@@ -0,0 +1,118 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// workspaceClouds maps cloud names from Accounts API to the ones users are familiar with
+var workspaceClouds = map[string]string{
+	"":      "AWS",
+	"aws":   "AWS",
+	"azure": "Azure",
+	"gcp":   "GCP",
+}
+
+// currentWorkspaceCloud returns cloud of the workspace, that the client is configured for
+func currentWorkspaceCloud(c *common.DatabricksClient) string {
+	switch {
+	case c.IsAzure():
+		return "Azure"
+	case c.IsGcp():
+		return "GCP"
+	default:
+		return "AWS"
+	}
+}
+
+// DataSourceWorkspace returns metadata of the current workspace or of a single workspace in the account
+func DataSourceWorkspace() *schema.Resource {
+	type entity struct {
+		AccountID      string `json:"account_id,omitempty" tf:"computed"`
+		WorkspaceID    int64  `json:"workspace_id,omitempty" tf:"computed"`
+		DeploymentName string `json:"deployment_name,omitempty" tf:"computed"`
+		WorkspaceName  string `json:"workspace_name,omitempty" tf:"computed"`
+		Cloud          string `json:"cloud,omitempty" tf:"computed"`
+		Region         string `json:"region,omitempty" tf:"computed"`
+		WorkspaceURL   string `json:"workspace_url,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			c := m.(*common.DatabricksClient)
+			if this.WorkspaceID == 0 && this.DeploymentName == "" {
+				// workspace-level provider describes the workspace, that it is configured for.
+				// Name and region of the workspace are known only to Accounts API.
+				this.WorkspaceID, err = c.CurrentWorkspaceID(ctx)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				this.Cloud = currentWorkspaceCloud(c)
+				this.WorkspaceURL = strings.TrimSuffix(c.Host, "/")
+				d.SetId(fmt.Sprintf("%d", this.WorkspaceID))
+				err = common.StructToData(this, s, d)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				return nil
+			}
+			if this.AccountID == "" {
+				this.AccountID = c.AccountID
+			}
+			if this.AccountID == "" {
+				return diag.Errorf("account_id is required to look up workspace by " +
+					"workspace_id or deployment_name")
+			}
+			workspacesAPI := NewWorkspacesAPI(ctx, c)
+			workspaces, err := workspacesAPI.List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, ws := range workspaces {
+				if this.WorkspaceID != 0 && ws.WorkspaceID != this.WorkspaceID {
+					continue
+				}
+				if this.DeploymentName != "" && ws.DeploymentName != this.DeploymentName {
+					continue
+				}
+				this.WorkspaceID = ws.WorkspaceID
+				this.DeploymentName = ws.DeploymentName
+				this.WorkspaceName = ws.WorkspaceName
+				this.Cloud = workspaceClouds[ws.Cloud]
+				this.Region = ws.AwsRegion
+				if ws.Location != "" {
+					this.Region = ws.Location
+				}
+				this.WorkspaceURL = ws.WorkspaceURL
+				if this.WorkspaceURL == "" {
+					this.WorkspaceURL = fmt.Sprintf("https://%s",
+						generateWorkspaceHostname(c, ws))
+				}
+				d.SetId(fmt.Sprintf("%d", ws.WorkspaceID))
+				err = common.StructToData(this, s, d)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				return nil
+			}
+			return diag.Errorf("cannot find workspace with workspace_id=%d or deployment_name=%s",
+				this.WorkspaceID, this.DeploymentName)
+		},
+	}
+}
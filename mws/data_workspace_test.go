@@ -0,0 +1,149 @@
+package mws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var workspacesListFixture = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.0/accounts/abc/workspaces",
+	// raw JSON, because Workspace marshals only creation request fields on GCP
+	Response: `[
+		{
+			"workspace_id": 123,
+			"workspace_name": "first",
+			"deployment_name": "dbc-first",
+			"aws_region": "us-east-1",
+			"workspace_url": "https://dbc-first.cloud.databricks.com"
+		},
+		{
+			"workspace_id": 456,
+			"workspace_name": "second",
+			"deployment_name": "second",
+			"cloud": "gcp",
+			"location": "us-central1",
+			"workspace_url": "https://456.6.gcp.databricks.com"
+		}
+	]`,
+}
+
+func TestDataSourceWorkspace_ByID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{workspacesListFixture},
+		Read:        true,
+		Resource:    DataSourceWorkspace(),
+		NonWritable: true,
+		HCL: `
+		account_id = "abc"
+		workspace_id = 456
+		`,
+		ID: "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "456", d.Id())
+	assert.Equal(t, "second", d.Get("workspace_name"))
+	assert.Equal(t, "second", d.Get("deployment_name"))
+	assert.Equal(t, "GCP", d.Get("cloud"))
+	assert.Equal(t, "us-central1", d.Get("region"))
+	assert.Equal(t, "https://456.6.gcp.databricks.com", d.Get("workspace_url"))
+}
+
+func TestDataSourceWorkspace_ByDeploymentName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{workspacesListFixture},
+		Read:        true,
+		Resource:    DataSourceWorkspace(),
+		NonWritable: true,
+		HCL: `
+		account_id = "abc"
+		deployment_name = "dbc-first"
+		`,
+		ID: "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, 123, d.Get("workspace_id"))
+	assert.Equal(t, "first", d.Get("workspace_name"))
+	assert.Equal(t, "AWS", d.Get("cloud"))
+	assert.Equal(t, "us-east-1", d.Get("region"))
+	assert.Equal(t, "https://dbc-first.cloud.databricks.com", d.Get("workspace_url"))
+}
+
+func TestDataSourceWorkspace_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{workspacesListFixture},
+		Read:        true,
+		Resource:    DataSourceWorkspace(),
+		NonWritable: true,
+		HCL: `
+		account_id = "abc"
+		workspace_id = 789
+		`,
+		ID: "_",
+	}.ExpectError(t, "cannot find workspace with workspace_id=789 or deployment_name=")
+}
+
+func TestDataSourceWorkspace_Current(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				ResponseHeaders: map[string]string{
+					"X-Databricks-Org-Id": "789",
+				},
+				Response: map[string]interface{}{
+					"userName": "me@example.com",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceWorkspace(),
+		NonWritable: true,
+		HCL:         ``,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, 789, d.Get("workspace_id"))
+	assert.Equal(t, "AWS", d.Get("cloud"))
+	assert.True(t, strings.HasPrefix(d.Get("workspace_url").(string), "http://127.0.0.1:"),
+		d.Get("workspace_url"))
+	assert.Equal(t, "", d.Get("workspace_name"))
+}
+
+func TestDataSourceWorkspace_Current_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Status:   403,
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "nope",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceWorkspace(),
+		NonWritable: true,
+		HCL:         ``,
+		ID:          "_",
+	}.ExpectError(t, "nope")
+}
+
+func TestDataSourceWorkspace_NoAccountID(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceWorkspace(),
+		NonWritable: true,
+		HCL:         `workspace_id = 456`,
+		ID:          "_",
+	}.ExpectError(t, "account_id is required to look up workspace by workspace_id or deployment_name")
+}
@@ -48,15 +48,26 @@ type NetworkVPCEndpoints struct {
 	DataplaneRelayAPI []string `json:"dataplane_relay" tf:"slice_set"`
 }
 
+// GcpNetworkInfo is the customer-managed VPC of a workspace on GCP
+type GcpNetworkInfo struct {
+	NetworkProjectID string `json:"network_project_id"`
+	VPCID            string `json:"vpc_id"`
+	SubnetID         string `json:"subnet_id"`
+	SubnetRegion     string `json:"subnet_region"`
+	PodSubnetID      string `json:"pod_subnet_id"`
+	ServiceSubnetID  string `json:"service_subnet_id"`
+}
+
 // Network is the object that contains all the information for BYOVPC
 type Network struct {
 	AccountID        string               `json:"account_id"`
 	NetworkID        string               `json:"network_id,omitempty" tf:"computed"`
 	NetworkName      string               `json:"network_name"`
-	VPCID            string               `json:"vpc_id"`
-	SubnetIds        []string             `json:"subnet_ids" tf:"slice_set"`
+	VPCID            string               `json:"vpc_id,omitempty"`
+	SubnetIds        []string             `json:"subnet_ids,omitempty" tf:"slice_set"`
 	VPCEndpoints     *NetworkVPCEndpoints `json:"vpc_endpoints,omitempty" tf:"computed"`
-	SecurityGroupIds []string             `json:"security_group_ids" tf:"slice_set"`
+	SecurityGroupIds []string             `json:"security_group_ids,omitempty" tf:"slice_set"`
+	GcpNetworkInfo   *GcpNetworkInfo      `json:"gcp_network_info,omitempty"`
 	VPCStatus        string               `json:"vpc_status,omitempty" tf:"computed"`
 	ErrorMessages    []NetworkHealth      `json:"error_messages,omitempty" tf:"computed"`
 	WorkspaceID      int64                `json:"workspace_id,omitempty" tf:"computed"`
@@ -118,6 +129,7 @@ type Workspace struct {
 	WorkspaceURL                        string `json:"workspace_url,omitempty" tf:"computed"`
 	WorkspaceStatus                     string `json:"workspace_status,omitempty" tf:"computed"`
 	WorkspaceStatusMessage              string `json:"workspace_status_message,omitempty" tf:"computed"`
+	NetworkPolicyID                     string `json:"network_policy_id,omitempty" tf:"computed"`
 	CreationTime                        int64  `json:"creation_time,omitempty" tf:"computed"`
 
 	ExternalCustomerInfo *externalCustomerInfo `json:"external_customer_info,omitempty"`
@@ -176,6 +188,36 @@ type PrivateAccessSettings struct {
 	AllowedVpcEndpointIDS []string `json:"allowed_vpc_endpoint_ids,omitempty"`
 }
 
+// NetworkPolicy is the object that contains network settings of a workspace. There is exactly one per workspace.
+type NetworkPolicy struct {
+	AccountID                            string   `json:"account_id,omitempty" tf:"computed,force_new"`
+	WorkspaceID                          int64    `json:"workspace_id" tf:"force_new"`
+	NetworkPolicyID                      string   `json:"network_policy_id,omitempty" tf:"computed"`
+	DefaultStorageFirewallEnabled        bool     `json:"default_storage_firewall_enabled,omitempty"`
+	StorageFirewallAllowedVpcEndpointIDs []string `json:"storage_firewall_allowed_vpc_endpoint_ids,omitempty" tf:"slice_set"`
+}
+
+// PermissionAssignment is the object that assigns user, service principal or group to a workspace
+type PermissionAssignment struct {
+	AccountID   string   `json:"account_id,omitempty" tf:"computed,force_new"`
+	WorkspaceID int64    `json:"workspace_id" tf:"force_new"`
+	PrincipalID int64    `json:"principal_id" tf:"force_new"`
+	Permissions []string `json:"permissions" tf:"slice_set"`
+}
+
+type permissionAssignmentPrincipal struct {
+	PrincipalID int64 `json:"principal_id"`
+}
+
+type permissionAssignmentEntry struct {
+	Principal   permissionAssignmentPrincipal `json:"principal"`
+	Permissions []string                      `json:"permissions"`
+}
+
+type permissionAssignmentList struct {
+	PermissionAssignments []permissionAssignmentEntry `json:"permission_assignments"`
+}
+
 type externalCustomerInfo struct {
 	CustomerName              string `json:"customer_name"`
 	AuthoritativeUserEmail    string `json:"authoritative_user_email"`
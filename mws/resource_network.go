@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
@@ -67,6 +68,19 @@ func (a NetworksAPI) List(mwsAcctID string) ([]Network, error) {
 	return mwsNetworkList, err
 }
 
+var (
+	gcpNameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+	gcpNetworkInfoFormats = map[string]*regexp.Regexp{
+		"network_project_id": regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`),
+		"vpc_id":             gcpNameRegex,
+		"subnet_id":          gcpNameRegex,
+		"subnet_region":      regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`),
+		"pod_subnet_id":      gcpNameRegex,
+		"service_subnet_id":  gcpNameRegex,
+	}
+)
+
 // ResourceNetwork ...
 func ResourceNetwork() *schema.Resource {
 	s := common.StructToSchema(Network{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
@@ -76,6 +90,17 @@ func ResourceNetwork() *schema.Resource {
 		s["subnet_ids"].MinItems = 2
 		s["security_group_ids"].MinItems = 1
 		s["security_group_ids"].MaxItems = 5
+		// either AWS or GCP network configuration has to be specified
+		for _, field := range []string{"vpc_id", "subnet_ids", "security_group_ids"} {
+			s[field].RequiredWith = []string{"vpc_id", "subnet_ids", "security_group_ids"}
+		}
+		s["vpc_id"].ExactlyOneOf = []string{"vpc_id", "gcp_network_info"}
+		s["gcp_network_info"].ConflictsWith = []string{"vpc_id", "subnet_ids", "security_group_ids"}
+		for field, re := range gcpNetworkInfoFormats {
+			if p, err := common.SchemaPath(s, "gcp_network_info", field); err == nil {
+				p.ValidateFunc = validation.StringMatch(re, "must be a valid GCP resource ID")
+			}
+		}
 		return s
 	})
 	p := common.NewPairSeparatedID("account_id", "network_id", "/")
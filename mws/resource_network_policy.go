@@ -0,0 +1,116 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewNetworkPoliciesAPI creates NetworkPoliciesAPI instance from provider meta
+func NewNetworkPoliciesAPI(ctx context.Context, m interface{}) NetworkPoliciesAPI {
+	return NetworkPoliciesAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// NetworkPoliciesAPI exposes the workspace network policy API
+type NetworkPoliciesAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+func networkPolicyAPIPath(mwsAcctID string, workspaceID int64) string {
+	return fmt.Sprintf("/accounts/%s/workspaces/%d/network-policy", mwsAcctID, workspaceID)
+}
+
+// Update replaces network policy of the workspace
+func (a NetworkPoliciesAPI) Update(np NetworkPolicy) error {
+	return a.client.Put(a.context, networkPolicyAPIPath(np.AccountID, np.WorkspaceID), np)
+}
+
+// Read returns network policy of the workspace
+func (a NetworkPoliciesAPI) Read(mwsAcctID string, workspaceID int64) (np NetworkPolicy, err error) {
+	err = a.client.Get(a.context, networkPolicyAPIPath(mwsAcctID, workspaceID), nil, &np)
+	return
+}
+
+// Delete resets network policy of the workspace to defaults
+func (a NetworkPoliciesAPI) Delete(mwsAcctID string, workspaceID int64) error {
+	return a.client.Delete(a.context, networkPolicyAPIPath(mwsAcctID, workspaceID), nil)
+}
+
+// ResourceNetworkPolicy manages the singleton network policy of a workspace
+func ResourceNetworkPolicy() *schema.Resource {
+	s := common.StructToSchema(NetworkPolicy{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	// policy is identified by workspace ID, while account ID could come from provider configuration
+	unpack := func(d *schema.ResourceData, c *common.DatabricksClient) (string, int64, error) {
+		workspaceID, err := strconv.ParseInt(d.Id(), 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid workspace ID: %s", d.Id())
+		}
+		accountID := d.Get("account_id").(string)
+		if accountID == "" {
+			accountID = c.AccountID
+		}
+		if accountID == "" {
+			return "", 0, fmt.Errorf("account_id is required")
+		}
+		return accountID, workspaceID, nil
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var np NetworkPolicy
+			if err := common.DataToStructPointer(d, s, &np); err != nil {
+				return err
+			}
+			if np.AccountID == "" {
+				np.AccountID = c.AccountID
+			}
+			if err := NewNetworkPoliciesAPI(ctx, c).Update(np); err != nil {
+				return err
+			}
+			d.Set("account_id", np.AccountID)
+			d.SetId(fmt.Sprintf("%d", np.WorkspaceID))
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, workspaceID, err := unpack(d, c)
+			if err != nil {
+				return err
+			}
+			np, err := NewNetworkPoliciesAPI(ctx, c).Read(accountID, workspaceID)
+			if err != nil {
+				return err
+			}
+			np.AccountID = accountID
+			np.WorkspaceID = workspaceID
+			return common.StructToData(np, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, workspaceID, err := unpack(d, c)
+			if err != nil {
+				return err
+			}
+			var np NetworkPolicy
+			if err := common.DataToStructPointer(d, s, &np); err != nil {
+				return err
+			}
+			np.AccountID = accountID
+			np.WorkspaceID = workspaceID
+			return NewNetworkPoliciesAPI(ctx, c).Update(np)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, workspaceID, err := unpack(d, c)
+			if err != nil {
+				return err
+			}
+			return NewNetworkPoliciesAPI(ctx, c).Delete(accountID, workspaceID)
+		},
+	}.ToResource()
+}
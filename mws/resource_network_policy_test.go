@@ -0,0 +1,178 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceNetworkPolicyCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+				ExpectedRequest: NetworkPolicy{
+					AccountID:                            "abc",
+					WorkspaceID:                          123,
+					DefaultStorageFirewallEnabled:        true,
+					StorageFirewallAllowedVpcEndpointIDs: []string{"vpce-1"},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+				Response: NetworkPolicy{
+					AccountID:                            "abc",
+					WorkspaceID:                          123,
+					NetworkPolicyID:                      "np-1",
+					DefaultStorageFirewallEnabled:        true,
+					StorageFirewallAllowedVpcEndpointIDs: []string{"vpce-1"},
+				},
+			},
+		},
+		Resource: ResourceNetworkPolicy(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		default_storage_firewall_enabled = true
+		storage_firewall_allowed_vpc_endpoint_ids = ["vpce-1"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, "np-1", d.Get("network_policy_id"))
+}
+
+func TestResourceNetworkPolicyCreate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceNetworkPolicy(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
+}
+
+func TestResourceNetworkPolicyRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+				Response: NetworkPolicy{
+					NetworkPolicyID:                      "np-1",
+					StorageFirewallAllowedVpcEndpointIDs: []string{"vpce-1", "vpce-2"},
+				},
+			},
+		},
+		Resource: ResourceNetworkPolicy(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		`,
+		Read: true,
+		New:  true,
+		ID:   "123",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, 123, d.Get("workspace_id"))
+	assert.Equal(t, "np-1", d.Get("network_policy_id"))
+	assert.Equal(t, false, d.Get("default_storage_firewall_enabled"))
+	assert.Equal(t, 2, d.Get("storage_firewall_allowed_vpc_endpoint_ids.#"))
+}
+
+func TestResourceNetworkPolicyRead_InvalidID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNetworkPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "abc/123",
+	}.ExpectError(t, "invalid workspace ID: abc/123")
+}
+
+func TestResourceNetworkPolicyRead_NoAccountID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNetworkPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "123",
+	}.ExpectError(t, "account_id is required")
+}
+
+func TestResourceNetworkPolicyUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+				ExpectedRequest: NetworkPolicy{
+					AccountID:                     "abc",
+					WorkspaceID:                   123,
+					NetworkPolicyID:               "np-1",
+					DefaultStorageFirewallEnabled: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+				Response: NetworkPolicy{
+					NetworkPolicyID:               "np-1",
+					DefaultStorageFirewallEnabled: true,
+				},
+			},
+		},
+		Resource: ResourceNetworkPolicy(),
+		InstanceState: map[string]string{
+			"account_id":        "abc",
+			"workspace_id":      "123",
+			"network_policy_id": "np-1",
+		},
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		default_storage_firewall_enabled = true
+		`,
+		Update: true,
+		ID:     "123",
+	}.ApplyNoError(t)
+}
+
+func TestResourceNetworkPolicyDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/network-policy",
+			},
+		},
+		Resource: ResourceNetworkPolicy(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		`,
+		Delete: true,
+		ID:     "123",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123", d.Id())
+}
@@ -240,3 +240,117 @@ func TestResourceNetworkDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/nid", d.Id())
 }
+
+func TestResourceNetworkCreate_GcpNetworkInfo(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/networks",
+				ExpectedRequest: Network{
+					AccountID:   "abc",
+					NetworkName: "Open Workers",
+					GcpNetworkInfo: &GcpNetworkInfo{
+						NetworkProjectID: "network-project",
+						VPCID:            "databricks-vpc",
+						SubnetID:         "databricks-subnet",
+						SubnetRegion:     "us-central1",
+						PodSubnetID:      "pods",
+						ServiceSubnetID:  "services",
+					},
+				},
+				Response: Network{
+					AccountID: "abc",
+					NetworkID: "nid",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks/nid",
+				Response: Network{
+					NetworkID:   "nid",
+					NetworkName: "Open Workers",
+					GcpNetworkInfo: &GcpNetworkInfo{
+						NetworkProjectID: "network-project",
+						VPCID:            "databricks-vpc",
+						SubnetID:         "databricks-subnet",
+						SubnetRegion:     "us-central1",
+						PodSubnetID:      "pods",
+						ServiceSubnetID:  "services",
+					},
+				},
+			},
+		},
+		Resource: ResourceNetwork(),
+		HCL: `
+		account_id = "abc"
+		network_name = "Open Workers"
+		gcp_network_info {
+			network_project_id = "network-project"
+			vpc_id = "databricks-vpc"
+			subnet_id = "databricks-subnet"
+			subnet_region = "us-central1"
+			pod_subnet_id = "pods"
+			service_subnet_id = "services"
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/nid", d.Id())
+	assert.Equal(t, "us-central1", d.Get("gcp_network_info.0.subnet_region"))
+}
+
+func TestResourceNetworkCreate_GcpNetworkInfoConflictsWithAws(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNetwork(),
+		HCL: `
+		account_id = "abc"
+		network_name = "Open Workers"
+		vpc_id = "five"
+		subnet_ids = ["three", "four"]
+		security_group_ids = ["one"]
+		gcp_network_info {
+			network_project_id = "network-project"
+			vpc_id = "databricks-vpc"
+			subnet_id = "databricks-subnet"
+			subnet_region = "us-central1"
+			pod_subnet_id = "pods"
+			service_subnet_id = "services"
+		}
+		`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [gcp_network_info] Conflicting configuration arguments. "+
+		"[vpc_id] Invalid combination of arguments")
+}
+
+func TestResourceNetworkCreate_NoNetworkInfo(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNetwork(),
+		HCL: `
+		account_id = "abc"
+		network_name = "Open Workers"
+		`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [vpc_id] Invalid combination of arguments")
+}
+
+func TestResourceNetworkCreate_GcpNetworkInfoInvalidFormat(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNetwork(),
+		HCL: `
+		account_id = "abc"
+		network_name = "Open Workers"
+		gcp_network_info {
+			network_project_id = "network-project"
+			vpc_id = "projects/network-project/global/networks/databricks-vpc"
+			subnet_id = "databricks-subnet"
+			subnet_region = "us-central1"
+			pod_subnet_id = "pods"
+			service_subnet_id = "services"
+		}
+		`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [gcp_network_info.#.vpc_id] invalid value "+
+		"for gcp_network_info.0.vpc_id (must be a valid GCP resource ID)")
+}
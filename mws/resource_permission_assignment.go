@@ -0,0 +1,159 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NewPermissionAssignmentAPI creates PermissionAssignmentAPI instance from provider meta
+func NewPermissionAssignmentAPI(ctx context.Context, m interface{}) PermissionAssignmentAPI {
+	return PermissionAssignmentAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// PermissionAssignmentAPI exposes the account-level workspace permission assignment API
+type PermissionAssignmentAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+func permissionAssignmentsAPIPath(mwsAcctID string, workspaceID int64) string {
+	return fmt.Sprintf("/accounts/%s/workspaces/%d/permissionassignments", mwsAcctID, workspaceID)
+}
+
+func permissionAssignmentAPIPath(pa PermissionAssignment) string {
+	return fmt.Sprintf("%s/principals/%d",
+		permissionAssignmentsAPIPath(pa.AccountID, pa.WorkspaceID), pa.PrincipalID)
+}
+
+// Update assigns principal to the workspace. API has upsert semantics: permissions
+// of already assigned principal are replaced, otherwise new assignment is created.
+func (a PermissionAssignmentAPI) Update(pa PermissionAssignment) error {
+	return a.client.Put(a.context, permissionAssignmentAPIPath(pa), map[string]interface{}{
+		"permissions": pa.Permissions,
+	})
+}
+
+// Read returns permissions of principal in the workspace
+func (a PermissionAssignmentAPI) Read(mwsAcctID string, workspaceID, principalID int64) (pa PermissionAssignment, err error) {
+	var list permissionAssignmentList
+	err = a.client.Get(a.context, permissionAssignmentsAPIPath(mwsAcctID, workspaceID), nil, &list)
+	if err != nil {
+		return
+	}
+	for _, v := range list.PermissionAssignments {
+		if v.Principal.PrincipalID != principalID {
+			continue
+		}
+		pa = PermissionAssignment{
+			AccountID:   mwsAcctID,
+			WorkspaceID: workspaceID,
+			PrincipalID: principalID,
+			Permissions: v.Permissions,
+		}
+		return
+	}
+	err = common.NotFound(fmt.Sprintf("principal %d is not assigned to workspace %d",
+		principalID, workspaceID))
+	return
+}
+
+// Delete removes principal from the workspace
+func (a PermissionAssignmentAPI) Delete(pa PermissionAssignment) error {
+	return a.client.Delete(a.context, permissionAssignmentAPIPath(pa), nil)
+}
+
+// ResourcePermissionAssignment manages assignment of principals to workspaces on account level
+func ResourcePermissionAssignment() *schema.Resource {
+	s := common.StructToSchema(PermissionAssignment{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		s["permissions"].Elem.(*schema.Schema).ValidateFunc = validation.StringInSlice(
+			[]string{"USER", "ADMIN"}, false)
+		return s
+	})
+	// assignment is identified by workspace and principal IDs, while account ID could
+	// come from provider configuration
+	p := common.NewPairSeparatedID("workspace_id", "principal_id", "|").Schema(
+		func(_ map[string]*schema.Schema) map[string]*schema.Schema {
+			return s
+		})
+	unpack := func(d *schema.ResourceData, c *common.DatabricksClient) (pa PermissionAssignment, err error) {
+		workspaceID, principalID, err := p.Unpack(d)
+		if err != nil {
+			return
+		}
+		pa.WorkspaceID, err = strconv.ParseInt(workspaceID, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid workspace_id: %s", workspaceID)
+			return
+		}
+		pa.PrincipalID, err = strconv.ParseInt(principalID, 10, 64)
+		if err != nil {
+			return
+		}
+		pa.AccountID = d.Get("account_id").(string)
+		if pa.AccountID == "" {
+			pa.AccountID = c.AccountID
+		}
+		if pa.AccountID == "" {
+			err = fmt.Errorf("account_id is required")
+		}
+		return
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var pa PermissionAssignment
+			if err := common.DataToStructPointer(d, s, &pa); err != nil {
+				return err
+			}
+			if pa.AccountID == "" {
+				pa.AccountID = c.AccountID
+			}
+			if pa.AccountID == "" {
+				return fmt.Errorf("account_id is required")
+			}
+			if err := NewPermissionAssignmentAPI(ctx, c).Update(pa); err != nil {
+				return err
+			}
+			d.Set("account_id", pa.AccountID)
+			p.Pack(d)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			id, err := unpack(d, c)
+			if err != nil {
+				return err
+			}
+			pa, err := NewPermissionAssignmentAPI(ctx, c).Read(id.AccountID, id.WorkspaceID, id.PrincipalID)
+			if err != nil {
+				return err
+			}
+			return common.StructToData(pa, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			id, err := unpack(d, c)
+			if err != nil {
+				return err
+			}
+			var pa PermissionAssignment
+			if err := common.DataToStructPointer(d, s, &pa); err != nil {
+				return err
+			}
+			id.Permissions = pa.Permissions
+			return NewPermissionAssignmentAPI(ctx, c).Update(id)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			id, err := unpack(d, c)
+			if err != nil {
+				return err
+			}
+			return NewPermissionAssignmentAPI(ctx, c).Delete(id)
+		},
+	}.ToResource()
+}
@@ -0,0 +1,206 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func permissionAssignmentListFixture(permissions ...string) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+		Response: permissionAssignmentList{
+			PermissionAssignments: []permissionAssignmentEntry{
+				{
+					Principal:   permissionAssignmentPrincipal{PrincipalID: 1},
+					Permissions: []string{"ADMIN"},
+				},
+				{
+					Principal:   permissionAssignmentPrincipal{PrincipalID: 456},
+					Permissions: permissions,
+				},
+			},
+		},
+	}
+}
+
+func TestResourcePermissionAssignmentCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/456",
+				ExpectedRequest: map[string]interface{}{
+					"permissions": []string{"USER"},
+				},
+			},
+			permissionAssignmentListFixture("USER"),
+		},
+		Resource: ResourcePermissionAssignment(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["USER"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123|456", d.Id())
+	assert.Equal(t, 1, d.Get("permissions.#"))
+}
+
+func TestResourcePermissionAssignmentCreate_InvalidPermission(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourcePermissionAssignment(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["OWNER"]
+		`,
+		Create: true,
+	}.ExpectError(t, "invalid config supplied. [permissions] expected permissions.0 to be one of [USER ADMIN], got OWNER")
+}
+
+func TestResourcePermissionAssignmentCreate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/456",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourcePermissionAssignment(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["USER"]
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
+}
+
+func TestResourcePermissionAssignmentRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			permissionAssignmentListFixture("USER", "ADMIN"),
+		},
+		Resource: ResourcePermissionAssignment(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["USER"]
+		`,
+		Read: true,
+		New:  true,
+		ID:   "123|456",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 123, d.Get("workspace_id"))
+	assert.Equal(t, 456, d.Get("principal_id"))
+	assert.Equal(t, 2, d.Get("permissions.#"))
+}
+
+func TestResourcePermissionAssignmentRead_NotAssigned(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: permissionAssignmentList{},
+			},
+		},
+		Resource: ResourcePermissionAssignment(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["USER"]
+		`,
+		Read:    true,
+		New:     true,
+		Removed: true,
+		ID:      "123|456",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePermissionAssignmentRead_InvalidID(t *testing.T) {
+	for id, message := range map[string]string{
+		"123":     "invalid ID: 123",
+		"123|":    "principal_id cannot be empty",
+		"abc|456": "invalid workspace_id: abc",
+		"123|abc": `strconv.ParseInt: parsing "abc": invalid syntax`,
+	} {
+		qa.ResourceFixture{
+			Resource: ResourcePermissionAssignment(),
+			Read:     true,
+			New:      true,
+			ID:       id,
+		}.ExpectError(t, message)
+	}
+}
+
+func TestResourcePermissionAssignmentUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/456",
+				ExpectedRequest: map[string]interface{}{
+					"permissions": []string{"ADMIN"},
+				},
+			},
+			permissionAssignmentListFixture("ADMIN"),
+		},
+		Resource: ResourcePermissionAssignment(),
+		InstanceState: map[string]string{
+			"account_id":   "abc",
+			"workspace_id": "123",
+			"principal_id": "456",
+		},
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["ADMIN"]
+		`,
+		Update: true,
+		ID:     "123|456",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePermissionAssignmentDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/456",
+			},
+		},
+		Resource: ResourcePermissionAssignment(),
+		HCL: `
+		account_id = "abc"
+		workspace_id = 123
+		principal_id = 456
+		permissions = ["USER"]
+		`,
+		Delete: true,
+		ID:     "123|456",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "123|456", d.Id())
+}
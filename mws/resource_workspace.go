@@ -111,6 +111,29 @@ func (a WorkspacesAPI) explainWorkspaceFailure(ws Workspace) error {
 		ws.WorkspaceStatusMessage, strBuffer.String())
 }
 
+// validateNoPublicIPNetwork checks, that customer-managed VPC can be used with secure cluster
+// connectivity, where cluster nodes have no public IPs and reach control plane through NAT gateway
+func (a WorkspacesAPI) validateNoPublicIPNetwork(ws Workspace) error {
+	if !ws.IsNoPublicIPEnabled || ws.NetworkID == "" {
+		return nil
+	}
+	network, err := NewNetworksAPI(a.context, a.client).Read(ws.AccountID, ws.NetworkID)
+	if err != nil {
+		return fmt.Errorf("cannot read network %s: %w", ws.NetworkID, err)
+	}
+	if network.VPCStatus != "BROKEN" {
+		return nil
+	}
+	problems := []string{}
+	for _, networkHealth := range network.ErrorMessages {
+		problems = append(problems, fmt.Sprintf("%s: %s",
+			networkHealth.ErrorType, networkHealth.ErrorMessage))
+	}
+	return fmt.Errorf("network %s cannot be used with is_no_public_ip_enabled, because its "+
+		"VPC is broken: %s. Private subnets must have a route to a NAT gateway",
+		ws.NetworkID, strings.Join(problems, "; "))
+}
+
 // WaitForRunning will wait until workspace is running, otherwise will try to explain why it failed
 func (a WorkspacesAPI) WaitForRunning(ws Workspace, timeout time.Duration) error {
 	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
@@ -283,6 +306,9 @@ func ResourceWorkspace() *schema.Resource {
 			if err := requireFields(c.IsGcp(), d, "location"); err != nil {
 				return err
 			}
+			if err := workspacesAPI.validateNoPublicIPNetwork(workspace); err != nil {
+				return err
+			}
 			if len(workspace.CustomerManagedKeyID) > 0 && len(workspace.ManagedServicesCustomerManagedKeyID) == 0 {
 				log.Print("[INFO] Using existing customer_managed_key_id as value for new managed_services_customer_managed_key_id")
 				workspace.ManagedServicesCustomerManagedKeyID = workspace.CustomerManagedKeyID
@@ -53,6 +53,14 @@ func TestGcpaAccWorkspace(t *testing.T) {
 func TestResourceWorkspaceCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks/fgh",
+				Response: Network{
+					NetworkID: "fgh",
+					VPCStatus: "VALID",
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/accounts/abc/workspaces",
@@ -253,6 +261,14 @@ func TestResourceWorkspaceCreateWithIsNoPublicIPEnabledFalse(t *testing.T) {
 func TestResourceWorkspaceCreateLegacyConfig(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks/fgh",
+				Response: Network{
+					NetworkID: "fgh",
+					VPCStatus: "VALID",
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/accounts/abc/workspaces",
@@ -308,6 +324,40 @@ func TestResourceWorkspaceCreateLegacyConfig(t *testing.T) {
 	assert.Equal(t, "abc/1234", d.Id())
 }
 
+func TestResourceWorkspaceCreate_BrokenNetworkWithoutPublicIP(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks/fgh",
+				Response: Network{
+					NetworkID: "fgh",
+					VPCStatus: "BROKEN",
+					ErrorMessages: []NetworkHealth{
+						{
+							ErrorType:    "subnet",
+							ErrorMessage: "subnet-1 has no route to NAT gateway",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		State: map[string]interface{}{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"network_id":               "fgh",
+			"storage_configuration_id": "ghi",
+		},
+		Create: true,
+	}.ExpectError(t, "network fgh cannot be used with is_no_public_ip_enabled, because "+
+		"its VPC is broken: subnet: subnet-1 has no route to NAT gateway. "+
+		"Private subnets must have a route to a NAT gateway")
+}
+
 func TestResourceWorkspaceCreate_Error(t *testing.T) {
 	t.Skipf("Making this test skip until we can configure sleep timings for test purposes")
 	d, err := qa.ResourceFixture{
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func clientCloud(c *common.DatabricksClient) string {
+	switch {
+	case c.IsAzure():
+		return "azure"
+	case c.IsGcp():
+		return "gcp"
+	default:
+		return "aws"
+	}
+}
+
+// DataSourceDebug returns provider diagnostics, that are safe to share in bug reports
+func DataSourceDebug() *schema.Resource {
+	type entity struct {
+		WorkspaceHost   string `json:"workspace_host,omitempty" tf:"computed"`
+		AuthType        string `json:"auth_type,omitempty" tf:"computed"`
+		WorkspaceID     int64  `json:"workspace_id,omitempty" tf:"computed"`
+		Cloud           string `json:"cloud,omitempty" tf:"computed"`
+		ProviderVersion string `json:"provider_version,omitempty" tf:"computed"`
+		GoVersion       string `json:"go_version,omitempty" tf:"computed"`
+		APILatencyMs    int64  `json:"api_latency_ms,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			c := m.(*common.DatabricksClient)
+			start := time.Now()
+			workspaceID, err := c.CurrentWorkspaceID(ctx)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("cannot reach workspace: %w", err))
+			}
+			this := entity{
+				WorkspaceHost:   c.Host,
+				AuthType:        c.AuthType(),
+				WorkspaceID:     workspaceID,
+				Cloud:           clientCloud(c),
+				ProviderVersion: common.Version(),
+				GoVersion:       runtime.Version(),
+				APILatencyMs:    time.Since(start).Milliseconds(),
+			}
+			d.SetId(c.Host)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceDebug(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: map[string]interface{}{
+					"userName": "me@example.com",
+				},
+				ResponseHeaders: map[string]string{
+					"X-Databricks-Org-Id": "1234567890123456",
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceDebug(),
+		NonWritable: true,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "pat", d.Get("auth_type"))
+	assert.Equal(t, "aws", d.Get("cloud"))
+	assert.Equal(t, 1234567890123456, d.Get("workspace_id"))
+	assert.Equal(t, common.Version(), d.Get("provider_version"))
+	assert.Equal(t, runtime.Version(), d.Get("go_version"))
+	assert.NotEqual(t, "", d.Get("workspace_host"))
+	_, hasToken := d.GetOk("token")
+	assert.False(t, hasToken)
+}
+
+func TestDataSourceDebug_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "Invalid access token",
+				},
+				Status: 403,
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceDebug(),
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "cannot reach workspace: Invalid access token")
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/databrickslabs/terraform-provider-databricks/access"
+	"github.com/databrickslabs/terraform-provider-databricks/catalog"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/compute"
 	"github.com/databrickslabs/terraform-provider-databricks/identity"
+	"github.com/databrickslabs/terraform-provider-databricks/mlflow"
 	"github.com/databrickslabs/terraform-provider-databricks/mws"
 	"github.com/databrickslabs/terraform-provider-databricks/sqlanalytics"
 	"github.com/databrickslabs/terraform-provider-databricks/storage"
@@ -25,19 +27,30 @@ import (
 func DatabricksProvider() *schema.Provider {
 	p := &schema.Provider{
 		DataSourcesMap: map[string]*schema.Resource{
-			"databricks_aws_crossaccount_policy": access.DataAwsCrossAccountPolicy(),
-			"databricks_aws_assume_role_policy":  access.DataAwsAssumeRolePolicy(),
-			"databricks_aws_bucket_policy":       access.DataAwsBucketPolicy(),
-			"databricks_current_user":            identity.DataSourceCurrentUser(),
-			"databricks_dbfs_file":               storage.DataSourceDBFSFile(),
-			"databricks_dbfs_file_paths":         storage.DataSourceDBFSFilePaths(),
-			"databricks_group":                   identity.DataSourceGroup(),
-			"databricks_node_type":               compute.DataSourceNodeType(),
-			"databricks_notebook":                workspace.DataSourceNotebook(),
-			"databricks_notebook_paths":          workspace.DataSourceNotebookPaths(),
-			"databricks_spark_version":           compute.DataSourceSparkVersion(),
-			"databricks_user":                    identity.DataSourceUser(),
-			"databricks_zones":                   compute.DataSourceClusterZones(),
+			"databricks_aws_crossaccount_policy":  access.DataAwsCrossAccountPolicy(),
+			"databricks_aws_assume_role_policy":   access.DataAwsAssumeRolePolicy(),
+			"databricks_aws_bucket_policy":        access.DataAwsBucketPolicy(),
+			"databricks_cluster":                  compute.DataSourceCluster(),
+			"databricks_cluster_policy_allowlist": compute.DataSourceClusterPolicyAllowlist(),
+			"databricks_cluster_policy_usage":     compute.DataSourceClusterPolicyUsage(),
+			"databricks_cluster_events":           compute.DataSourceClusterEvents(),
+			"databricks_cluster_tags":             compute.DataSourceClusterTags(),
+			"databricks_current_user":             identity.DataSourceCurrentUser(),
+			"databricks_dbfs_file":                storage.DataSourceDBFSFile(),
+			"databricks_dbfs_file_paths":          storage.DataSourceDBFSFilePaths(),
+			"databricks_debug":                    DataSourceDebug(),
+			"databricks_group":                    identity.DataSourceGroup(),
+			"databricks_job_last_run":             compute.DataSourceJobLastRun(),
+			"databricks_node_type":                compute.DataSourceNodeType(),
+			"databricks_node_types":               compute.DataSourceNodeTypes(),
+			"databricks_notebook":                 workspace.DataSourceNotebook(),
+			"databricks_notebook_paths":           workspace.DataSourceNotebookPaths(),
+			"databricks_permissions":              access.DataSourcePermissions(),
+			"databricks_spark_version":            compute.DataSourceSparkVersion(),
+			"databricks_sql_statement":            sqlanalytics.DataSourceSQLStatement(),
+			"databricks_user":                     identity.DataSourceUser(),
+			"databricks_workspace":                mws.DataSourceWorkspace(),
+			"databricks_zones":                    compute.DataSourceClusterZones(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"databricks_secret":          access.ResourceSecret(),
@@ -47,6 +60,11 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_sql_permissions": access.ResourceSqlPermissions(),
 			"databricks_ip_access_list":  access.ResourceIPAccessList(),
 
+			"databricks_artifact_allowlist":   catalog.ResourceArtifactAllowlist(),
+			"databricks_connection":           catalog.ResourceConnection(),
+			"databricks_metastore":            catalog.ResourceMetastore(),
+			"databricks_metastore_assignment": catalog.ResourceMetastoreAssignment(),
+
 			"databricks_cluster":        compute.ResourceCluster(),
 			"databricks_cluster_policy": compute.ResourceClusterPolicy(),
 			"databricks_instance_pool":  compute.ResourceInstancePool(),
@@ -63,14 +81,18 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_user":                   identity.ResourceUser(),
 			"databricks_service_principal":      identity.ResourceServicePrincipal(),
 
+			"databricks_automl_experiment": mlflow.ResourceAutoMLExperiment(),
+
 			"databricks_mws_customer_managed_keys":   mws.ResourceCustomerManagedKey(),
 			"databricks_mws_credentials":             mws.ResourceCredentials(),
 			"databricks_mws_log_delivery":            mws.ResourceLogDelivery(),
 			"databricks_mws_networks":                mws.ResourceNetwork(),
+			"databricks_mws_permission_assignment":   mws.ResourcePermissionAssignment(),
 			"databricks_mws_private_access_settings": mws.ResourcePrivateAccessSettings(),
 			"databricks_mws_storage_configurations":  mws.ResourceStorageConfiguration(),
 			"databricks_mws_vpc_endpoint":            mws.ResourceVPCEndpoint(),
 			"databricks_mws_workspaces":              mws.ResourceWorkspace(),
+			"databricks_network_policy":              mws.ResourceNetworkPolicy(),
 
 			"databricks_aws_s3_mount":          storage.ResourceAWSS3Mount(),
 			"databricks_azure_adls_gen1_mount": storage.ResourceAzureAdlsGen1Mount(),
@@ -123,6 +145,7 @@ func providerSchema() map[string]*schema.Schema {
 
 	ps["token"].Sensitive = true
 	ps["azure_client_secret"].Sensitive = true
+	ps["git_token"].Sensitive = true
 
 	azCoordinatesDeprecation := "`%s` is deprecated and would be removed in v0.4.0. Please rewrite provider configuration " +
 		"with `host = data.azurerm_databricks_workspace.example.workspace_url` to achieve the same effect. " +
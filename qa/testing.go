@@ -62,6 +62,7 @@ type HTTPFixture struct {
 	Resource        string
 	Response        interface{}
 	Status          int
+	ResponseHeaders map[string]string
 	ExpectedRequest interface{}
 	ReuseRequest    bool
 	MatchAny        bool
@@ -88,6 +89,8 @@ type ResourceFixture struct {
 	AzureSPN    bool
 	Gcp         bool
 	Token       string
+	// account ID of the provider, that is used by account-level resources
+	AccountID string
 	// new resource
 	New bool
 }
@@ -168,6 +171,9 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if f.Gcp {
 		client.GoogleServiceAccount = "sa@prj.iam.gserviceaccount.com"
 	}
+	if f.AccountID != "" {
+		client.AccountID = f.AccountID
+	}
 	if len(f.HCL) > 0 {
 		var out interface{}
 		// TODO: update to HCLv2 somehow, so that importer and this use the same stuff
@@ -281,6 +287,11 @@ func CornerCaseSkipCRUD(method string) CornerCase {
 	return CornerCase{"skip_crud", method}
 }
 
+// CornerCaseAccountID sets account ID of the provider for account-level resources
+func CornerCaseAccountID(id string) CornerCase {
+	return CornerCase{"account_id", id}
+}
+
 // ResourceCornerCases checks for corner cases of error handling. Optional field name used to create error
 func ResourceCornerCases(t *testing.T, resource *schema.Resource, cc ...CornerCase) {
 	config := map[string]string{
@@ -312,6 +323,7 @@ func ResourceCornerCases(t *testing.T, resource *schema.Resource, cc ...CornerCa
 			},
 		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.AccountID = config["account_id"]
 		validData := resource.TestResourceData()
 		validData.SetId(config["id"])
 		for n, v := range m {
@@ -380,6 +392,9 @@ func HttpFixtureClientWithToken(t *testing.T, fixtures []HTTPFixture, token stri
 		found := false
 		for i, fixture := range fixtures {
 			if (req.Method == fixture.Method && req.RequestURI == fixture.Resource) || fixture.MatchAny {
+				for k, v := range fixture.ResponseHeaders {
+					rw.Header().Set(k, v)
+				}
 				if fixture.Status == 0 {
 					rw.WriteHeader(200)
 				} else {
@@ -0,0 +1,263 @@
+package sqlanalytics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DefaultStatementTimeout is the default amount of time to wait for SQL statement to complete
+const DefaultStatementTimeout = 20 * time.Minute
+
+// States of SQL statement execution
+const (
+	StatementStatePending   = "PENDING"
+	StatementStateRunning   = "RUNNING"
+	StatementStateSucceeded = "SUCCEEDED"
+	StatementStateFailed    = "FAILED"
+	StatementStateCanceled  = "CANCELED"
+	StatementStateClosed    = "CLOSED"
+)
+
+// StatementParameter is the named parameter of SQL statement, that is referenced as :name
+type StatementParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
+
+// ExecuteStatementRequest ...
+type ExecuteStatementRequest struct {
+	WarehouseID   string               `json:"warehouse_id"`
+	Statement     string               `json:"statement"`
+	Parameters    []StatementParameter `json:"parameters,omitempty"`
+	WaitTimeout   string               `json:"wait_timeout,omitempty"`
+	OnWaitTimeout string               `json:"on_wait_timeout,omitempty"`
+	Disposition   string               `json:"disposition,omitempty"`
+	Format        string               `json:"format,omitempty"`
+}
+
+// StatementError ...
+type StatementError struct {
+	ErrorCode string `json:"error_code,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// StatementStatus ...
+type StatementStatus struct {
+	State string          `json:"state"`
+	Error *StatementError `json:"error,omitempty"`
+}
+
+// StatementColumn describes the column of the result set
+type StatementColumn struct {
+	Name     string `json:"name"`
+	TypeName string `json:"type_name,omitempty"`
+	Position int    `json:"position"`
+}
+
+// StatementResultSchema ...
+type StatementResultSchema struct {
+	Columns []StatementColumn `json:"columns,omitempty"`
+}
+
+// StatementManifest ...
+type StatementManifest struct {
+	Schema StatementResultSchema `json:"schema"`
+}
+
+// StatementResult contains a chunk of rows of the result set, where every value is a string
+// or null. Large result sets are split into chunks, that are fetched one by one.
+type StatementResult struct {
+	ChunkIndex     int        `json:"chunk_index,omitempty"`
+	NextChunkIndex int        `json:"next_chunk_index,omitempty"`
+	DataArray      [][]string `json:"data_array,omitempty"`
+}
+
+// StatementResponse ...
+type StatementResponse struct {
+	StatementID string             `json:"statement_id"`
+	Status      StatementStatus    `json:"status"`
+	Manifest    *StatementManifest `json:"manifest,omitempty"`
+	Result      *StatementResult   `json:"result,omitempty"`
+}
+
+// IsTerminal returns true if the statement cannot change its state anymore
+func (sr StatementResponse) IsTerminal() bool {
+	switch sr.Status.State {
+	case StatementStatePending, StatementStateRunning:
+		return false
+	}
+	return true
+}
+
+// NewStatementExecutionAPI ...
+func NewStatementExecutionAPI(ctx context.Context, m interface{}) StatementExecutionAPI {
+	return StatementExecutionAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// StatementExecutionAPI runs SQL statements on SQL warehouses
+type StatementExecutionAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Execute starts the statement and returns it, once it completes or wait_timeout is reached
+func (a StatementExecutionAPI) Execute(req ExecuteStatementRequest) (sr StatementResponse, err error) {
+	err = a.client.Post(a.context, "/sql/statements", req, &sr)
+	return
+}
+
+// Get ...
+func (a StatementExecutionAPI) Get(statementID string) (sr StatementResponse, err error) {
+	err = a.client.Get(a.context, fmt.Sprintf("/sql/statements/%s", statementID), nil, &sr)
+	return
+}
+
+// GetChunk returns the chunk of results of the succeeded statement
+func (a StatementExecutionAPI) GetChunk(statementID string, chunkIndex int) (chunk StatementResult, err error) {
+	err = a.client.Get(a.context, fmt.Sprintf("/sql/statements/%s/result/chunks/%d",
+		statementID, chunkIndex), nil, &chunk)
+	return
+}
+
+// Rows returns all rows of the succeeded statement, following every chunk of results
+func (a StatementExecutionAPI) Rows(sr StatementResponse) (rows [][]string, err error) {
+	if sr.Result == nil {
+		return
+	}
+	chunk := *sr.Result
+	for {
+		rows = append(rows, chunk.DataArray...)
+		if chunk.NextChunkIndex == 0 {
+			return
+		}
+		chunk, err = a.GetChunk(sr.StatementID, chunk.NextChunkIndex)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// ExecuteAndWait runs the statement and waits for its results
+func (a StatementExecutionAPI) ExecuteAndWait(req ExecuteStatementRequest,
+	timeout time.Duration) (sr StatementResponse, err error) {
+	req.OnWaitTimeout = "CONTINUE"
+	req.Disposition = "INLINE"
+	req.Format = "JSON_ARRAY"
+	sr, err = a.Execute(req)
+	if err != nil {
+		return
+	}
+	if !sr.IsTerminal() {
+		err = resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+			sr, err = a.Get(sr.StatementID)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if !sr.IsTerminal() {
+				return resource.RetryableError(fmt.Errorf("statement %s is %s",
+					sr.StatementID, sr.Status.State))
+			}
+			return nil
+		})
+		if err != nil {
+			return
+		}
+	}
+	if sr.Status.State != StatementStateSucceeded {
+		message := "no error message"
+		if sr.Status.Error != nil {
+			message = sr.Status.Error.Message
+		}
+		err = fmt.Errorf("statement %s is %s: %s", sr.StatementID, sr.Status.State, message)
+	}
+	return
+}
+
+type statementRow struct {
+	Values []string `json:"values,omitempty"`
+}
+
+type statementResults struct {
+	Schema []StatementColumn `json:"schema,omitempty"`
+	Data   []statementRow    `json:"data,omitempty"`
+}
+
+// API accepts either 0s for asynchronous execution or 5 to 50 seconds
+var waitTimeoutRegex = regexp.MustCompile(`^(0|[5-9]|[1-4][0-9]|50)s$`)
+
+// DataSourceSQLStatement runs SQL statement on SQL warehouse on every read and returns its results.
+// Nothing prevents statements from changing data, so it's up to users to keep them read-only.
+func DataSourceSQLStatement() *schema.Resource {
+	type entity struct {
+		WarehouseID string               `json:"warehouse_id"`
+		Statement   string               `json:"statement"`
+		Parameters  []StatementParameter `json:"parameters,omitempty"`
+		WaitTimeout string               `json:"wait_timeout,omitempty"`
+		StatementID string               `json:"statement_id,omitempty" tf:"computed"`
+		Status      string               `json:"status,omitempty" tf:"computed"`
+		Results     *statementResults    `json:"results,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["wait_timeout"].Default = "30s"
+		s["wait_timeout"].ValidateFunc = validation.StringMatch(waitTimeoutRegex,
+			"wait_timeout must be 0s or between 5s and 50s")
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) diag.Diagnostics {
+			var this entity
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			statementsAPI := NewStatementExecutionAPI(ctx, m)
+			sr, err := statementsAPI.ExecuteAndWait(ExecuteStatementRequest{
+				WarehouseID: this.WarehouseID,
+				Statement:   this.Statement,
+				Parameters:  this.Parameters,
+				WaitTimeout: this.WaitTimeout,
+			}, d.Timeout(schema.TimeoutRead))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.StatementID = sr.StatementID
+			this.Status = sr.Status.State
+			this.Results = &statementResults{
+				Schema: []StatementColumn{},
+				Data:   []statementRow{},
+			}
+			if sr.Manifest != nil {
+				this.Results.Schema = sr.Manifest.Schema.Columns
+			}
+			rows, err := statementsAPI.Rows(sr)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, row := range rows {
+				this.Results.Data = append(this.Results.Data, statementRow{Values: row})
+			}
+			d.SetId(sr.StatementID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(DefaultStatementTimeout),
+		},
+	}
+}
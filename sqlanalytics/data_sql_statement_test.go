@@ -0,0 +1,179 @@
+package sqlanalytics
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLStatementDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/sql/statements",
+				ExpectedRequest: ExecuteStatementRequest{
+					WarehouseID: "abc",
+					Statement:   "SELECT name, age FROM people WHERE age > :age",
+					Parameters: []StatementParameter{
+						{
+							Name:  "age",
+							Value: "30",
+							Type:  "INT",
+						},
+					},
+					WaitTimeout:   "30s",
+					OnWaitTimeout: "CONTINUE",
+					Disposition:   "INLINE",
+					Format:        "JSON_ARRAY",
+				},
+				Response: StatementResponse{
+					StatementID: "01ed",
+					Status: StatementStatus{
+						State: StatementStatePending,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/statements/01ed",
+				Response: StatementResponse{
+					StatementID: "01ed",
+					Status: StatementStatus{
+						State: StatementStateSucceeded,
+					},
+					Manifest: &StatementManifest{
+						Schema: StatementResultSchema{
+							Columns: []StatementColumn{
+								{Name: "name", TypeName: "STRING", Position: 0},
+								{Name: "age", TypeName: "INT", Position: 1},
+							},
+						},
+					},
+					Result: &StatementResult{
+						DataArray: [][]string{
+							{"Alice", "35"},
+							{"Bob", "42"},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceSQLStatement(),
+		NonWritable: true,
+		HCL: `
+		warehouse_id = "abc"
+		statement = "SELECT name, age FROM people WHERE age > :age"
+		parameters {
+			name = "age"
+			value = "30"
+			type = "INT"
+		}`,
+		ID: "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "01ed", d.Id())
+	assert.Equal(t, "01ed", d.Get("statement_id"))
+	assert.Equal(t, "SUCCEEDED", d.Get("status"))
+	assert.Equal(t, "age", d.Get("results.0.schema.1.name"))
+	assert.Equal(t, "INT", d.Get("results.0.schema.1.type_name"))
+	assert.Equal(t, 2, d.Get("results.0.data.#"))
+	assert.Equal(t, []interface{}{"Bob", "42"}, d.Get("results.0.data.1.values"))
+}
+
+func TestSQLStatementDataSource_Chunks(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/sql/statements",
+				Response: StatementResponse{
+					StatementID: "01ed",
+					Status: StatementStatus{
+						State: StatementStateSucceeded,
+					},
+					Result: &StatementResult{
+						NextChunkIndex: 1,
+						DataArray: [][]string{
+							{"Alice"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/statements/01ed/result/chunks/1",
+				Response: StatementResult{
+					ChunkIndex:     1,
+					NextChunkIndex: 2,
+					DataArray: [][]string{
+						{"Bob"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/statements/01ed/result/chunks/2",
+				Response: StatementResult{
+					ChunkIndex: 2,
+					DataArray: [][]string{
+						{"Carol"},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceSQLStatement(),
+		NonWritable: true,
+		HCL: `
+		warehouse_id = "abc"
+		statement = "SELECT name FROM people"`,
+		ID: "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("results.0.data.#"))
+	assert.Equal(t, []interface{}{"Carol"}, d.Get("results.0.data.2.values"))
+}
+
+func TestSQLStatementDataSource_Failed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/sql/statements",
+				Response: StatementResponse{
+					StatementID: "01ed",
+					Status: StatementStatus{
+						State: StatementStateFailed,
+						Error: &StatementError{
+							ErrorCode: "BAD_REQUEST",
+							Message:   "Table or view not found: people",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceSQLStatement(),
+		NonWritable: true,
+		HCL: `
+		warehouse_id = "abc"
+		statement = "SELECT * FROM people"`,
+		ID: "_",
+	}.ExpectError(t, "statement 01ed is FAILED: Table or view not found: people")
+}
+
+func TestSQLStatementDataSource_InvalidWaitTimeout(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceSQLStatement(),
+		NonWritable: true,
+		HCL: `
+		warehouse_id = "abc"
+		statement = "SELECT 1"
+		wait_timeout = "3s"`,
+		ID: "_",
+	}.ExpectError(t, "invalid config supplied. [wait_timeout] invalid value for wait_timeout (wait_timeout must be 0s or between 5s and 50s)")
+}
@@ -237,7 +237,7 @@ func TestDeletedMountClusterRecreates(t *testing.T) {
 		{
 			Method:       "GET",
 			ReuseRequest: true,
-			Resource:     "/api/2.0/clusters/list",
+			Resource:     "/api/2.1/clusters/list?page_size=100",
 			Response:     map[string]interface{}{},
 		},
 		{
@@ -174,12 +174,12 @@ func TestResourceAwsS3MountGenericCreate_WithInstanceProfile(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list?page_size=100",
 				Response: map[string]interface{}{},
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list?page_size=100",
 				Response: compute.ClusterList{
 					Clusters: []compute.ClusterInfo{
 						{
@@ -1447,12 +1447,12 @@ func TestResourceGcsMountGenericCreate_WithServiceAccount(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list?page_size=100",
 				Response: map[string]interface{}{},
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/clusters/list",
+				Resource: "/api/2.1/clusters/list?page_size=100",
 				Response: compute.ClusterList{
 					Clusters: []compute.ClusterInfo{
 						{
@@ -0,0 +1,108 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+)
+
+// GitCredential is the personal access token for Git provider, that is used by Repos
+type GitCredential struct {
+	CredentialID        int64  `json:"credential_id,omitempty"`
+	GitProvider         string `json:"git_provider"`
+	GitUsername         string `json:"git_username,omitempty"`
+	PersonalAccessToken string `json:"personal_access_token,omitempty"`
+}
+
+type gitCredentialList struct {
+	Credentials []GitCredential `json:"credentials,omitempty"`
+}
+
+// NewGitCredentialsAPI creates GitCredentialsAPI instance from provider meta
+func NewGitCredentialsAPI(ctx context.Context, m interface{}) GitCredentialsAPI {
+	return GitCredentialsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// GitCredentialsAPI exposes the Git credentials API
+type GitCredentialsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// List returns Git credentials of the current user without tokens
+func (a GitCredentialsAPI) List() ([]GitCredential, error) {
+	var gcl gitCredentialList
+	err := a.client.Get(a.context, "/git-credentials", nil, &gcl)
+	return gcl.Credentials, err
+}
+
+// Create adds Git credential for the current user
+func (a GitCredentialsAPI) Create(gc GitCredential) (GitCredential, error) {
+	var created GitCredential
+	err := a.client.Post(a.context, "/git-credentials", gc, &created)
+	return created, err
+}
+
+// Update replaces Git provider, username and token of the credential
+func (a GitCredentialsAPI) Update(gc GitCredential) error {
+	return a.client.Patch(a.context, fmt.Sprintf("/git-credentials/%d", gc.CredentialID), GitCredential{
+		GitProvider:         gc.GitProvider,
+		GitUsername:         gc.GitUsername,
+		PersonalAccessToken: gc.PersonalAccessToken,
+	})
+}
+
+type gitCredentialsCache struct {
+	mu         sync.Mutex
+	configured map[*common.DatabricksClient]string
+}
+
+var providerGitCredentials = &gitCredentialsCache{
+	configured: map[*common.DatabricksClient]string{},
+}
+
+// EnsureProviderCredentials creates or updates Git credential of the current user with
+// `git_username` and `git_token` from provider configuration, so that all repositories
+// could be cloned with them. Only one Git credential is allowed per user, so the existing
+// one is replaced. It's done once per Git provider and provider process, as host of
+// the workspace may not be known during provider initialization.
+func (a GitCredentialsAPI) EnsureProviderCredentials(gitProvider string) error {
+	if a.client.GitToken == "" {
+		return nil
+	}
+	providerGitCredentials.mu.Lock()
+	defer providerGitCredentials.mu.Unlock()
+	if strings.EqualFold(providerGitCredentials.configured[a.client], gitProvider) {
+		return nil
+	}
+	gc := GitCredential{
+		GitProvider:         gitProvider,
+		GitUsername:         a.client.GitUsername,
+		PersonalAccessToken: a.client.GitToken,
+	}
+	existing, err := a.List()
+	if err != nil {
+		return fmt.Errorf("cannot list git credentials: %w", err)
+	}
+	if len(existing) > 0 {
+		gc.CredentialID = existing[0].CredentialID
+		if !strings.EqualFold(existing[0].GitProvider, gitProvider) {
+			log.Printf("[WARN] Replacing git credential %d for %s with %s from provider configuration. "+
+				"Repos of %s can no longer be pulled with it", gc.CredentialID,
+				existing[0].GitProvider, gitProvider, existing[0].GitProvider)
+		}
+		log.Printf("[INFO] Updating git credential %d with provider configuration", gc.CredentialID)
+		err = a.Update(gc)
+	} else {
+		_, err = a.Create(gc)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot configure git credentials: %w", err)
+	}
+	providerGitCredentials.configured[a.client] = gitProvider
+	return nil
+}
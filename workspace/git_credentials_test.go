@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureProviderCredentials_NotConfigured(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := NewGitCredentialsAPI(ctx, client).EnsureProviderCredentials("gitHub")
+		assert.NoError(t, err)
+	})
+}
+
+func TestEnsureProviderCredentials_Create(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/git-credentials",
+			Response: gitCredentialList{},
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/git-credentials",
+			ExpectedRequest: GitCredential{
+				GitProvider:         "gitHub",
+				GitUsername:         "octocat",
+				PersonalAccessToken: "ghp_abc",
+			},
+			Response: GitCredential{
+				CredentialID: 121,
+				GitProvider:  "gitHub",
+				GitUsername:  "octocat",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.GitUsername = "octocat"
+		client.GitToken = "ghp_abc"
+		gitCredentialsAPI := NewGitCredentialsAPI(ctx, client)
+		err := gitCredentialsAPI.EnsureProviderCredentials("gitHub")
+		assert.NoError(t, err)
+
+		// credentials are configured only once per provider process
+		err = gitCredentialsAPI.EnsureProviderCredentials("gitHub")
+		assert.NoError(t, err)
+	})
+}
+
+func TestEnsureProviderCredentials_UpdateExisting(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/git-credentials",
+			Response: gitCredentialList{
+				Credentials: []GitCredential{
+					{
+						CredentialID: 121,
+						GitProvider:  "gitLab",
+						GitUsername:  "someone",
+					},
+				},
+			},
+		},
+		{
+			Method:   http.MethodPatch,
+			Resource: "/api/2.0/git-credentials/121",
+			ExpectedRequest: GitCredential{
+				GitProvider:         "gitHub",
+				GitUsername:         "octocat",
+				PersonalAccessToken: "ghp_abc",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.GitUsername = "octocat"
+		client.GitToken = "ghp_abc"
+		err := NewGitCredentialsAPI(ctx, client).EnsureProviderCredentials("gitHub")
+		assert.NoError(t, err)
+	})
+}
+
+func TestEnsureProviderCredentials_Error(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/git-credentials",
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "Repos are disabled",
+			},
+			Status: 403,
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.GitToken = "ghp_abc"
+		err := NewGitCredentialsAPI(ctx, client).EnsureProviderCredentials("gitHub")
+		qa.AssertErrorStartsWith(t, err, "cannot list git credentials: Repos are disabled")
+	})
+}
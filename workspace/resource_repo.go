@@ -52,10 +52,14 @@ func (a ReposAPI) Create(r createRequest) (ReposInformation, error) {
 	if r.Provider == "" {
 		return resp, fmt.Errorf("git_provider isn't specified and we can't detect provider from URL")
 	}
+	if r.Path != "" && !strings.HasPrefix(r.Path, "/Repos/") {
+		return resp, fmt.Errorf("path should start with /Repos/")
+	}
+	err := NewGitCredentialsAPI(a.context, a.client).EnsureProviderCredentials(r.Provider)
+	if err != nil {
+		return resp, err
+	}
 	if r.Path != "" {
-		if !strings.HasPrefix(r.Path, "/Repos/") {
-			return resp, fmt.Errorf("path should start with /Repos/")
-		}
 		p := r.Path
 		if strings.HasSuffix(r.Path, "/") {
 			p = strings.TrimSuffix(r.Path, "/")
@@ -66,7 +70,7 @@ func (a ReposAPI) Create(r createRequest) (ReposInformation, error) {
 		}
 	}
 
-	err := a.client.Post(a.context, "/repos", r, &resp)
+	err = a.client.Post(a.context, "/repos", r, &resp)
 	return resp, err
 }
 
@@ -74,10 +78,18 @@ func (a ReposAPI) Delete(id string) error {
 	return a.client.Delete(a.context, fmt.Sprintf("/repos/%s", id), nil)
 }
 
-func (a ReposAPI) Update(id string, r map[string]string) error {
+// Update changes path, branch or tag of the repo. Credentials from provider configuration
+// are applied first, so that checkout of existing repo uses the rotated `git_token`.
+func (a ReposAPI) Update(id, provider string, r map[string]string) error {
 	if len(r) == 0 {
 		return nil
 	}
+	if provider != "" {
+		err := NewGitCredentialsAPI(a.context, a.client).EnsureProviderCredentials(provider)
+		if err != nil {
+			return err
+		}
+	}
 	// TODO: update may change ONE OF (url AND provider (optional)), (path), or (branch OR tag).
 	// for URL/provider force re-create as there are limits on what could be done for changing URL/provider
 	if path, ok := r["path"]; ok {
@@ -181,7 +193,7 @@ func ResourceRepo() *schema.Resource {
 			} else if branch != "" && branch != resp.Branch {
 				updateReq["branch"] = branch
 			}
-			return reposAPI.Update(d.Id(), updateReq)
+			return reposAPI.Update(d.Id(), req.Provider, updateReq)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			reposAPI := NewReposAPI(ctx, c)
@@ -204,7 +216,11 @@ func ResourceRepo() *schema.Resource {
 			} else if d.HasChange("branch") {
 				req["branch"] = d.Get("branch").(string)
 			}
-			return reposAPI.Update(d.Id(), req)
+			provider := d.Get("git_provider").(string)
+			if provider == "" {
+				provider = getProviderFromUrl(d.Get("url").(string))
+			}
+			return reposAPI.Update(d.Id(), provider, req)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewReposAPI(ctx, c).Delete(d.Id())
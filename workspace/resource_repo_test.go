@@ -225,6 +225,17 @@ func TestResourceRepoCreateCustomDirectoryWrongLocation(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "path should start with /Repos/")
 }
 
+func TestReposAPICreate_WrongLocationBeforeGitCredentials(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.GitToken = "ghp_abc"
+		_, err := NewReposAPI(ctx, client).Create(createRequest{
+			Url:  "https://github.com/user/test.git",
+			Path: "/NotRepos/Production/test/",
+		})
+		qa.AssertErrorStartsWith(t, err, "path should start with /Repos/")
+	})
+}
+
 func TestResourceRepoCreateWithBranch(t *testing.T) {
 	resp := ReposInformation{
 		ID:           121232342,
@@ -489,3 +500,41 @@ func TestReposListWithPrefix(t *testing.T) {
 	assert.Equal(t, len(reposList), 1)
 	assert.Equal(t, resp.Branch, reposList[0].Branch)
 }
+
+func TestReposAPIUpdate_RotatedGitToken(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/git-credentials",
+			Response: gitCredentialList{
+				Credentials: []GitCredential{
+					{
+						CredentialID: 121,
+						GitProvider:  "gitHub",
+						GitUsername:  "octocat",
+					},
+				},
+			},
+		},
+		{
+			Method:   "PATCH",
+			Resource: "/api/2.0/git-credentials/121",
+			ExpectedRequest: GitCredential{
+				GitProvider:         "gitHub",
+				GitUsername:         "octocat",
+				PersonalAccessToken: "ghp_rotated",
+			},
+		},
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/repos/121232342",
+			ExpectedRequest: map[string]interface{}{"branch": "releases"},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.GitUsername = "octocat"
+		client.GitToken = "ghp_rotated"
+		err := NewReposAPI(ctx, client).Update("121232342", "gitHub",
+			map[string]string{"branch": "releases"})
+		assert.NoError(t, err)
+	})
+}